@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// lastAppliedYAML renders the configuration kubectl apply recorded on an object, for comparing it with the live YAML
+// Objects never applied with kubectl get a YAML comment saying so, which reads naturally in the YAML view
+func lastAppliedYAML(kind, name string, annotations map[string]string) (string, error) {
+	applied, ok := annotations[corev1.LastAppliedConfigAnnotation]
+	if !ok {
+		return fmt.Sprintf("# %s %s has no %s annotation.\n"+
+			"# It wasn't created or updated with kubectl apply; controllers and server-side apply don't set it.\n",
+			kind, name, corev1.LastAppliedConfigAnnotation), nil
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(applied), &obj); err != nil {
+		return "", fmt.Errorf("failed to parse the last applied configuration of %s %s: %w", kind, name, err)
+	}
+	b, err := yaml.Marshal(obj)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the last applied configuration of %s %s to yaml: %w", kind, name, err)
+	}
+	return string(b), nil
+}
+
+// fetchPodLastApplied retrieves the last applied configuration of a pod
+func (m *Model) fetchPodLastApplied(namespace, podName string) (string, error) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	pod, err := m.kubeClient.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get pod %s: %w", podName, err)
+	}
+	return lastAppliedYAML("pod", podName, pod.Annotations)
+}
+
+// fetchEtcdLastApplied retrieves the last applied configuration of the Etcd custom resource
+func (m *Model) fetchEtcdLastApplied() (string, error) {
+	etcd, err := m.fetchEtcdResource()
+	if err != nil {
+		return "", err
+	}
+	return lastAppliedYAML("Etcd", m.etcdName, etcd.GetAnnotations())
+}
+
+// toggleLastApplied switches the YAML view between the live object and its last applied configuration
+func (m *Model) toggleLastApplied() tea.Cmd {
+	m.lastApplied = !m.lastApplied
+	if m.yamlEtcd {
+		return m.loadEtcdYAML()
+	}
+	return m.loadPodYAML()
+}
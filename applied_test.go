@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestLastAppliedYAML(t *testing.T) {
+	annotations := map[string]string{
+		corev1.LastAppliedConfigAnnotation: `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"etcd-main-0"},"spec":{"containers":[{"image":"etcd:v3.5","name":"etcd"}]}}`,
+	}
+	got, err := lastAppliedYAML("pod", "etcd-main-0", annotations)
+	if err != nil {
+		t.Fatalf("lastAppliedYAML() error = %v", err)
+	}
+	for _, want := range []string{"kind: Pod\n", "  name: etcd-main-0\n", "  - image: etcd:v3.5\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("lastAppliedYAML() missing %q:\n%s", want, got)
+		}
+	}
+
+	got, err = lastAppliedYAML("pod", "etcd-main-0", nil)
+	if err != nil || !strings.HasPrefix(got, "# pod etcd-main-0 has no "+corev1.LastAppliedConfigAnnotation) {
+		t.Errorf("lastAppliedYAML() without the annotation = %q, %v, want a note", got, err)
+	}
+
+	annotations[corev1.LastAppliedConfigAnnotation] = "{not json"
+	if _, err := lastAppliedYAML("pod", "etcd-main-0", annotations); err == nil {
+		t.Error("lastAppliedYAML() of a corrupt annotation succeeded")
+	}
+}
+
+func TestToggleLastApplied(t *testing.T) {
+	pod := testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))
+	pod.Annotations = map[string]string{corev1.LastAppliedConfigAnnotation: `{"kind":"Pod","metadata":{"name":"etcd-main-0","labels":{"app":"etcd"}}}`}
+	m, _ := newTestModel([]runtime.Object{pod})
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("y"))
+
+	next, cmd := m.Update(keyMsg("a"))
+	m = next.(Model)
+	if !m.lastApplied || !strings.Contains(m.View(), "[last applied]") {
+		t.Fatalf("a did not switch to the last applied configuration:\n%s", m.View())
+	}
+	m = update(t, m, cmd())
+	if !strings.Contains(m.content, "app: etcd") || strings.Contains(m.content, "status:") {
+		t.Errorf("content = %q, want only the applied configuration", m.content)
+	}
+
+	// Opening the YAML view again starts from the live object
+	m = update(t, m, keyMsg("esc"))
+	m = update(t, m, keyMsg("y"))
+	if m.lastApplied {
+		t.Error("the YAML view reopened on the last applied configuration")
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+)
+
+// usage is printed by -h and whenever the arguments don't fit one of the invocations, followed by the flags
+const usage = `Usage:
+  etcd-pod-viewer [flags] [<namespace> [<etcd-name>]]
+  etcd-pod-viewer [flags] --all-namespaces <etcd-name>
+  etcd-pod-viewer [flags] --namespace-label-selector <selector>
+
+Without <etcd-name> the Etcds of the namespace are offered to pick from, and
+without <namespace> too the namespace of the kubeconfig's current context is used.
+--output and --dashboard show a single Etcd, they need both arguments.
+
+Flags:
+`
+
+// printUsage writes the usage and the flags, replacing the flag package's terse default
+func printUsage() {
+	fmt.Fprint(flag.CommandLine.Output(), usage)
+	flag.PrintDefaults()
+}
+
+// launchArgs is what the positional arguments ask for
+type launchArgs struct {
+	namespace string
+	etcdName  string
+	// currentNamespace is set when no namespace was given, the kubeconfig's is used then; see kubeConfigNamespace
+	currentNamespace bool
+}
+
+// parseLaunchArgs reads the positional arguments for the mode the flags chose
+// needsEtcd is set for --output and --dashboard, which can't pick the Etcd in the TUI
+func parseLaunchArgs(args []string, allNamespaces, namespaceSelector, needsEtcd bool) (launchArgs, error) {
+	switch {
+	case namespaceSelector:
+		// The namespace comes with the Etcd picked in the TUI
+		if len(args) > 0 {
+			return launchArgs{}, errors.New("--namespace-label-selector picks the etcd in the TUI, it takes no arguments")
+		}
+		return launchArgs{}, nil
+	case allNamespaces:
+		if len(args) != 1 {
+			return launchArgs{}, errors.New("--all-namespaces takes just the <etcd-name>")
+		}
+		return launchArgs{etcdName: args[0]}, nil
+	case len(args) > 2:
+		return launchArgs{}, fmt.Errorf("too many arguments: %q", args[2:])
+	case len(args) == 2:
+		return launchArgs{namespace: args[0], etcdName: args[1]}, nil
+	case needsEtcd:
+		return launchArgs{}, errors.New("--output and --dashboard need both <namespace> and <etcd-name>")
+	case len(args) == 1:
+		return launchArgs{namespace: args[0]}, nil
+	}
+	return launchArgs{currentNamespace: true}, nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func TestParseLaunchArgs(t *testing.T) {
+	tests := []struct {
+		name              string
+		args              []string
+		allNamespaces     bool
+		namespaceSelector bool
+		needsEtcd         bool
+		want              launchArgs
+		wantErr           bool
+	}{
+		{name: "namespace and etcd", args: []string{"shoot--foo", "etcd-main"}, want: launchArgs{namespace: "shoot--foo", etcdName: "etcd-main"}},
+		{name: "namespace only", args: []string{"shoot--foo"}, want: launchArgs{namespace: "shoot--foo"}},
+		{name: "no arguments", want: launchArgs{currentNamespace: true}},
+		{name: "too many", args: []string{"a", "b", "c"}, wantErr: true},
+		{name: "output with both", args: []string{"shoot--foo", "etcd-main"}, needsEtcd: true, want: launchArgs{namespace: "shoot--foo", etcdName: "etcd-main"}},
+		{name: "output without etcd", args: []string{"shoot--foo"}, needsEtcd: true, wantErr: true},
+		{name: "output without arguments", needsEtcd: true, wantErr: true},
+		{name: "all namespaces", args: []string{"etcd-main"}, allNamespaces: true, want: launchArgs{etcdName: "etcd-main"}},
+		{name: "all namespaces without etcd", allNamespaces: true, wantErr: true},
+		{name: "all namespaces with namespace", args: []string{"shoot--foo", "etcd-main"}, allNamespaces: true, wantErr: true},
+		{name: "namespace selector", namespaceSelector: true},
+		{name: "namespace selector with arguments", args: []string{"shoot--foo"}, namespaceSelector: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLaunchArgs(tt.args, tt.allNamespaces, tt.namespaceSelector, tt.needsEtcd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseLaunchArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseLaunchArgs() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKubeConfigNamespace(t *testing.T) {
+	kubeconfig := `apiVersion: v1
+kind: Config
+current-context: garden
+contexts:
+- name: garden
+  context: {cluster: garden, namespace: shoot--foo}
+- name: seed
+  context: {cluster: garden}
+clusters:
+- name: garden
+  cluster: {server: https://garden.example.com}
+`
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	if err := os.WriteFile(path, []byte(kubeconfig), 0o600); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+	t.Setenv("KUBECONFIG", path)
+
+	if got, err := kubeConfigNamespace(&clientcmd.ConfigOverrides{}); err != nil || got != "shoot--foo" {
+		t.Errorf("kubeConfigNamespace() = %q, %v, want shoot--foo", got, err)
+	}
+	// A context without a namespace means the default one, like kubectl
+	if got, err := kubeConfigNamespace(&clientcmd.ConfigOverrides{CurrentContext: "seed"}); err != nil || got != "default" {
+		t.Errorf("kubeConfigNamespace() of seed = %q, %v, want default", got, err)
+	}
+}
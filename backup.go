@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// backupReadyCondition is the Etcd status condition etcd-druid sets from the snapshot leases
+const backupReadyCondition = "BackupReady"
+
+// etcdCondition mirrors an entry of the Etcd resource's .status.conditions
+type etcdCondition struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason,omitempty"`
+	Message            string `json:"message,omitempty"`
+	LastUpdateTime     string `json:"lastUpdateTime,omitempty"`
+	LastTransitionTime string `json:"lastTransitionTime,omitempty"`
+}
+
+// etcdStatusConditions is the part of the Etcd resource status holding the conditions
+type etcdStatusConditions struct {
+	Status struct {
+		Conditions []etcdCondition `json:"conditions"`
+	} `json:"status"`
+}
+
+// backupSummaryMsg carries the one-line backup health shown above the pod list
+type backupSummaryMsg struct{ summary string }
+
+// isBackupContainer reports whether a container is the backup-restore sidecar
+// etcd-druid names it backup-restore, so anything mentioning "backup" matches unless --backup-container is set
+func (m *Model) isBackupContainer(name string) bool {
+	if m.backupContainer != "" {
+		return name == m.backupContainer
+	}
+	return strings.Contains(name, "backup")
+}
+
+// backupSidecarState renders the readiness of the backup sidecar among a pod's container statuses
+// It is empty when the pod has no sidecar, e.g. for an Etcd without a backup store
+func (m *Model) backupSidecarState(statuses []corev1.ContainerStatus) string {
+	for _, status := range statuses {
+		if !m.isBackupContainer(status.Name) {
+			continue
+		}
+		if status.Ready {
+			return "ready"
+		}
+		return "not ready"
+	}
+	return ""
+}
+
+// fetchBackupSummary describes the backup health reported on the Etcd resource
+// etcd-druid doesn't put the snapshot revision in the status, so the condition is the best summary available
+func (m *Model) fetchBackupSummary() (string, error) {
+	etcd, err := m.fetchEtcdResource()
+	if err != nil {
+		return "", err
+	}
+
+	var decoded etcdStatusConditions
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(etcd.Object, &decoded); err != nil {
+		return "", fmt.Errorf("failed to decode conditions of Etcd %s/%s: %w", m.namespace, m.etcdName, err)
+	}
+
+	return summarizeBackup(decoded.Status.Conditions, m.formatTimestamp), nil
+}
+
+// summarizeBackup renders the BackupReady condition in one line, e.g. "Backup: True (FullSnapshotTaken), updated 5m0s ago"
+// formatTime renders the update time, see formatTimestamp
+func summarizeBackup(conditions []etcdCondition, formatTime func(time.Time) string) string {
+	for _, condition := range conditions {
+		if condition.Type != backupReadyCondition {
+			continue
+		}
+		summary := fmt.Sprintf("Backup: %s", condition.Status)
+		if condition.Reason != "" {
+			summary += fmt.Sprintf(" (%s)", condition.Reason)
+		}
+		if updated, err := time.Parse(time.RFC3339, condition.LastUpdateTime); err == nil {
+			summary += fmt.Sprintf(", updated %s", formatTime(updated))
+		}
+		if condition.Message != "" {
+			summary += " - " + condition.Message
+		}
+		return summary
+	}
+	return "Backup: no BackupReady condition reported"
+}
+
+// loadBackupSummary is a command that fetches the backup health asynchronously
+// Failures leave the summary out instead of replacing the pod list with an error
+func (m *Model) loadBackupSummary() tea.Cmd {
+	// Each namespace has its own backups, so there is nothing to summarize across them
+	if m.allNamespaces {
+		return nil
+	}
+	return func() tea.Msg {
+		summary, err := retryFetch(m.fetchBackupSummary)
+		if err != nil {
+			return backupSummaryMsg{}
+		}
+		return backupSummaryMsg{summary}
+	}
+}
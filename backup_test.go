@@ -0,0 +1,56 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestBackupSidecarState(t *testing.T) {
+	statuses := []corev1.ContainerStatus{
+		{Name: "etcd", Ready: true},
+		{Name: "backup-restore", Ready: false},
+		{Name: "snapshotter", Ready: true},
+	}
+
+	m, _ := newTestModel(nil)
+	if got := m.backupSidecarState(statuses); got != "not ready" {
+		t.Errorf("backupSidecarState() = %q, want not ready from the name heuristic", got)
+	}
+	if got := m.backupSidecarState(statuses[:1]); got != "" {
+		t.Errorf("backupSidecarState() without a sidecar = %q, want empty", got)
+	}
+
+	m.backupContainer = "snapshotter"
+	if got := m.backupSidecarState(statuses); got != "ready" {
+		t.Errorf("backupSidecarState() with an override = %q, want ready", got)
+	}
+}
+
+func TestFetchBackupSummary(t *testing.T) {
+	etcd := testEtcdWithMembers()
+	updated := time.Now().Add(-5 * time.Minute).UTC().Format(time.RFC3339)
+	etcd.Object["status"].(map[string]interface{})["conditions"] = []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "True"},
+		map[string]interface{}{
+			"type": "BackupReady", "status": "False", "reason": "BackupFailed",
+			"message": "Stale snapshot leases", "lastUpdateTime": updated,
+		},
+	}
+	m, _ := newTestModel(nil, etcd)
+
+	got, err := m.fetchBackupSummary()
+	if err != nil {
+		t.Fatalf("fetchBackupSummary() error = %v", err)
+	}
+	if !strings.HasPrefix(got, "Backup: False (BackupFailed), updated 5m") || !strings.HasSuffix(got, "- Stale snapshot leases") {
+		t.Errorf("fetchBackupSummary() = %q", got)
+	}
+
+	m, _ = newTestModel(nil, testEtcdWithMembers())
+	if got, _ := m.fetchBackupSummary(); !strings.Contains(got, "no BackupReady condition") {
+		t.Errorf("fetchBackupSummary() without the condition = %q", got)
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// crumbLevel is a level of the breadcrumb above every screen, from the cluster down to a container
+type crumbLevel int
+
+const (
+	crumbCluster crumbLevel = iota
+	crumbNamespace
+	crumbEtcd
+	crumbPod
+	crumbContainer
+)
+
+// crumbSeparator sits between the levels of the breadcrumb
+const crumbSeparator = " › "
+
+// crumbKey maps the number key of a breadcrumb level, 1 for the cluster, to the level
+func crumbKey(key string) (crumbLevel, bool) {
+	if len(key) != 1 || key[0] < '1' || key[0] > '1'+byte(crumbContainer) {
+		return 0, false
+	}
+	return crumbLevel(key[0] - '1'), true
+}
+
+// currentCrumb returns the level the current screen is about
+// The views of the whole Etcd, such as metrics or the cluster logs, sit at the Etcd even though they may use a pod
+func (m *Model) currentCrumb() crumbLevel {
+	switch m.state {
+	case DiagnosticsState:
+		if m.startupChecks {
+			return crumbCluster
+		}
+	case EtcdSelectState:
+		if m.namespaceSelector != "" {
+			return crumbCluster
+		}
+		return crumbNamespace
+	case LogState:
+		if m.mergedLogs {
+			return crumbPod
+		}
+		return crumbContainer
+	case DescribeState:
+		switch {
+		case m.describeEtcd:
+			return crumbEtcd
+		case m.describeOne != "":
+			return crumbContainer
+		}
+		return crumbPod
+	case YamlState:
+		if m.yamlEtcd {
+			return crumbEtcd
+		}
+		return crumbPod
+	case ContainerSelectState, NodeState, CertsState:
+		return crumbPod
+	}
+	return crumbEtcd
+}
+
+// breadcrumbs labels every level from the cluster down to the current one
+func (m *Model) breadcrumbs() []string {
+	cluster := m.contextName
+	if cluster == "" {
+		cluster = "cluster"
+	}
+	container := m.describeOne
+	if container == "" {
+		container = m.currentContainer()
+	}
+	labels := []string{cluster, m.namespaceLabel(), m.etcdName, m.podDisplayName(m.selectedPod), container}
+	return labels[:m.currentCrumb()+1]
+}
+
+// crumbText renders one level without styling, numbered by the key that jumps to it
+func crumbText(level int, label string) string {
+	return fmt.Sprintf("%d %s", level+1, label)
+}
+
+// breadcrumbView renders the breadcrumb, highlighting the current level
+func (m *Model) breadcrumbView() string {
+	labels := m.breadcrumbs()
+	crumbs := make([]string, len(labels))
+	for i, label := range labels {
+		style := m.theme.crumb
+		if i == len(labels)-1 {
+			style = m.theme.crumbCurrent
+		}
+		crumbs[i] = style.Render(crumbText(i, label))
+	}
+	return strings.Join(crumbs, m.theme.crumb.Render(crumbSeparator))
+}
+
+// crumbAt returns the level drawn at column x of the breadcrumb, for clicks
+func (m *Model) crumbAt(x int) (crumbLevel, bool) {
+	start := 0
+	for i, label := range m.breadcrumbs() {
+		end := start + lipgloss.Width(crumbText(i, label))
+		if x >= start && x < end {
+			return crumbLevel(i), true
+		}
+		start = end + lipgloss.Width(crumbSeparator)
+	}
+	return 0, false
+}
+
+// jumpToCrumb goes straight back to the screen of a breadcrumb level, forgetting what was selected below it
+// Levels at or below the current one leave the screen as it is
+func (m *Model) jumpToCrumb(level crumbLevel) tea.Cmd {
+	if level >= m.currentCrumb() {
+		return nil
+	}
+
+	switch level {
+	case crumbCluster, crumbNamespace:
+		if level == crumbCluster && m.namespaceSelector == "" {
+			return m.setStatus("the cluster comes from the kube context, restart with --context to switch it")
+		}
+		if ok, cmd := m.guardSingleNamespace("picking an etcd"); !ok {
+			return cmd
+		}
+		return m.reselectEtcd()
+	case crumbEtcd:
+		m.resetCrumbs(ListState)
+		m.selectedPod = Pod{}
+		return nil
+	}
+
+	// The pod is described, the screen of a pod without a container
+	m.resetCrumbs(DescribeState)
+	m.navStack = []AppState{ListState}
+	return tea.Batch(m.startLoading(m.describeLoadingText()), m.loadDescribe())
+}
+
+// reselectEtcd forgets the Etcd and its pods and lists the Etcd resources of the namespace to pick another
+func (m *Model) reselectEtcd() tea.Cmd {
+	m.resetCrumbs(EtcdSelectState)
+	m.etcdName = ""
+	m.pods = nil
+	m.list.SetItems(nil)
+	m.backupSummary = ""
+	m.diffMarks = nil
+	return m.loadEtcdNames()
+}
+
+// resetCrumbs switches to state with an empty navigation stack, dropping the container and view selections
+func (m *Model) resetCrumbs(state AppState) {
+	m.stopEventsWatch()
+	m.stopLogStream()
+	m.stopClusterLogs()
+	m.content = ""
+	m.tabbed = nil
+	m.navStack = nil
+	m.containers = nil
+	m.describeOne = ""
+	m.describeEtcd = false
+	m.yamlEtcd = false
+	m.mergedLogs = false
+	m.stopLoading()
+	m.state = state
+	m.layout()
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// logViewModel opens the logs of the etcd container of etcd-main-0
+func logViewModel(t *testing.T) Model {
+	t.Helper()
+	pod := testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))
+	m, _ := newTestModel([]runtime.Object{pod})
+	m.contextName = "garden"
+	m = update(t, m, tea.WindowSizeMsg{Width: 120, Height: 20})
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("l"))
+	m = update(t, m, containersLoadedMsg{[]Container{{Name: "etcd"}}})
+	m = update(t, m, logsLoadedMsg{content: "log line\n"})
+	if m.state != LogState {
+		t.Fatalf("state = %v, want the log view", m.state)
+	}
+	return m
+}
+
+func TestBreadcrumb(t *testing.T) {
+	m := logViewModel(t)
+	want := "1 garden › 2 " + testNamespace + " › 3 " + testEtcdName + " › 4 etcd-main-0 › 5 etcd"
+	if got := strings.SplitN(m.View(), "\n", 2)[0]; !strings.Contains(got, want) {
+		t.Errorf("breadcrumb = %q, want %q", got, want)
+	}
+
+	m = update(t, m, keyMsg("esc"))
+	if got := m.breadcrumbs(); len(got) != 3 || got[2] != testEtcdName {
+		t.Errorf("breadcrumbs in the list = %q, want down to the etcd", got)
+	}
+}
+
+func TestJumpToCrumb(t *testing.T) {
+	// 4 describes the pod, leaving the container behind
+	m := logViewModel(t)
+	next, cmd := m.Update(keyMsg("4"))
+	m = next.(Model)
+	if m.state != DescribeState || cmd == nil || len(m.containers) != 0 {
+		t.Errorf("4 went to %v with containers %v, want the pod described", m.state, m.containers)
+	}
+	m = update(t, m, keyMsg("esc"))
+	if m.state != ListState {
+		t.Errorf("esc from the jumped-to describe went to %v, want the list", m.state)
+	}
+
+	// 3 returns to the pod list with nothing to go back to
+	m = logViewModel(t)
+	m = update(t, m, keyMsg("3"))
+	if m.state != ListState || len(m.navStack) != 0 || m.selectedPod.Name != "" {
+		t.Errorf("3 went to %v, stack %v, pod %q, want the list with the pod dropped", m.state, m.navStack, m.selectedPod.Name)
+	}
+
+	// 2 picks another Etcd of the namespace
+	m = logViewModel(t)
+	m = update(t, m, keyMsg("2"))
+	if m.state != EtcdSelectState || m.etcdName != "" || len(m.pods) != 0 {
+		t.Errorf("2 went to %v with etcd %q, want the etcd selection", m.state, m.etcdName)
+	}
+
+	// The cluster comes from the kube context
+	m = logViewModel(t)
+	m = update(t, m, keyMsg("1"))
+	if m.state != LogState || !strings.Contains(m.status, "--context") {
+		t.Errorf("1 went to %v, status %q, want a notice", m.state, m.status)
+	}
+}
+
+func TestCrumbKeysWithOverlay(t *testing.T) {
+	m := logViewModel(t)
+	m = update(t, m, keyMsg("?"))
+	m = update(t, m, keyMsg("3"))
+	if m.state != LogState || !m.showKeys {
+		t.Errorf("3 with the keys overlay open: state %v, overlay %v, want the overlay kept", m.state, m.showKeys)
+	}
+}
+
+func TestLogHelpCrumbRange(t *testing.T) {
+	m := logViewModel(t)
+	if view := m.View(); !strings.Contains(view, "1-4: breadcrumb") {
+		t.Errorf("logs of a container don't offer 1-4:\n%s", view)
+	}
+	// Merged logs are about the pod, so 4 has nowhere to go
+	m.setMergedLogs(true)
+	if view := m.View(); !strings.Contains(view, "1-3: breadcrumb") {
+		t.Errorf("merged logs don't offer 1-3:\n%s", view)
+	}
+}
+
+func TestClickCrumb(t *testing.T) {
+	m := logViewModel(t)
+	crumbs := strings.SplitN(m.View(), "\n", 2)[0]
+	x := lipgloss.Width(crumbs[:strings.Index(crumbs, "3 "+testEtcdName)])
+	m = update(t, m, tea.MouseMsg{X: x + 2, Y: 0, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	if m.state != ListState {
+		t.Errorf("clicking the etcd went to %v, want the list", m.state)
+	}
+}
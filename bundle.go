@@ -0,0 +1,280 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// bundleContainer is a container whose logs go into the bundle
+// previous is set when it restarted, which is when the kubelet still has the logs of the instance before
+type bundleContainer struct {
+	name     string
+	previous bool
+}
+
+// bundleFile is one log file of the bundle, spooled to a temporary file at path until the archive is written
+// Logs of a long-running member can be far larger than the log view keeps, so they aren't held in memory
+type bundleFile struct {
+	name string
+	path string
+	size int64
+}
+
+// logBundle collects the logs of every container of a pod, one container per step so progress can be shown
+// It travels in the messages of the steps rather than in the Model, see bundleStepMsg
+type logBundle struct {
+	pod        Pod
+	namespace  string
+	containers []bundleContainer
+	next       int // index of the container fetched in the next step
+	files      []bundleFile
+	missing    []string // containers whose current logs couldn't be read, e.g. init containers that never ran
+}
+
+// logBundlePath is where the bundle of a pod is written, in the working directory
+func logBundlePath(podName string) string {
+	return podName + "-logs.tar.gz"
+}
+
+// fetchBundleContainers lists every container of the pod, init and debug containers included
+func (m *Model) fetchBundleContainers(namespace, podName string) ([]bundleContainer, error) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	pod, err := m.kubeClient.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod %s: %w", podName, err)
+	}
+	statuses := slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses, pod.Status.EphemeralContainerStatuses)
+	restarted := func(name string) bool {
+		for _, status := range statuses {
+			if status.Name == name {
+				return status.LastTerminationState.Terminated != nil
+			}
+		}
+		return false
+	}
+
+	var containers []bundleContainer
+	for _, c := range pod.Spec.InitContainers {
+		containers = append(containers, bundleContainer{name: c.Name, previous: restarted(c.Name)})
+	}
+	for _, c := range pod.Spec.Containers {
+		containers = append(containers, bundleContainer{name: c.Name, previous: restarted(c.Name)})
+	}
+	for _, c := range pod.Spec.EphemeralContainers {
+		containers = append(containers, bundleContainer{name: c.Name})
+	}
+	return containers, nil
+}
+
+// bundleStepMsg carries the bundle after the logs of one more container were added
+// A nil bundle with err set means the pod's containers couldn't be listed
+type bundleStepMsg struct {
+	bundle *logBundle
+	err    error
+}
+
+// bundleWrittenMsg reports the bundle written to disk
+type bundleWrittenMsg struct {
+	bundle logBundle
+	path   string
+	err    error
+}
+
+// startLogBundle begins bundling the logs of a pod, listing its containers first
+func (m *Model) startLogBundle(pod Pod) tea.Cmd {
+	if m.bundling != "" {
+		return m.setStatus("already bundling the logs of pod " + m.bundling)
+	}
+	m.bundling = pod.Name
+	m.bundleProgress = "listing containers"
+	m.beginOperation(bundleOperation(pod.Name))
+	namespace := m.podNamespace(pod)
+	return func() tea.Msg {
+		containers, err := retryFetch(func() ([]bundleContainer, error) {
+			return m.fetchBundleContainers(namespace, pod.Name)
+		})
+		if err != nil {
+			return bundleStepMsg{err: err}
+		}
+		return bundleStepMsg{bundle: &logBundle{pod: pod, namespace: namespace, containers: containers}}
+	}
+}
+
+// bundleNext updates the progress in the footer and fetches the next container, or writes the archive once all are in
+func (m *Model) bundleNext(msg bundleStepMsg) tea.Cmd {
+	if msg.err != nil {
+		m.endOperation(bundleOperation(m.bundling))
+		m.bundling, m.bundleProgress = "", ""
+		return m.setStatus(msg.err.Error())
+	}
+	bundle := *msg.bundle
+	if bundle.next < len(bundle.containers) {
+		m.bundleProgress = fmt.Sprintf("%d/%d containers", bundle.next, len(bundle.containers))
+		return m.bundleContainerLogs(bundle)
+	}
+	m.bundleProgress = "writing " + logBundlePath(bundle.pod.Name)
+	return m.writeLogBundle(bundle)
+}
+
+// bundleContainerLogs is a command that adds the current and, after a restart, the previous logs of a container
+// The previous logs are a bonus: when the kubelet no longer has them the container is bundled without
+func (m *Model) bundleContainerLogs(bundle logBundle) tea.Cmd {
+	container := bundle.containers[bundle.next]
+	return func() tea.Msg {
+		// The files are copied, the bundle of the previous step may still be referenced
+		bundle.files, bundle.missing = slices.Clone(bundle.files), slices.Clone(bundle.missing)
+		bundle.next++
+		current, err := m.spoolPodLogs(bundle.namespace, bundle.pod.Name, &corev1.PodLogOptions{Container: container.name, Timestamps: true})
+		if err != nil {
+			bundle.missing = append(bundle.missing, container.name)
+			return bundleStepMsg{bundle: &bundle}
+		}
+		current.name = container.name + ".log"
+		bundle.files = append(bundle.files, current)
+		if container.previous {
+			previous, err := m.spoolPodLogs(bundle.namespace, bundle.pod.Name, &corev1.PodLogOptions{Container: container.name, Timestamps: true, Previous: true})
+			if err == nil {
+				previous.name = container.name + ".previous.log"
+				bundle.files = append(bundle.files, previous)
+			}
+		}
+		return bundleStepMsg{bundle: &bundle}
+	}
+}
+
+// spoolPodLogs copies the whole log selected by opts into a temporary file
+// Unlike streamPodLogs it keeps every line, regardless of maxLogBytes and the time window of the log view
+// Only opening the stream is bounded by the request timeout; copying a long log or over a slow link runs until it ends or the viewer quits
+func (m *Model) spoolPodLogs(namespace, podName string, opts *corev1.PodLogOptions) (bundleFile, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var deadline *time.Timer
+	if m.requestTimeout > 0 {
+		deadline = time.AfterFunc(m.requestTimeout, cancel)
+	}
+	logs, err := m.kubeClient.CoreV1().Pods(namespace).GetLogs(podName, opts).Stream(ctx)
+	if deadline != nil && !deadline.Stop() {
+		// The timeout passed while opening and cancelled ctx, a stream that opened just in time can't be read anymore
+		if err == nil {
+			logs.Close()
+		}
+		err = context.DeadlineExceeded
+	}
+	if err != nil {
+		return bundleFile{}, fmt.Errorf("failed to get logs for pod %s (container %s): %w", podName, opts.Container, err)
+	}
+	defer logs.Close()
+
+	spool, err := os.CreateTemp("", "etcd-pod-viewer-bundle-*.log")
+	if err != nil {
+		return bundleFile{}, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer spool.Close()
+	size, err := io.Copy(spool, logs)
+	if err == nil {
+		err = spool.Close()
+	}
+	if err != nil {
+		os.Remove(spool.Name())
+		return bundleFile{}, fmt.Errorf("failed to read logs for pod %s (container %s): %w", podName, opts.Container, err)
+	}
+	return bundleFile{path: spool.Name(), size: size}, nil
+}
+
+// writeLogBundle is a command that writes the bundle as a gzipped tarball, the files under a directory named after the pod
+func (m *Model) writeLogBundle(bundle logBundle) tea.Cmd {
+	path := logBundlePath(bundle.pod.Name)
+	return func() tea.Msg {
+		err := writeTarball(path, bundle.pod.Name, bundle.files)
+		for _, file := range bundle.files {
+			os.Remove(file.path)
+		}
+		if abs, absErr := filepath.Abs(path); absErr == nil {
+			path = abs
+		}
+		return bundleWrittenMsg{bundle: bundle, path: path, err: err}
+	}
+}
+
+// writeTarball writes files into a gzipped tarball at path, under dir
+// The tarball is written next to path and renamed over it once complete, so a failure leaves an earlier bundle as it was
+func writeTarball(path, dir string, files []bundleFile) error {
+	out, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create log bundle: %w", err)
+	}
+	defer out.Close()
+	if err := writeTarballTo(out, dir, files); err != nil {
+		os.Remove(out.Name())
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(out.Name())
+		return fmt.Errorf("failed to write log bundle: %w", err)
+	}
+	if err := os.Rename(out.Name(), path); err != nil {
+		os.Remove(out.Name())
+		return fmt.Errorf("failed to write log bundle: %w", err)
+	}
+	return nil
+}
+
+// writeTarballTo writes the gzipped tarball of files to out, copying each from its spooled file
+func writeTarballTo(out io.Writer, dir string, files []bundleFile) error {
+	zw := gzip.NewWriter(out)
+	tw := tar.NewWriter(zw)
+	now := time.Now()
+	for _, file := range files {
+		if err := addTarFile(tw, dir+"/"+file.name, file, now); err != nil {
+			return fmt.Errorf("failed to write log bundle: %w", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to write log bundle: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to write log bundle: %w", err)
+	}
+	return nil
+}
+
+// addTarFile adds a spooled file to the tarball under name
+func addTarFile(tw *tar.Writer, name string, file bundleFile, modTime time.Time) error {
+	in, err := os.Open(file.path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: file.size, ModTime: modTime}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, in)
+	return err
+}
+
+// finishLogBundle reports where the bundle went and which containers had no logs to add
+func (m *Model) finishLogBundle(msg bundleWrittenMsg) tea.Cmd {
+	m.endOperation(bundleOperation(msg.bundle.pod.Name))
+	m.bundling, m.bundleProgress = "", ""
+	if msg.err != nil {
+		return m.setStatus(msg.err.Error())
+	}
+	status := fmt.Sprintf("wrote %d log files to %s", len(msg.bundle.files), msg.path)
+	if len(msg.bundle.missing) > 0 {
+		status += fmt.Sprintf(" (no logs for %s)", strings.Join(msg.bundle.missing, ", "))
+	}
+	return m.setStatus(status)
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// runBundle feeds the steps of a log bundle through Update until the archive was written
+func runBundle(t *testing.T, m Model, cmd tea.Cmd) Model {
+	t.Helper()
+	for {
+		msg := cmd()
+		var next tea.Model
+		next, cmd = m.Update(msg)
+		m = next.(Model)
+		if _, ok := msg.(bundleWrittenMsg); ok {
+			return m
+		}
+		if !strings.Contains(m.footerView(), "bundling the logs of etcd-main-0") {
+			t.Fatalf("footer = %q while bundling, want the progress", m.footerView())
+		}
+	}
+}
+
+func TestLogBundle(t *testing.T) {
+	t.Chdir(t.TempDir())
+	spoolDir := t.TempDir()
+	t.Setenv("TMPDIR", spoolDir)
+	restarted := runningContainer("backup-restore")
+	restarted.LastTerminationState.Terminated = &corev1.ContainerStateTerminated{ExitCode: 1}
+	pod := testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"), restarted)
+	m, _ := newTestModel([]runtime.Object{pod})
+	m = update(t, m, m.loadPods()())
+
+	next, cmd := m.Update(keyMsg("B"))
+	m = runBundle(t, next.(Model), cmd)
+	if m.bundling != "" || !strings.Contains(m.status, "wrote 3 log files to ") || !strings.HasSuffix(m.status, "etcd-main-0-logs.tar.gz") {
+		t.Fatalf("status = %q, want the bundle path reported", m.status)
+	}
+
+	file, err := os.Open(logBundlePath("etcd-main-0"))
+	if err != nil {
+		t.Fatalf("failed to open the bundle: %v", err)
+	}
+	defer file.Close()
+	zr, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("bundle isn't gzipped: %v", err)
+	}
+	tr := tar.NewReader(zr)
+	var names []string
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("failed to read the bundle: %v", err)
+		}
+		content, _ := io.ReadAll(tr)
+		if string(content) != "fake logs" {
+			t.Errorf("%s = %q, want the container's logs", header.Name, content)
+		}
+		names = append(names, header.Name)
+	}
+	sort.Strings(names)
+	// Only the restarted container has previous logs
+	want := []string{"etcd-main-0/backup-restore.log", "etcd-main-0/backup-restore.previous.log", "etcd-main-0/etcd.log"}
+	if strings.Join(names, " ") != strings.Join(want, " ") {
+		t.Errorf("bundle files = %v, want %v", names, want)
+	}
+	if spooled, _ := os.ReadDir(spoolDir); len(spooled) != 0 {
+		t.Errorf("spooled logs left behind: %v", spooled)
+	}
+}
+
+// spoolFile writes content to a file of the test's temp dir as if spoolPodLogs had
+func spoolFile(t *testing.T, name, content string) bundleFile {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return bundleFile{name: name, path: path, size: int64(len(content))}
+}
+
+func TestWriteTarballKeepsLargeLogs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "etcd-main-0-logs.tar.gz")
+	large := strings.Repeat("x", maxLogBytes+1)
+	if err := writeTarball(path, "etcd-main-0", []bundleFile{spoolFile(t, "etcd.log", large)}); err != nil {
+		t.Fatalf("writeTarball() error = %v", err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	zr, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(zr)
+	if _, err := tr.Next(); err != nil {
+		t.Fatal(err)
+	}
+	// The log view keeps only the last maxLogBytes, the bundle keeps everything
+	if content, _ := io.ReadAll(tr); len(content) != len(large) {
+		t.Errorf("bundled log is %d bytes, want %d", len(content), len(large))
+	}
+}
+
+func TestWriteTarballFailureKeepsEarlierBundle(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "etcd-main-0-logs.tar.gz")
+	if err := os.WriteFile(path, []byte("earlier bundle"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	gone := bundleFile{name: "etcd.log", path: filepath.Join(dir, "gone.log"), size: 1}
+	if err := writeTarball(path, "etcd-main-0", []bundleFile{gone}); err == nil {
+		t.Fatal("writeTarball() of a missing spool succeeded")
+	}
+	if content, _ := os.ReadFile(path); string(content) != "earlier bundle" {
+		t.Errorf("bundle = %q after a failed write, want the earlier one kept", content)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("files left after a failed write: %v", entries)
+	}
+}
+
+func TestSpoolPodLogsOutlastsRequestTimeout(t *testing.T) {
+	const timeout = 50 * time.Millisecond
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    string
+	}{
+		{
+			// The log keeps arriving after the timeout, once the stream opened in time
+			name: "slow log",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, "first\n")
+				w.(http.Flusher).Flush()
+				time.Sleep(3 * timeout)
+				io.WriteString(w, "second\n")
+			},
+			want: "first\nsecond\n",
+		},
+		{
+			name: "slow to open",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(3 * timeout)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+			m, _ := newTestModel(nil)
+			m.kubeClient = kubernetes.NewForConfigOrDie(&rest.Config{Host: server.URL})
+			m.requestTimeout = timeout
+
+			file, err := m.spoolPodLogs(testNamespace, "etcd-main-0", &corev1.PodLogOptions{Container: "etcd"})
+			if tt.want == "" {
+				if !isRequestTimeout(err) {
+					t.Errorf("spoolPodLogs() error = %v, want the request timeout", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("spoolPodLogs() error = %v", err)
+			}
+			defer os.Remove(file.path)
+			content, err := os.ReadFile(file.path)
+			if err != nil {
+				t.Fatalf("failed to read the spooled logs: %v", err)
+			}
+			if string(content) != tt.want {
+				t.Errorf("spooled logs = %q, want %q", content, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// certExpiryWarning is how close to its expiry a certificate is highlighted
+const certExpiryWarning = 30 * 24 * time.Hour
+
+// podSecretNames returns the secrets a pod mounts, directly or through a projected volume, in the order of its volumes
+// etcd-druid mounts the CA, server and client certificates of etcd and the backup sidecar this way
+func podSecretNames(pod *corev1.Pod) []string {
+	var names []string
+	add := func(name string) {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Secret != nil {
+			add(volume.Secret.SecretName)
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil {
+					add(source.Secret.Name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// formatCertValidity renders how long a certificate is still valid, in days unless it is down to the last two
+func formatCertValidity(left time.Duration) string {
+	if left < 48*time.Hour {
+		return left.Truncate(time.Minute).String()
+	}
+	return fmt.Sprintf("%d days", int(left/(24*time.Hour)))
+}
+
+// describeCertificate renders the metadata of a certificate: who it is for, who signed it and until when it is valid
+func (m *Model) describeCertificate(out *strings.Builder, cert *x509.Certificate, now time.Time) {
+	fmt.Fprintf(out, "    Subject: %s\n", cert.Subject)
+	fmt.Fprintf(out, "    Issuer: %s\n", cert.Issuer)
+	var sans []string
+	sans = append(sans, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	if len(sans) > 0 {
+		fmt.Fprintf(out, "    SANs: %s\n", strings.Join(sans, ", "))
+	}
+	if cert.IsCA {
+		out.WriteString("    CA: yes\n")
+	}
+	fmt.Fprintf(out, "    Valid from: %s\n", m.formatTimestamp(cert.NotBefore))
+
+	left := cert.NotAfter.Sub(now)
+	expiry := "    Expires: " + m.formatExpiry(cert.NotAfter, left)
+	switch {
+	case left <= 0:
+		out.WriteString(m.theme.eventWarning.Bold(true).Render(expiry+" (EXPIRED)") + "\n")
+	case left < certExpiryWarning:
+		out.WriteString(m.theme.eventWarning.Render(expiry) + "\n")
+	default:
+		out.WriteString(expiry + "\n")
+	}
+}
+
+// formatExpiry renders when a certificate expires like formatTimestamp, also saying how long is left in absolute mode
+// formatTimestamp only knows the past, an expiry is usually ahead
+func (m *Model) formatExpiry(notAfter time.Time, left time.Duration) string {
+	switch {
+	case left <= 0:
+		return m.formatTimestamp(notAfter)
+	case m.absoluteTimes:
+		return fmt.Sprintf("%s (in %s)", notAfter.Format(time.RFC3339), formatCertValidity(left))
+	}
+	return "in " + formatCertValidity(left)
+}
+
+// describeSecretKey renders the certificates in one key of a secret
+// Only CERTIFICATE blocks are parsed; keys and anything else are named with their size and never shown
+func (m *Model) describeSecretKey(out *strings.Builder, key string, data []byte, now time.Time) {
+	var certs []*x509.Certificate
+	var other []string
+	for rest := data; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			other = append(other, block.Type)
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			fmt.Fprintf(out, "  %s: unparsable certificate: %v\n", key, err)
+			continue
+		}
+		certs = append(certs, cert)
+	}
+	switch {
+	case len(certs) == 0 && len(other) > 0:
+		fmt.Fprintf(out, "  %s: %s, not shown\n", key, strings.ToLower(strings.Join(other, ", ")))
+	case len(certs) == 0:
+		fmt.Fprintf(out, "  %s: no certificate (%d bytes, not shown)\n", key, len(data))
+	}
+	for i, cert := range certs {
+		label := key
+		if len(certs) > 1 {
+			label = fmt.Sprintf("%s [%d/%d]", key, i+1, len(certs))
+		}
+		fmt.Fprintf(out, "  %s:\n", label)
+		m.describeCertificate(out, cert, now)
+	}
+}
+
+// describePodCerts renders the certificate metadata of every secret the pod mounts
+// Private keys are never decoded, let alone printed, so the view is safe to share
+func (m *Model) describePodCerts(namespace, podName string) (string, error) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	pod, err := m.kubeClient.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get pod %s: %w", podName, err)
+	}
+	names := podSecretNames(pod)
+	if len(names) == 0 {
+		return fmt.Sprintf("Pod %s mounts no secrets\n", podName), nil
+	}
+
+	now := time.Now()
+	var out strings.Builder
+	for i, name := range names {
+		if i > 0 {
+			out.WriteString("\n")
+		}
+		fmt.Fprintf(&out, "Secret %s:\n", name)
+		secret, err := m.kubeClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		switch {
+		case apierrors.IsForbidden(err):
+			out.WriteString("  (not permitted to read this secret)\n")
+			continue
+		case apierrors.IsNotFound(err):
+			out.WriteString("  (not found)\n")
+			continue
+		case err != nil:
+			return "", fmt.Errorf("failed to get secret %s: %w", name, err)
+		}
+		keys := make([]string, 0, len(secret.Data))
+		for key := range secret.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			m.describeSecretKey(&out, key, secret.Data[key], now)
+		}
+	}
+	return out.String(), nil
+}
+
+// certsLoadedMsg carries the certificate metadata of the selected pod's secrets
+type certsLoadedMsg struct{ content string }
+
+// loadPodCerts is a command that reads the selected pod's certificates asynchronously
+func (m *Model) loadPodCerts() tea.Cmd {
+	namespace, podName := m.podNamespace(m.selectedPod), m.selectedPod.Name
+	return func() tea.Msg {
+		content, err := retryFetch(func() (string, error) {
+			return m.describePodCerts(namespace, podName)
+		})
+		if err != nil {
+			return errMsg{err}
+		}
+		return certsLoadedMsg{content}
+	}
+}
+
+// promptPodCerts asks before reading the secrets of a pod; like mutations it is off in read-only mode,
+// which is what a shared or screen-recorded session would run in
+func (m *Model) promptPodCerts(pod Pod) tea.Cmd {
+	if ok, cmd := m.guardMutation("reading secrets"); !ok {
+		return cmd
+	}
+	label := fmt.Sprintf("read the TLS secrets of pod %s to show their certificates? (y/N)", pod.Name)
+	return m.openPrompt(label, "", func(m *Model, answer string) tea.Cmd {
+		if answer := strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+			return m.setStatus("reading secrets cancelled")
+		}
+		m.selectedPod = pod
+		m.navigate(CertsState)
+		return tea.Batch(m.startLoading("Reading certificates…"), m.loadPodCerts())
+	})
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// testCert returns a self-signed PEM certificate valid for validity, and the PEM of its private key
+func testCert(t *testing.T, name string, validity time.Duration, dnsNames ...string) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     dnsNames,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(validity),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestPodCerts(t *testing.T) {
+	ca, _ := testCert(t, "etcd-ca", 365*24*time.Hour)
+	server, serverKey := testCert(t, "etcd-server", 10*24*time.Hour, "etcd-main-local", "etcd-main-client.shoot--foo.svc")
+	pod := testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))
+	pod.Spec.Volumes = []corev1.Volume{
+		{Name: "ca", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "ca-etcd"}}},
+		{Name: "tls", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{
+			{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "etcd-server-cert"}}},
+			{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "gone"}}},
+		}}}},
+	}
+	m, _ := newTestModel([]runtime.Object{
+		pod,
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "ca-etcd", Namespace: testNamespace}, Data: map[string][]byte{"bundle.crt": ca}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "etcd-server-cert", Namespace: testNamespace},
+			Data: map[string][]byte{"tls.crt": server, "tls.key": serverKey}},
+	})
+	m = update(t, m, m.loadPods()())
+
+	// Nothing is read without confirmation
+	m = update(t, m, keyMsg("S"))
+	m = submitPrompt(t, m, "")
+	if m.state != ListState || !strings.Contains(m.status, "cancelled") {
+		t.Fatalf("declining went to %v, status %q", m.state, m.status)
+	}
+	m = update(t, m, keyMsg("S"))
+	m = submitPrompt(t, m, "y")
+	if m.state != CertsState {
+		t.Fatalf("confirming went to %v, want the certificates", m.state)
+	}
+	m = update(t, m, m.loadPodCerts()())
+	for _, want := range []string{
+		"Secret ca-etcd:\n  bundle.crt:\n    Subject: CN=etcd-ca\n",
+		"Secret etcd-server-cert:\n  tls.crt:\n    Subject: CN=etcd-server\n    Issuer: CN=etcd-server\n    SANs: etcd-main-local, etcd-main-client.shoot--foo.svc\n",
+		"Valid from: 1h",
+		"Expires: in 9 days\n",
+		"Expires: in 364 days\n",
+		"  tls.key: private key, not shown\n",
+		"Secret gone:\n  (not found)\n",
+	} {
+		if !strings.Contains(m.content, want) {
+			t.Errorf("certificates missing %q:\n%s", want, m.content)
+		}
+	}
+	if strings.Contains(m.content, "BEGIN") || strings.Contains(m.content, string(serverKey[30:60])) {
+		t.Errorf("certificates show key material:\n%s", m.content)
+	}
+
+	// Absolute times show the date along with how long is left
+	m = update(t, m, keyMsg("t"))
+	m = update(t, m, m.loadPodCerts()())
+	if want := "Expires: " + time.Now().Add(10*24*time.Hour).Format("2006-01-02"); !strings.Contains(m.content, want) || !strings.Contains(m.content, "(in 9 days)") {
+		t.Errorf("certificates with absolute times missing %q:\n%s", want, m.content)
+	}
+}
+
+func TestPodCertsReadOnly(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m.readOnly = true
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("S"))
+	if m.prompt != nil || !strings.Contains(m.status, "read-only mode: reading secrets is disabled") {
+		t.Errorf("S in read-only mode: prompt open %v, status %q", m.prompt != nil, m.status)
+	}
+}
+
+func TestFormatCertValidity(t *testing.T) {
+	for left, want := range map[time.Duration]string{
+		90 * 24 * time.Hour:         "90 days",
+		36*time.Hour + time.Second:  "36h0m0s",
+		50*24*time.Hour + time.Hour: "50 days",
+	} {
+		if got := formatCertValidity(left); got != want {
+			t.Errorf("formatCertValidity(%s) = %q, want %q", left, got, want)
+		}
+	}
+}
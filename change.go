@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// defaultFieldManager is recorded in managedFields for the changes the viewer makes, unless --field-manager names another
+const defaultFieldManager = "etcd-pod-viewer"
+
+// patchOptions are the options of every patch the viewer sends: the field manager, and a server-side dry run with --dry-run
+func (m *Model) patchOptions() metav1.PatchOptions {
+	opts := metav1.PatchOptions{FieldManager: m.fieldManager}
+	if m.dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	return opts
+}
+
+// updateOptions are patchOptions for updates
+func (m *Model) updateOptions() metav1.UpdateOptions {
+	opts := metav1.UpdateOptions{FieldManager: m.fieldManager}
+	if m.dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	return opts
+}
+
+// changeMsg carries the diff of an object a mutation changed, in place of the mutation's own result
+// dryRun is set for a server-side dry run, which changed nothing
+type changeMsg struct {
+	operation string // The operation the change finishes, see beginOperation
+	title     string // What was done, e.g. "scale etcd etcd-main to 5 replicas"
+	diff      string
+	dryRun    bool
+	err       error
+}
+
+// dryRunResult diffs the object before a dry-run mutation against the one the server returned
+// The server ran its admission and defaulting on it, so the diff is what the mutation would really do
+func dryRunResult(operation, title string, before, after runtime.Object) tea.Msg {
+	from, err := changeYAML(before)
+	if err != nil {
+		return changeMsg{operation: operation, err: err}
+	}
+	to, err := changeYAML(after)
+	if err != nil {
+		return changeMsg{operation: operation, err: err}
+	}
+	return changeMsg{operation: operation, title: title, diff: changeDiff("live", "dry run", from, to), dryRun: true}
+}
+
+// changeDiff is the unified diff of a change, noting when the server left the object as it was
+func changeDiff(fromName, toName, from, to string) string {
+	if diff := unifiedDiff(fromName, toName, from, to); diff != "" {
+		return diff
+	}
+	return "The server accepted it without changing anything\n"
+}
+
+// changeYAML renders an object for editing and for the diff of a change, without the managedFields that every write touches
+func changeYAML(obj runtime.Object) (string, error) {
+	obj = obj.DeepCopyObject()
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the metadata of %T: %w", obj, err)
+	}
+	accessor.SetManagedFields(nil)
+	var b []byte
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		b, err = yaml.Marshal(u.Object)
+	} else {
+		b, err = yaml.Marshal(obj)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %T to yaml: %w", obj, err)
+	}
+	return string(b), nil
+}
+
+// showChange opens the diff of a change, which an applied one follows with a reload of the pods
+func (m *Model) showChange(msg changeMsg) tea.Cmd {
+	m.endOperation(msg.operation)
+	if msg.err != nil {
+		return m.setStatus(msg.err.Error())
+	}
+	m.navigate(ChangeState)
+	m.content = msg.diff
+	m.refreshViewport()
+	m.viewport.GotoTop()
+	if msg.dryRun {
+		m.changeTitle = "Dry run: " + msg.title
+		return m.setStatus(fmt.Sprintf("dry run: %s, nothing was changed", msg.title))
+	}
+	m.changeTitle = "Applied: " + msg.title
+	return tea.Batch(m.setStatus("applied: "+msg.title), m.loadPods())
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestDryRunScale(t *testing.T) {
+	etcd := testEtcd(testEtcdName)
+	_ = unstructured.SetNestedField(etcd.Object, int64(3), "spec", "replicas")
+	m, _ := newTestModel(nil, etcd)
+	m.dryRun = true
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+
+	msg, ok := m.scaleEtcd(5)().(changeMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("dry-run scale = %+v, want a dry run", msg)
+	}
+	for _, want := range []string{"--- live\n+++ dry run\n", "-  replicas: 3\n", "+  replicas: 5\n"} {
+		if !strings.Contains(msg.diff, want) {
+			t.Errorf("dry-run diff missing %q:\n%s", want, msg.diff)
+		}
+	}
+
+	m = update(t, m, msg)
+	if m.state != ChangeState || len(m.operations) != 0 || !strings.Contains(m.status, "dry run: scale etcd etcd-main to 5 replicas, nothing was changed") {
+		t.Errorf("after the dry run: state %v, operations %v, status %q", m.state, m.operations, m.status)
+	}
+	if view := m.View(); !strings.Contains(view, "Dry run: scale etcd etcd-main to 5 replicas") || !strings.Contains(view, "dry run") {
+		t.Errorf("dry-run view:\n%s", view)
+	}
+	m = update(t, m, keyMsg("esc"))
+	if m.state != ListState {
+		t.Errorf("esc returned to %v", m.state)
+	}
+}
+
+func TestMutationOptions(t *testing.T) {
+	m, client := newTestModel([]runtime.Object{
+		testStatefulSet(3, appsv1.StatefulSetStatus{}),
+		testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd")),
+	})
+	m.fieldManager = "gardener-ops"
+	var patches []metav1.PatchOptions
+	client.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patches = append(patches, action.(k8stesting.PatchActionImpl).PatchOptions)
+		return false, nil, nil
+	})
+
+	m.dryRun = true
+	msg, ok := m.restartStatefulSet()().(changeMsg)
+	if !ok || !strings.Contains(msg.diff, "kubectl.kubernetes.io/restartedAt") {
+		t.Errorf("dry-run restart = %+v", msg)
+	}
+	if msg, ok := m.patchPodMetadata(Pod{Name: "etcd-main-0"}, metadataChange{field: "labels", key: "team", value: "etcd"})().(changeMsg); !ok || !strings.Contains(msg.diff, "+    team: etcd\n") {
+		t.Errorf("dry-run label = %+v", msg)
+	}
+	m.dryRun = false
+	m.restartStatefulSet()()
+
+	if len(patches) != 3 {
+		t.Fatalf("sent %d patches, want 3", len(patches))
+	}
+	for i, opts := range patches {
+		if opts.FieldManager != "gardener-ops" {
+			t.Errorf("patch %d field manager = %q", i, opts.FieldManager)
+		}
+		if dryRun := len(opts.DryRun) > 0; dryRun != (i < 2) {
+			t.Errorf("patch %d dry run = %v", i, opts.DryRun)
+		}
+	}
+}
+
+func TestDryRunYAMLDropsManagedFields(t *testing.T) {
+	pod := testPod("etcd-main-0", corev1.PodRunning)
+	pod.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "kubectl"}}
+	got, err := changeYAML(pod)
+	if err != nil || strings.Contains(got, "managedFields") || !strings.Contains(got, "name: etcd-main-0") {
+		t.Errorf("changeYAML() = %v:\n%s", err, got)
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+)
+
+// writeClipboard puts text on the system clipboard through the OSC 52 escape sequence
+// The terminal does the copying, so it works over SSH and needs no xclip or pbcopy
+// Tests replace it to capture what was copied
+var writeClipboard = termenv.Copy
+
+// copyToClipboard copies text and confirms it in the footer with the given description
+func (m *Model) copyToClipboard(what, text string) tea.Cmd {
+	writeClipboard(text)
+	return m.setStatus(fmt.Sprintf("copied %s: %s", what, text))
+}
+
+// kubectlLogsCommand is the kubectl invocation equivalent to the log view
+// An empty container leaves the choice to kubectl's default container annotation
+func (m *Model) kubectlLogsCommand(namespace, podName, container string) string {
+	command := fmt.Sprintf("kubectl logs -n %s %s", namespace, podName)
+	if container != "" {
+		command += " -c " + container
+	}
+	return command
+}
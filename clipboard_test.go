@@ -0,0 +1,50 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// captureClipboard swaps writeClipboard for the duration of a test and returns what was copied
+func captureClipboard(t *testing.T) *string {
+	t.Helper()
+	var copied string
+	saved := writeClipboard
+	writeClipboard = func(text string) { copied = text }
+	t.Cleanup(func() { writeClipboard = saved })
+	return &copied
+}
+
+func TestCopyFromList(t *testing.T) {
+	copied := captureClipboard(t)
+	m, _ := newTestModel(nil)
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+
+	m = update(t, m, keyMsg("c"))
+	if *copied != "etcd-main-0" {
+		t.Errorf("copied %q, want the pod name", *copied)
+	}
+	if !strings.HasPrefix(m.status, "copied pod name") {
+		t.Errorf("status = %q, want a confirmation", m.status)
+	}
+
+	m = update(t, m, keyMsg("C"))
+	if want := "kubectl logs -n " + testNamespace + " etcd-main-0"; *copied != want {
+		t.Errorf("copied %q, want %q", *copied, want)
+	}
+}
+
+func TestCopyLogCommandFromLogView(t *testing.T) {
+	copied := captureClipboard(t)
+	m, _ := newTestModel(nil)
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("l"))
+	m = update(t, m, containersLoadedMsg{[]Container{{Name: "etcd"}, {Name: "backup-restore"}}})
+	m = update(t, m, logsLoadedMsg{content: "line"})
+	m = update(t, m, keyMsg("]"))
+
+	m = update(t, m, keyMsg("C"))
+	if want := "kubectl logs -n " + testNamespace + " etcd-main-0 -c backup-restore"; *copied != want {
+		t.Errorf("copied %q, want %q", *copied, want)
+	}
+}
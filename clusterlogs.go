@@ -0,0 +1,433 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// etcdContainer is the name etcd-druid gives the etcd container of each member pod
+const etcdContainer = "etcd"
+
+// maxClusterLogStreams bounds how many pod logs the cluster view streams at once, like stern's --max-log-requests
+// Large clusters would otherwise hold one request per member open against the API server; pods past it wait for a stream to end
+const maxClusterLogStreams = 8
+
+// errPodGone notes a pod that was deleted between listing the pods and reading its logs, e.g. during a rollout
+var errPodGone = errors.New("pod no longer exists")
+
+// getClusterLogs fetches the etcd container logs of every pod once and interleaves them by kubelet timestamp, for a time window
+// Each line is tagged with its pod; a pod whose logs can't be read gets a note instead of failing the whole view
+func (m *Model) getClusterLogs(pods []Pod) (string, bool, error) {
+	logs := make([]containerLogs, len(pods))
+	truncated := make([]bool, len(pods))
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, maxClusterLogStreams)
+	for i, pod := range pods {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			// Timestamps are always requested for sorting; interleaveLogs drops them again unless switched on
+			opts := m.podLogOptions(etcdContainer)
+			opts.Timestamps = true
+			content, cut, err := m.streamPodLogs(m.podNamespace(pod), pod.Name, opts)
+			if apierrors.IsNotFound(err) {
+				err = errPodGone
+			}
+			logs[i] = containerLogs{container: m.podDisplayName(pod), content: content, err: err}
+			truncated[i] = cut
+		}()
+	}
+	wg.Wait()
+
+	failures := 0
+	var anyTruncated bool
+	for i := range logs {
+		if logs[i].err != nil {
+			failures++
+		}
+		anyTruncated = anyTruncated || truncated[i]
+	}
+	if failures > 0 && failures == len(pods) {
+		return "", false, fmt.Errorf("failed to get etcd logs for any pod of %s: %w", m.etcdName, logs[0].err)
+	}
+	return interleaveLogs(logs, m.timestamps), anyTruncated, nil
+}
+
+// clusterLogsLoadedMsg carries the interleaved etcd logs of all pods
+type clusterLogsLoadedMsg struct {
+	content   string
+	truncated bool
+}
+
+// fetchClusterLogs is a command that fetches the etcd logs of every pod in the list asynchronously, those it hides left out
+func (m *Model) fetchClusterLogs() tea.Cmd {
+	pods := m.listedPods()
+	return func() tea.Msg {
+		var truncated bool
+		content, err := retryFetch(func() (string, error) {
+			var content string
+			var err error
+			content, truncated, err = m.getClusterLogs(pods)
+			return content, err
+		})
+		if err != nil {
+			return errMsg{err}
+		}
+		return clusterLogsLoadedMsg{content: content, truncated: truncated}
+	}
+}
+
+// clusterLogTags lists the tag of each pod in the cluster view, in list order so their colors stay put
+func (m *Model) clusterLogTags() []string {
+	pods := m.listedPods()
+	tags := make([]string, len(pods))
+	for i, pod := range pods {
+		tags[i] = m.podDisplayName(pod)
+	}
+	return tags
+}
+
+// streamsClusterLogs reports whether the cluster view streams the logs of its pods rather than re-fetching them
+// A time window is read once per refresh, like in the log view
+func (m *Model) streamsClusterLogs() bool {
+	return !m.logWindow.active()
+}
+
+// loadClusterLogs (re)opens the streams of the cluster view, or fetches a time window of it
+func (m *Model) loadClusterLogs() tea.Cmd {
+	return tea.Batch(m.clusterLogsFetches()...)
+}
+
+// clusterLogsFetches returns the commands of loadClusterLogs, a stream per pod or the window fetch
+// Leaving the streams for a window starts refreshing again
+func (m *Model) clusterLogsFetches() []tea.Cmd {
+	if m.streamsClusterLogs() {
+		return m.startClusterLogs()
+	}
+	var refresh tea.Cmd
+	if m.clusterLogs != nil {
+		m.stopClusterLogs()
+		refresh = m.scheduleRefresh()
+	}
+	return []tea.Cmd{m.fetchClusterLogs(), refresh}
+}
+
+// clusterLogs holds the streams of the cluster view along with the lines read so far, see startClusterLogs
+// The lines are kept in kubelet timestamp order, so the tails read when a stream opens interleave with the others
+type clusterLogs struct {
+	id        int
+	streams   map[string]*clusterLogStream // by pod name
+	waiting   []Pod                        // past maxClusterLogStreams, opened as streams end
+	lines     []clusterLogLine
+	size      int
+	truncated bool
+}
+
+// clusterLogStream follows the etcd container of one pod; last is the newest line read, where a reopened stream continues
+type clusterLogStream struct {
+	pod    Pod
+	last   time.Time
+	failed string // the error the last open failed with, noted once
+	lines  <-chan string
+	cancel context.CancelFunc
+}
+
+// clusterLogLine is a line of the cluster view, tagged with its pod
+type clusterLogLine struct {
+	time time.Time
+	line string
+}
+
+// clusterLogStreamStartedMsg carries the stream opened for a pod of the cluster view
+type clusterLogStreamStartedMsg struct {
+	id     int
+	pod    string
+	lines  <-chan string
+	cancel context.CancelFunc
+	err    error
+}
+
+// clusterLogLinesMsg carries the lines read from the stream of a pod; closed is set once it ended
+type clusterLogLinesMsg struct {
+	id     int
+	pod    string
+	lines  []string
+	closed bool
+}
+
+// clusterLogStreamEndedMsg reports whether the pod of an ended stream is gone, rather than only its container restarted
+type clusterLogStreamEndedMsg struct {
+	id   int
+	pod  string
+	gone bool
+}
+
+// clusterLogRetryMsg reopens the ended stream of a pod
+type clusterLogRetryMsg struct {
+	id  int
+	pod string
+}
+
+// startClusterLogs replaces the cluster view with a follow stream of each pod's etcd logs, starting at the tail setting
+func (m *Model) startClusterLogs() []tea.Cmd {
+	m.stopClusterLogs()
+	m.clusterLogsID++
+	m.clusterLogs = &clusterLogs{id: m.clusterLogsID, streams: map[string]*clusterLogStream{}}
+	m.content, m.logsTruncated = "", false
+	var cmds []tea.Cmd
+	// Finished pods left out of the list have no etcd to follow
+	for _, pod := range m.listedPods() {
+		if len(m.clusterLogs.streams) == maxClusterLogStreams {
+			m.clusterLogs.waiting = append(m.clusterLogs.waiting, pod)
+			m.noteClusterLog(pod, fmt.Sprintf("(waiting, at most %d pods are streamed at once)", maxClusterLogStreams))
+			continue
+		}
+		s := &clusterLogStream{pod: pod}
+		m.clusterLogs.streams[pod.Name] = s
+		cmds = append(cmds, m.openClusterLogStream(m.clusterLogs.id, s))
+	}
+	m.refreshViewport()
+	return cmds
+}
+
+// stopClusterLogs ends every stream of the cluster view, e.g. when leaving it
+func (m *Model) stopClusterLogs() {
+	if m.clusterLogs == nil {
+		return
+	}
+	for _, s := range m.clusterLogs.streams {
+		if s.cancel != nil {
+			s.cancel()
+		}
+	}
+	m.clusterLogs = nil
+}
+
+// openClusterLogStream is a command that opens a follow stream of a pod's etcd logs
+// A reopened stream continues after the last line read, e.g. once the container restarted
+func (m *Model) openClusterLogStream(id int, s *clusterLogStream) tea.Cmd {
+	namespace, podName := m.podNamespace(s.pod), s.pod.Name
+	// Timestamps are always requested for sorting; applyClusterLogLines drops them again unless switched on
+	opts := m.podLogOptions(etcdContainer)
+	opts.Follow, opts.Timestamps = true, true
+	if !s.last.IsZero() {
+		sinceTime := metav1.NewTime(s.last)
+		opts.SinceTime, opts.SinceSeconds, opts.TailLines = &sinceTime, nil, nil
+	}
+	return func() tea.Msg {
+		// The stream outlives the request timeout, it ends when stopped
+		ctx, cancel := context.WithCancel(context.Background())
+		logs, err := m.kubeClient.CoreV1().Pods(namespace).GetLogs(podName, opts).Stream(ctx)
+		if err != nil {
+			cancel()
+			if apierrors.IsNotFound(err) {
+				err = errPodGone
+			}
+			return clusterLogStreamStartedMsg{id: id, pod: podName, err: err}
+		}
+		lines := make(chan string, maxStreamBatch)
+		go readLogLines(ctx, logs, lines)
+		return clusterLogStreamStartedMsg{id: id, pod: podName, lines: lines, cancel: cancel}
+	}
+}
+
+// nextClusterLogLines is a command that waits for the next lines of a pod's stream
+func nextClusterLogLines(id int, pod string, lines <-chan string) tea.Cmd {
+	return func() tea.Msg {
+		batch, closed := readLogBatch(lines)
+		return clusterLogLinesMsg{id: id, pod: pod, lines: batch, closed: closed}
+	}
+}
+
+// currentClusterStream returns the stream of a pod a message is for, or nil when the streams were replaced since or the view left
+func (m *Model) currentClusterStream(id int, pod string) *clusterLogStream {
+	if m.clusterLogs == nil || m.clusterLogs.id != id || m.state != ClusterLogsState {
+		return nil
+	}
+	return m.clusterLogs.streams[pod]
+}
+
+// setClusterLogStream keeps the opened stream of a pod and waits for its lines
+// A pod that is gone is noted and its stream dropped; any other failure is noted once and retried after refreshInterval
+func (m *Model) setClusterLogStream(msg clusterLogStreamStartedMsg) tea.Cmd {
+	s := m.currentClusterStream(msg.id, msg.pod)
+	if s == nil {
+		if msg.cancel != nil {
+			msg.cancel()
+		}
+		m.dropClusterLogs(msg.id)
+		return nil
+	}
+	if errors.Is(msg.err, errPodGone) {
+		return m.endClusterLogStream(s)
+	}
+	if msg.err != nil {
+		if msg.err.Error() != s.failed {
+			s.failed = msg.err.Error()
+			m.noteClusterLog(s.pod, fmt.Sprintf("(logs unavailable: %v)", msg.err))
+			m.refreshViewport()
+		}
+		return m.retryClusterLogStream(msg.id, msg.pod)
+	}
+	s.failed, s.lines, s.cancel = "", msg.lines, msg.cancel
+	return nextClusterLogLines(msg.id, msg.pod, msg.lines)
+}
+
+// applyClusterLogLines adds the lines streamed from a pod in timestamp order, keeping the view at the bottom if it was there
+// A reopened stream starts in the second of the last line read, whose lines are dropped again
+func (m *Model) applyClusterLogLines(msg clusterLogLinesMsg) tea.Cmd {
+	s := m.currentClusterStream(msg.id, msg.pod)
+	if s == nil {
+		m.dropClusterLogs(msg.id)
+		return nil
+	}
+	prefix := mergedLogPrefix(m.podDisplayName(s.pod))
+	added := false
+	for _, line := range msg.lines {
+		line = strings.TrimSuffix(line, "\n")
+		t, rest, ok := splitLogTimestamp(line)
+		if ok && !t.After(s.last) {
+			continue
+		}
+		if !ok {
+			// A note from the kubelet keeps its place after the last line of its pod
+			t = s.last
+		} else {
+			s.last = t
+		}
+		if ok && !m.timestamps {
+			line = rest
+		}
+		m.clusterLogs.insert(clusterLogLine{time: t, line: prefix + line})
+		added = true
+	}
+	if added {
+		m.showClusterLogs()
+	}
+	if msg.closed {
+		if s.cancel != nil {
+			s.cancel()
+		}
+		s.lines, s.cancel = nil, nil
+		return m.checkClusterLogPod(msg.id, s.pod)
+	}
+	return nextClusterLogLines(msg.id, msg.pod, s.lines)
+}
+
+// checkClusterLogPod is a command that tells whether the pod of an ended stream still exists
+// The stream of a restarted container or a member recreated under its name ends too, and is reopened
+func (m *Model) checkClusterLogPod(id int, pod Pod) tea.Cmd {
+	namespace := m.podNamespace(pod)
+	return func() tea.Msg {
+		ctx, cancel := m.requestContext()
+		defer cancel()
+		_, err := m.kubeClient.CoreV1().Pods(namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		return clusterLogStreamEndedMsg{id: id, pod: pod.Name, gone: apierrors.IsNotFound(err)}
+	}
+}
+
+// clusterLogStreamEnded notes a pod that is gone, or reopens the stream of one still there after refreshInterval
+func (m *Model) clusterLogStreamEnded(msg clusterLogStreamEndedMsg) tea.Cmd {
+	s := m.currentClusterStream(msg.id, msg.pod)
+	if s == nil {
+		m.dropClusterLogs(msg.id)
+		return nil
+	}
+	if msg.gone {
+		return m.endClusterLogStream(s)
+	}
+	return m.retryClusterLogStream(msg.id, msg.pod)
+}
+
+// endClusterLogStream notes that a pod is gone and hands its stream to the next pod waiting for one
+func (m *Model) endClusterLogStream(s *clusterLogStream) tea.Cmd {
+	cl := m.clusterLogs
+	delete(cl.streams, s.pod.Name)
+	m.noteClusterLog(s.pod, fmt.Sprintf("--- %s, its stream ended ---", errPodGone))
+	var open tea.Cmd
+	if len(cl.waiting) > 0 {
+		next := &clusterLogStream{pod: cl.waiting[0]}
+		cl.waiting = cl.waiting[1:]
+		cl.streams[next.pod.Name] = next
+		open = m.openClusterLogStream(cl.id, next)
+	}
+	m.showClusterLogs()
+	return open
+}
+
+// retryClusterLogStream is a command that reopens the stream of a pod after refreshInterval
+func (m *Model) retryClusterLogStream(id int, pod string) tea.Cmd {
+	return tea.Tick(m.refreshInterval, func(time.Time) tea.Msg {
+		return clusterLogRetryMsg{id: id, pod: pod}
+	})
+}
+
+// reopenClusterLogStream reopens the ended stream of a pod unless the cluster view was left since
+func (m *Model) reopenClusterLogStream(msg clusterLogRetryMsg) tea.Cmd {
+	s := m.currentClusterStream(msg.id, msg.pod)
+	if s == nil {
+		m.dropClusterLogs(msg.id)
+		return nil
+	}
+	return m.openClusterLogStream(msg.id, s)
+}
+
+// dropClusterLogs stops the streams a stale message is for, if they are still the current ones
+// They are, when the cluster view was left without stopping them
+func (m *Model) dropClusterLogs(id int) {
+	if m.clusterLogs != nil && m.clusterLogs.id == id && m.state != ClusterLogsState {
+		m.stopClusterLogs()
+	}
+}
+
+// noteClusterLog adds a note about a pod's stream at the end of the cluster view
+func (m *Model) noteClusterLog(pod Pod, note string) {
+	cl := m.clusterLogs
+	var t time.Time
+	if len(cl.lines) > 0 {
+		t = cl.lines[len(cl.lines)-1].time
+	}
+	cl.insert(clusterLogLine{time: t, line: mergedLogPrefix(m.podDisplayName(pod)) + note})
+	m.showClusterLogs()
+}
+
+// insert adds a line after those not later than it, dropping the oldest lines beyond maxLogBytes
+func (cl *clusterLogs) insert(line clusterLogLine) {
+	i := sort.Search(len(cl.lines), func(i int) bool { return cl.lines[i].time.After(line.time) })
+	cl.lines = slices.Insert(cl.lines, i, line)
+	cl.size += len(line.line) + 1
+	for cl.size > maxLogBytes && len(cl.lines) > 1 {
+		cl.size -= len(cl.lines[0].line) + 1
+		cl.lines = cl.lines[1:]
+		cl.truncated = true
+	}
+}
+
+// showClusterLogs renders the streamed lines into the cluster view, following new lines unless the user scrolled up to read
+func (m *Model) showClusterLogs() {
+	cl := m.clusterLogs
+	lines := make([]string, len(cl.lines))
+	for i, l := range cl.lines {
+		lines[i] = l.line
+	}
+	follow := m.content == "" || m.viewport.AtBottom()
+	m.content = strings.Join(lines, "\n")
+	m.logsTruncated = cl.truncated
+	m.refreshViewport()
+	if follow {
+		m.viewport.GotoBottom()
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetClusterLogs(t *testing.T) {
+	var objects []runtime.Object
+	var pods []Pod
+	for i := range maxClusterLogStreams + 2 {
+		name := fmt.Sprintf("etcd-main-%d", i)
+		objects = append(objects, testPod(name, corev1.PodRunning, runningContainer("etcd")))
+		pods = append(pods, Pod{Name: name})
+	}
+	m, _ := newTestModel(objects)
+
+	// A time window reads each pod's logs once; more pods than maxClusterLogStreams still all end up in the view
+	content, _, err := m.getClusterLogs(pods)
+	if err != nil {
+		t.Fatalf("getClusterLogs() error = %v", err)
+	}
+	for _, pod := range pods {
+		if !strings.Contains(content, mergedLogPrefix(pod.Name)+"fake logs") {
+			t.Errorf("getClusterLogs() missing the logs of %s:\n%s", pod.Name, content)
+		}
+	}
+}
+
+func TestClusterLogView(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = update(t, m, podsLoadedMsg{})
+	m = update(t, m, keyMsg("L"))
+	if m.state != ListState || !strings.Contains(m.status, "no pods") {
+		t.Fatalf("L without pods: state %v, status %q", m.state, m.status)
+	}
+
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}, {Name: "etcd-main-1"}}})
+	next, cmd := m.Update(keyMsg("L"))
+	m = next.(Model)
+	if m.state != ClusterLogsState || cmd == nil {
+		t.Fatalf("L opened state %v, want the cluster logs loading", m.state)
+	}
+	if m.clusterLogs == nil || m.isLive() {
+		t.Error("the cluster logs aren't streamed")
+	}
+
+	id := m.clusterLogs.id
+	m = update(t, m, clusterLogLinesMsg{id: id, pod: "etcd-main-1", lines: []string{"2026-10-14T10:00:00Z became leader\n"}})
+	m = update(t, m, clusterLogLinesMsg{id: id, pod: "etcd-main-0", lines: []string{"2026-10-14T10:00:01Z lost leader\n"}})
+	view := m.View()
+	for _, want := range []string{"Cluster logs: ", "■ etcd-main-0  ■ etcd-main-1", "etcd-main-1 became leader", "etcd-main-0 lost leader"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("cluster log view missing %q:\n%s", want, view)
+		}
+	}
+	// Colors follow the pod's position in the list
+	if m.tagStyle(m.clusterLogTags(), "etcd-main-1").GetForeground() != m.theme.logContainers[1].GetForeground() {
+		t.Error("etcd-main-1 isn't colored by its pod index")
+	}
+
+	m.logGrep, _ = parseLogGrep("became")
+	if got := m.viewportContent(); strings.Contains(got, "lost leader") {
+		t.Errorf("grep became kept the other pod's line:\n%s", got)
+	}
+	m.logGrep = nil
+
+	m = update(t, m, keyMsg("esc"))
+	if m.state != ListState {
+		t.Errorf("esc returned to state %v, want ListState", m.state)
+	}
+	if m.clusterLogs != nil {
+		t.Error("leaving the cluster logs kept their streams open")
+	}
+	// Lines arriving after leaving must not take over another view
+	m = update(t, m, clusterLogLinesMsg{id: id, pod: "etcd-main-0", lines: []string{"2026-10-14T10:00:02Z late\n"}})
+	if strings.Contains(m.content, "late") {
+		t.Error("a late cluster log line replaced the content of the pod list")
+	}
+}
+
+func TestClusterLogStreams(t *testing.T) {
+	var objects []runtime.Object
+	var pods []Pod
+	for _, name := range []string{"etcd-main-0", "etcd-main-1", "etcd-main-2"} {
+		objects = append(objects, testPod(name, corev1.PodRunning, runningContainer("etcd")))
+		pods = append(pods, Pod{Name: name})
+	}
+	m, client := newTestModel(objects)
+	m = update(t, m, tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = update(t, m, podsLoadedMsg{pods: pods})
+	m = update(t, m, keyMsg("L"))
+	if m.clusterLogs == nil || len(m.clusterLogs.streams) != 3 {
+		t.Fatalf("cluster logs = %+v, want a stream per pod", m.clusterLogs)
+	}
+	id := m.clusterLogs.id
+
+	// Each pod's stream is opened on its own and its lines are tagged as they arrive
+	started, ok := m.openClusterLogStream(id, m.clusterLogs.streams["etcd-main-0"])().(clusterLogStreamStartedMsg)
+	if !ok || started.err != nil {
+		t.Fatalf("openClusterLogStream() = %+v, want the stream", started)
+	}
+	next, cmd := m.Update(started)
+	m = next.(Model)
+	lines, ok := cmd().(clusterLogLinesMsg)
+	if !ok || lines.pod != "etcd-main-0" {
+		t.Fatalf("stream sent %+v, want the lines of etcd-main-0", lines)
+	}
+	m = update(t, m, clusterLogLinesMsg{id: id, pod: "etcd-main-0", lines: lines.lines})
+	if !strings.Contains(m.content, "[etcd-main-0] fake logs") {
+		t.Errorf("content = %q, want the streamed line tagged with its pod", m.content)
+	}
+
+	// Lines interleave by timestamp whichever stream they arrive from; a reopened stream's repeated lines are dropped
+	m = update(t, m, clusterLogLinesMsg{id: id, pod: "etcd-main-1", lines: []string{"2026-10-14T10:00:02Z applied index 7\n"}})
+	m = update(t, m, clusterLogLinesMsg{id: id, pod: "etcd-main-2", lines: []string{"2026-10-14T10:00:01Z became leader\n"}})
+	m = update(t, m, clusterLogLinesMsg{id: id, pod: "etcd-main-1", lines: []string{"2026-10-14T10:00:02Z applied index 7\n", "2026-10-14T10:00:03Z applied index 8\n"}})
+	want := "[etcd-main-2] became leader\n[etcd-main-1] applied index 7\n[etcd-main-1] applied index 8"
+	if !strings.HasSuffix(m.content, want) {
+		t.Errorf("content =\n%s\nwant it to end with\n%s", m.content, want)
+	}
+
+	// A stream ending with its pod gone is noted mid-stream, one whose pod is still there is reopened
+	if err := client.CoreV1().Pods(testNamespace).Delete(context.Background(), "etcd-main-2", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("failed to delete pod: %v", err)
+	}
+	next, cmd = m.Update(clusterLogLinesMsg{id: id, pod: "etcd-main-2", closed: true})
+	m = update(t, next.(Model), cmd())
+	if _, ok := m.clusterLogs.streams["etcd-main-2"]; ok || !strings.HasSuffix(m.content, "[etcd-main-2] --- pod no longer exists, its stream ended ---") {
+		t.Errorf("content = %q, want the end of the gone pod's stream noted", m.content)
+	}
+	next, cmd = m.Update(clusterLogLinesMsg{id: id, pod: "etcd-main-1", closed: true})
+	m = next.(Model)
+	if ended := cmd().(clusterLogStreamEndedMsg); ended.gone {
+		t.Error("the stream of a pod that is still there ended as gone")
+	}
+	if m.clusterLogs.streams["etcd-main-1"].last.IsZero() {
+		t.Error("the ended stream doesn't remember where to continue")
+	}
+	if _, ok := m.reopenClusterLogStream(clusterLogRetryMsg{id: id, pod: "etcd-main-1"})().(clusterLogStreamStartedMsg); !ok {
+		t.Error("the ended stream wasn't reopened")
+	}
+
+	// A time window is read once per refresh instead
+	m.logWindow = logWindow{start: time.Now().Add(-time.Hour)}
+	m.clusterLogsFetches()
+	if m.clusterLogs != nil || !m.isLive() {
+		t.Error("a time window kept streaming the cluster logs")
+	}
+}
+
+func TestClusterLogStreamsLimit(t *testing.T) {
+	var pods []Pod
+	for i := range maxClusterLogStreams + 1 {
+		pods = append(pods, Pod{Name: fmt.Sprintf("etcd-main-%d", i)})
+	}
+	m, _ := newTestModel(nil)
+	m = update(t, m, tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = update(t, m, podsLoadedMsg{pods: pods})
+	m = update(t, m, keyMsg("L"))
+	last := pods[maxClusterLogStreams].Name
+	if len(m.clusterLogs.streams) != maxClusterLogStreams || !strings.Contains(m.content, "["+last+"] (waiting") {
+		t.Fatalf("%d streams, content %q, want %d and %s waiting", len(m.clusterLogs.streams), m.content, maxClusterLogStreams, last)
+	}
+
+	// A stream that ended for good goes to the pod waiting for one
+	m = update(t, m, clusterLogStreamEndedMsg{id: m.clusterLogs.id, pod: "etcd-main-0", gone: true})
+	if _, ok := m.clusterLogs.streams[last]; !ok || len(m.clusterLogs.waiting) != 0 {
+		t.Errorf("streams %v, waiting %v, want %s streamed once etcd-main-0 is gone", m.clusterLogs.streams, m.clusterLogs.waiting, last)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// etcdConditions extracts the conditions from the unstructured Etcd resource, e.g. AllMembersReady or BackupReady
+// Unlike decoding the whole status, each field is read on its own: entries that aren't objects are skipped
+// and fields with unexpected types stay empty, so a half-written status still renders
+func etcdConditions(obj map[string]interface{}) []etcdCondition {
+	var conditions []etcdCondition
+	for _, entry := range describeList(obj, "status", "conditions") {
+		conditions = append(conditions, etcdCondition{
+			Type:               conditionField(entry, "type"),
+			Status:             conditionField(entry, "status"),
+			Reason:             conditionField(entry, "reason"),
+			Message:            conditionField(entry, "message"),
+			LastUpdateTime:     conditionField(entry, "lastUpdateTime"),
+			LastTransitionTime: conditionField(entry, "lastTransitionTime"),
+		})
+	}
+	// Oldest transition first, like the events view, so the latest change is at the bottom
+	sort.SliceStable(conditions, func(i, j int) bool {
+		return conditionTransition(conditions[i]).Before(conditionTransition(conditions[j]))
+	})
+	return conditions
+}
+
+// conditionTransition parses when a condition last changed, the zero time when missing or unparsable
+func conditionTransition(condition etcdCondition) time.Time {
+	t, err := time.Parse(time.RFC3339, condition.LastTransitionTime)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// conditionField returns a string field of a condition, or "" when it is missing or not a string
+func conditionField(condition map[string]interface{}, field string) string {
+	value, _, _ := unstructured.NestedString(condition, field)
+	return strings.TrimSpace(value)
+}
+
+// fetchEtcdConditions renders the conditions of the Etcd resource as a table
+func (m *Model) fetchEtcdConditions() (string, error) {
+	etcd, err := m.fetchEtcdResource()
+	if err != nil {
+		return "", err
+	}
+	conditions := etcdConditions(etcd.Object)
+	if len(conditions) == 0 {
+		return fmt.Sprintf("Etcd %s reports no conditions yet\n", m.etcdName), nil
+	}
+
+	// Align columns first, then color whole rows so escape codes don't skew the widths
+	var table strings.Builder
+	w := tabwriter.NewWriter(&table, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tSTATUS\tREASON\tMESSAGE\tLAST TRANSITION")
+	for _, condition := range conditions {
+		transition := "<unknown>"
+		if t := conditionTransition(condition); !t.IsZero() {
+			transition = m.formatTimestamp(t)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			orNone(condition.Type), orNone(condition.Status), orNone(condition.Reason), orNone(condition.Message), transition)
+	}
+	w.Flush()
+
+	lines := strings.Split(strings.TrimSuffix(table.String(), "\n"), "\n")
+	for i, condition := range conditions {
+		if condition.Status != "True" {
+			lines[i+1] = m.theme.eventWarning.Render(lines[i+1])
+		}
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// orNone fills an empty table cell so the columns stay readable
+func orNone(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// conditionsLoadedMsg carries the rendered conditions table
+type conditionsLoadedMsg struct{ content string }
+
+// loadEtcdConditions is a command that fetches the Etcd conditions asynchronously
+func (m *Model) loadEtcdConditions() tea.Cmd {
+	return func() tea.Msg {
+		content, err := retryFetch(m.fetchEtcdConditions)
+		if err != nil {
+			return errMsg{err}
+		}
+		return conditionsLoadedMsg{content}
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestEtcdConditions(t *testing.T) {
+	obj := map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "BackupReady", "status": "True", "lastTransitionTime": "2024-05-02T10:00:00Z"},
+				map[string]interface{}{"type": "AllMembersReady", "status": "False", "reason": "NotAllMembersReady", "lastTransitionTime": "2024-05-01T10:00:00Z"},
+				// Unexpected shapes must not break the table
+				"garbage",
+				map[string]interface{}{"type": "Ready", "status": int64(1), "lastTransitionTime": "yesterday"},
+			},
+		},
+	}
+
+	got := etcdConditions(obj)
+	var types []string
+	for _, condition := range got {
+		types = append(types, condition.Type)
+	}
+	// Conditions without a usable transition time sort first
+	if want := "Ready,AllMembersReady,BackupReady"; strings.Join(types, ",") != want {
+		t.Fatalf("etcdConditions() order = %v, want %s", types, want)
+	}
+	if got[0].Status != "" {
+		t.Errorf("etcdConditions() status = %q, want a non-string status dropped", got[0].Status)
+	}
+	if got[1].Reason != "NotAllMembersReady" {
+		t.Errorf("etcdConditions() reason = %q, want NotAllMembersReady", got[1].Reason)
+	}
+
+	if got := etcdConditions(map[string]interface{}{"status": "broken"}); len(got) != 0 {
+		t.Errorf("etcdConditions() = %v for a malformed status, want none", got)
+	}
+}
+
+func TestFetchEtcdConditions(t *testing.T) {
+	etcd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "AllMembersReady", "status": "True", "reason": "AllMembersReady", "message": "All members are ready", "lastTransitionTime": "2024-05-01T10:00:00Z"},
+				map[string]interface{}{"type": "BackupReady", "status": "Unknown"},
+			},
+		},
+	}}
+	etcd.SetAPIVersion("druid.gardener.cloud/v1alpha1")
+	etcd.SetKind("Etcd")
+	etcd.SetNamespace(testNamespace)
+	etcd.SetName(testEtcdName)
+
+	m, _ := newTestModel(nil, etcd)
+	got, err := m.fetchEtcdConditions()
+	if err != nil {
+		t.Fatalf("fetchEtcdConditions() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(got), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "TYPE") {
+		t.Fatalf("fetchEtcdConditions() = %q, want a heading and two rows", got)
+	}
+	if !strings.Contains(lines[1], "BackupReady") || !strings.Contains(lines[1], "<unknown>") {
+		t.Errorf("row 1 = %q, want BackupReady without a transition time", lines[1])
+	}
+	for _, want := range []string{"AllMembersReady", "All members are ready", " ago"} {
+		if !strings.Contains(lines[2], want) {
+			t.Errorf("row 2 = %q, missing %q", lines[2], want)
+		}
+	}
+
+	// Absolute times show the transition as reported
+	m.absoluteTimes = true
+	if got, _ := m.fetchEtcdConditions(); !strings.Contains(got, "2024-05-01T10:00:00Z") || strings.Contains(got, " ago") {
+		t.Errorf("fetchEtcdConditions() with absolute times = %q, want the RFC3339 time", got)
+	}
+}
+
+func TestConditionsFromEtcdDescribe(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, tea.WindowSizeMsg{Width: 80, Height: 40})
+	m = update(t, m, keyMsg("D"))
+	if m.state != DescribeState || !m.describeEtcd {
+		t.Fatalf("D opened state %v, want the Etcd describe", m.state)
+	}
+	m = update(t, m, keyMsg("c"))
+	if m.state != ConditionsState {
+		t.Fatalf("c opened state %v, want ConditionsState", m.state)
+	}
+	m = update(t, m, conditionsLoadedMsg{"TYPE  STATUS\n"})
+	if !strings.Contains(m.viewport.View(), "TYPE") {
+		t.Errorf("conditions view = %q, want the table", m.viewport.View())
+	}
+	m = update(t, m, keyMsg("esc"))
+	if m.state != DescribeState {
+		t.Errorf("esc returned to state %v, want DescribeState", m.state)
+	}
+}
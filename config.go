@@ -0,0 +1,171 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v2"
+)
+
+// Config holds the user preferences persisted between runs
+type Config struct {
+	TailLines       int    `yaml:"tailLines"`
+	Timestamps      bool   `yaml:"timestamps"`
+	RefreshInterval string `yaml:"refreshInterval"`
+	RawLogs         bool   `yaml:"rawLogs"`
+	LineNumbers     bool   `yaml:"lineNumbers"`
+	PlainYAML       bool   `yaml:"plainYAML"`
+	CompactList     bool   `yaml:"compactList"`
+	Theme           string `yaml:"theme"`
+	AbsoluteTimes   bool   `yaml:"absoluteTimes"`
+	// Pins are the pinned pods as namespace/name, kept at the top of the list
+	Pins []string `yaml:"pins,omitempty"`
+	// Keys rebinds actions, e.g. describe: "i" or back: "q,esc,backspace"; see keyDefaults for the actions
+	Keys map[string]string `yaml:"keys,omitempty"`
+}
+
+// defaultConfig returns the built-in preferences used when no config file exists
+func defaultConfig() Config {
+	return Config{
+		TailLines:       defaultTailLines,
+		RefreshInterval: defaultRefreshInterval.String(),
+	}
+}
+
+// defaultConfigPath returns ~/.config/etcd-pod-viewer/config.yaml, or the platform equivalent
+func defaultConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "etcd-pod-viewer", "config.yaml"), nil
+}
+
+// unloadedConfigError reports a config file that couldn't be read, which saving the defaults over would destroy
+type unloadedConfigError struct{ error }
+
+func (e unloadedConfigError) Unwrap() error { return e.error }
+
+// loadConfig reads preferences from path
+// A missing file yields the defaults; a malformed one yields the defaults plus an unloadedConfigError to report
+func loadConfig(path string) (Config, error) {
+	cfg := defaultConfig()
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, unloadedConfigError{fmt.Errorf("failed to read config %s, not saving preferences: %w", path, err)}
+	}
+
+	var loaded Config
+	if err := yaml.UnmarshalStrict(data, &loaded); err != nil {
+		return cfg, unloadedConfigError{fmt.Errorf("ignoring malformed config %s, not saving preferences until it is fixed: %w", path, err)}
+	}
+
+	// Keep the defaults for values that are unset or out of range
+	if loaded.TailLines <= 0 {
+		loaded.TailLines = cfg.TailLines
+	}
+	if d, err := time.ParseDuration(loaded.RefreshInterval); err != nil || d <= 0 {
+		loaded.RefreshInterval = cfg.RefreshInterval
+	}
+	if _, ok := palettes[loaded.Theme]; !ok && loaded.Theme != themeAuto {
+		loaded.Theme = cfg.Theme
+	}
+	// Bindings are all or nothing, a partly applied keys section could leave an action without its key
+	// The section is kept as written though, so saving a preference doesn't drop it from the file
+	if _, err := parseKeyMap(loaded.Keys); err != nil {
+		return loaded, fmt.Errorf("ignoring the keys of config %s: %w", path, err)
+	}
+	return loaded, nil
+}
+
+// loadPreferences applies the config file at path and saves changed preferences back to it
+// A file that couldn't be loaded is never saved to, so a hand edit with a typo isn't replaced by the defaults
+func (m *Model) loadPreferences(path string) {
+	cfg, err := loadConfig(path)
+	if err != nil {
+		m.status = err.Error()
+	}
+	m.applyConfig(cfg)
+	if !errors.As(err, new(unloadedConfigError)) {
+		m.configPath = path
+	}
+}
+
+// saveConfig writes preferences to path, creating its directory if needed
+func saveConfig(path string, cfg Config) error {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write config %s: %w", path, err)
+	}
+	return nil
+}
+
+// applyConfig copies preferences onto the Model
+func (m *Model) applyConfig(cfg Config) {
+	m.tailLines = int64(cfg.TailLines)
+	m.timestamps = cfg.Timestamps
+	if d, err := time.ParseDuration(cfg.RefreshInterval); err == nil && d > 0 {
+		m.refreshInterval = d
+	}
+	m.rawLogs = cfg.RawLogs
+	m.lineNumbers = cfg.LineNumbers
+	m.plainYAML = cfg.PlainYAML
+	m.compactList = cfg.CompactList
+	m.absoluteTimes = cfg.AbsoluteTimes
+	m.themeName = cfg.Theme
+	if p, ok := palettes[cfg.Theme]; ok {
+		m.theme = newTheme(cfg.Theme, p)
+	}
+	// An invalid keys section falls back to the defaults, loadConfig already reported it
+	m.keys, _ = parseKeyMap(cfg.Keys)
+	m.pins = cfg.Pins
+	m.updatePodDelegate()
+}
+
+// currentConfig captures the Model's preferences for saving
+func (m Model) currentConfig() Config {
+	return Config{
+		TailLines:       int(m.tailLines),
+		Timestamps:      m.timestamps,
+		RefreshInterval: m.refreshInterval.String(),
+		RawLogs:         m.rawLogs,
+		LineNumbers:     m.lineNumbers,
+		PlainYAML:       m.plainYAML,
+		CompactList:     m.compactList,
+		Theme:           m.themeName,
+		AbsoluteTimes:   m.absoluteTimes,
+		Pins:            m.pins,
+		Keys:            m.keys.custom,
+	}
+}
+
+// configSaveFailedMsg reports that preferences could not be written
+type configSaveFailedMsg struct{ err error }
+
+// persistConfig is a command that saves the current preferences after a setting changed
+// It's a no-op when persistence is disabled, e.g. in tests
+func (m *Model) persistConfig() tea.Cmd {
+	if m.configPath == "" {
+		return nil
+	}
+	path, cfg := m.configPath, m.currentConfig()
+	return func() tea.Msg {
+		if err := saveConfig(path, cfg); err != nil {
+			return configSaveFailedMsg{err}
+		}
+		return nil
+	}
+}
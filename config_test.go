@@ -0,0 +1,144 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content *string
+		want    Config
+		wantErr bool
+	}{
+		{
+			name: "missing file",
+			want: defaultConfig(),
+		},
+		{
+			name:    "valid file",
+			content: ptr("tailLines: 500\ntimestamps: true\nrefreshInterval: 30s\nlineNumbers: true\nabsoluteTimes: true\n"),
+			want:    Config{TailLines: 500, Timestamps: true, RefreshInterval: "30s", LineNumbers: true, AbsoluteTimes: true},
+		},
+		{
+			name:    "out of range values",
+			content: ptr("tailLines: -1\nrefreshInterval: soon\nrawLogs: true\n"),
+			want:    Config{TailLines: defaultTailLines, RefreshInterval: defaultRefreshInterval.String(), RawLogs: true},
+		},
+		{
+			name:    "unknown theme",
+			content: ptr("theme: solarized\ncompactList: true\n"),
+			want:    Config{TailLines: defaultTailLines, RefreshInterval: defaultRefreshInterval.String(), CompactList: true},
+		},
+		{
+			name:    "rebound keys",
+			content: ptr("keys:\n  describe: i\n  back: q, esc, backspace\n"),
+			want:    Config{TailLines: defaultTailLines, RefreshInterval: defaultRefreshInterval.String(), Keys: map[string]string{"describe": "i", "back": "q, esc, backspace"}},
+		},
+		{
+			name:    "conflicting keys",
+			content: ptr("timestamps: true\nkeys:\n  describe: l\n"),
+			want:    Config{TailLines: defaultTailLines, RefreshInterval: defaultRefreshInterval.String(), Timestamps: true, Keys: map[string]string{"describe": "l"}},
+			wantErr: true,
+		},
+		{
+			name:    "malformed yaml",
+			content: ptr("tailLines: [\n"),
+			want:    defaultConfig(),
+			wantErr: true,
+		},
+		{
+			name:    "unknown key",
+			content: ptr("tailLine: 10\n"),
+			want:    defaultConfig(),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if tt.content != nil {
+				if err := os.WriteFile(path, []byte(*tt.content), 0o644); err != nil {
+					t.Fatalf("failed to write config: %v", err)
+				}
+			}
+
+			got, err := loadConfig(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveConfigRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "config.yaml")
+
+	m, _ := newTestModel(nil)
+	m.configPath = path
+	m.timestamps = true
+	m.plainYAML = true
+	if msg := m.persistConfig()(); msg != nil {
+		t.Fatalf("persistConfig() = %v, want nil", msg)
+	}
+
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("loadConfig() error = %v", err)
+	}
+	if !reflect.DeepEqual(cfg, m.currentConfig()) {
+		t.Errorf("loadConfig() = %+v, want %+v", cfg, m.currentConfig())
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestInvalidKeysKeptOnSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("keys:\n  describe: l\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	m, _ := newTestModel(nil)
+	m.loadPreferences(path)
+	if m.configPath != path || !strings.Contains(m.status, "ignoring the keys") || m.keys.action(ListState, "d") != actionDescribe {
+		t.Fatalf("loadPreferences() of conflicting keys: configPath %q, status %q, want the defaults in use and saving enabled", m.configPath, m.status)
+	}
+	m = update(t, m, keyMsg("t"))
+	if cmd := m.persistConfig(); cmd != nil {
+		cmd()
+	}
+	saved, err := loadConfig(path)
+	if err == nil || saved.Keys["describe"] != "l" || !saved.CompactList {
+		t.Errorf("config after a toggle = %+v, want the toggle saved along with the keys as written", saved)
+	}
+}
+
+func TestMalformedConfigNotOverwritten(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "tailLines: 500\ntimestamps: [\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	m, _ := newTestModel(nil)
+	m.loadPreferences(path)
+	if m.configPath != "" || !strings.Contains(m.status, "not saving preferences") {
+		t.Fatalf("loadPreferences() of a malformed file: configPath %q, status %q", m.configPath, m.status)
+	}
+	m = update(t, m, keyMsg("t"))
+	if cmd := m.persistConfig(); cmd != nil {
+		cmd()
+	}
+	if data, _ := os.ReadFile(path); string(data) != content {
+		t.Errorf("config after a toggle = %q, want the malformed file left as it was", data)
+	}
+}
@@ -0,0 +1,372 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// describeContainer renders one container of a pod in depth: what it runs, its environment, resources, mounts and probes
+// Env vars are resolved as the kubelet would, except that secret values stay hidden behind the name of their source
+func (m *Model) describeContainer(namespace, podName, containerName string) (string, error) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	pod, err := m.kubeClient.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to describe container %s of pod %s: %w", containerName, podName, err)
+	}
+
+	container, kind, ok := findContainer(pod.Spec, containerName)
+	if !ok {
+		return "", fmt.Errorf("container %s not found in pod %s", containerName, podName)
+	}
+	statuses := append(append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...), pod.Status.EphemeralContainerStatuses...)
+
+	var desc strings.Builder
+	desc.WriteString(fmt.Sprintf("Name: %s\n", container.Name))
+	desc.WriteString(fmt.Sprintf("Pod: %s/%s\n", pod.Namespace, pod.Name))
+	desc.WriteString(fmt.Sprintf("Kind: %s\n", kind))
+	desc.WriteString(fmt.Sprintf("Image: %s\n", container.Image))
+	for _, status := range statuses {
+		if status.Name == container.Name {
+			desc.WriteString(fmt.Sprintf("State: %s\n", containerStateSummary(status)))
+			desc.WriteString(fmt.Sprintf("Restarts: %d\n", status.RestartCount))
+		}
+	}
+	desc.WriteString(m.oomKilledLine("", statuses, container.Name))
+
+	desc.WriteString(fmt.Sprintf("\nCommand: %s\n", formatArgs(container.Command)))
+	desc.WriteString(fmt.Sprintf("Args: %s\n", formatArgs(container.Args)))
+	if container.WorkingDir != "" {
+		desc.WriteString(fmt.Sprintf("Working Dir: %s\n", container.WorkingDir))
+	}
+
+	desc.WriteString("\nPorts:\n")
+	if len(container.Ports) == 0 {
+		desc.WriteString("  <none>\n")
+	}
+	for _, port := range container.Ports {
+		line := fmt.Sprintf("  %d/%s", port.ContainerPort, port.Protocol)
+		if port.Name != "" {
+			line += fmt.Sprintf(" (%s)", port.Name)
+		}
+		desc.WriteString(line + "\n")
+	}
+
+	desc.WriteString("\nEnvironment:\n")
+	if len(container.Env) == 0 && len(container.EnvFrom) == 0 {
+		desc.WriteString("  <none>\n")
+	}
+	sources := envSources{pod: pod, configMaps: m.fetchEnvConfigMaps(namespace, container)}
+	for _, env := range container.Env {
+		desc.WriteString(fmt.Sprintf("  %s: %s\n", env.Name, sources.formatEnvValue(container, env)))
+	}
+	for _, source := range container.EnvFrom {
+		desc.WriteString(fmt.Sprintf("  %s\n", formatEnvFrom(source, sources.configMaps)))
+		if source.ConfigMapRef == nil {
+			continue
+		}
+		if cm := sources.configMaps[source.ConfigMapRef.Name]; cm.err == nil {
+			for _, key := range sortedKeys(cm.data) {
+				desc.WriteString(fmt.Sprintf("    %s%s: %s\n", source.Prefix, key, cm.data[key]))
+			}
+		}
+	}
+
+	desc.WriteString("\nResources:\n")
+	desc.WriteString(fmt.Sprintf("  Requests: %s\n", formatResourceList(container.Resources.Requests)))
+	desc.WriteString(fmt.Sprintf("  Limits: %s\n", formatResourceList(container.Resources.Limits)))
+
+	desc.WriteString("\nMounts:\n")
+	if len(container.VolumeMounts) == 0 {
+		desc.WriteString("  <none>\n")
+	}
+	for _, mount := range container.VolumeMounts {
+		line := fmt.Sprintf("  %s from %s", mount.MountPath, mount.Name)
+		if mount.SubPath != "" {
+			line += fmt.Sprintf(" (path %s)", mount.SubPath)
+		}
+		if mount.ReadOnly {
+			line += " (ro)"
+		} else {
+			line += " (rw)"
+		}
+		desc.WriteString(line + "\n")
+	}
+
+	desc.WriteString("\nProbes:\n")
+	writeProbes(&desc, "  ", container, statuses)
+	writeLifecycleHooks(&desc, container, pod.Spec.TerminationGracePeriodSeconds)
+
+	return desc.String(), nil
+}
+
+// writeLifecycleHooks renders the postStart and preStop hooks of a container, leaving the section out without any
+// A preStop hook runs within the pod's termination grace period, which is shown along with it for slow shutdowns
+func writeLifecycleHooks(desc *strings.Builder, container corev1.Container, gracePeriod *int64) {
+	lifecycle := container.Lifecycle
+	if lifecycle == nil || (lifecycle.PostStart == nil && lifecycle.PreStop == nil) {
+		return
+	}
+	desc.WriteString("\nLifecycle Hooks:\n")
+	if lifecycle.PostStart != nil {
+		desc.WriteString(fmt.Sprintf("  PostStart: %s\n", formatLifecycleHandler(lifecycle.PostStart)))
+	}
+	if lifecycle.PreStop != nil {
+		line := fmt.Sprintf("  PreStop: %s", formatLifecycleHandler(lifecycle.PreStop))
+		if gracePeriod != nil {
+			line += fmt.Sprintf(" (grace period %ds)", *gracePeriod)
+		}
+		desc.WriteString(line + "\n")
+	}
+}
+
+// formatLifecycleHandler renders what a hook does, like formatProbe does for the action of a probe
+func formatLifecycleHandler(handler *corev1.LifecycleHandler) string {
+	switch {
+	case handler.Exec != nil:
+		return "exec " + formatArgs(handler.Exec.Command)
+	case handler.HTTPGet != nil:
+		scheme := strings.ToLower(string(handler.HTTPGet.Scheme))
+		if scheme == "" {
+			scheme = "http"
+		}
+		return fmt.Sprintf("http-get %s://%s:%s%s", scheme, handler.HTTPGet.Host, handler.HTTPGet.Port.String(), handler.HTTPGet.Path)
+	case handler.TCPSocket != nil:
+		return fmt.Sprintf("tcp-socket %s:%s", handler.TCPSocket.Host, handler.TCPSocket.Port.String())
+	case handler.Sleep != nil:
+		return fmt.Sprintf("sleep %ds", handler.Sleep.Seconds)
+	}
+	return "unknown"
+}
+
+// findContainer looks a container up by name among the regular, init and ephemeral containers of a pod
+// Ephemeral containers share the fields of regular ones, so they are converted to describe them alike
+func findContainer(spec corev1.PodSpec, name string) (corev1.Container, string, bool) {
+	for _, container := range spec.Containers {
+		if container.Name == name {
+			return container, "container", true
+		}
+	}
+	for _, container := range spec.InitContainers {
+		if container.Name == name {
+			return container, "init container", true
+		}
+	}
+	for _, container := range spec.EphemeralContainers {
+		if container.Name == name {
+			return corev1.Container(container.EphemeralContainerCommon), "ephemeral container", true
+		}
+	}
+	return corev1.Container{}, "", false
+}
+
+// formatArgs renders a command or its arguments, quoting those with spaces so they read back unambiguously
+func formatArgs(args []string) string {
+	if len(args) == 0 {
+		return "<image default>"
+	}
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n\"'") {
+			arg = fmt.Sprintf("%q", arg)
+		}
+		quoted[i] = arg
+	}
+	return strings.Join(quoted, " ")
+}
+
+// configMapLookup is a config map referenced by env vars, or why it couldn't be read
+type configMapLookup struct {
+	data map[string]string
+	err  error
+}
+
+// fetchEnvConfigMaps reads every config map the env of container refers to, each once
+func (m *Model) fetchEnvConfigMaps(namespace string, container corev1.Container) map[string]configMapLookup {
+	var names []string
+	for _, env := range container.Env {
+		if env.ValueFrom != nil && env.ValueFrom.ConfigMapKeyRef != nil {
+			names = append(names, env.ValueFrom.ConfigMapKeyRef.Name)
+		}
+	}
+	for _, source := range container.EnvFrom {
+		if source.ConfigMapRef != nil {
+			names = append(names, source.ConfigMapRef.Name)
+		}
+	}
+
+	lookups := make(map[string]configMapLookup)
+	for _, name := range names {
+		if _, ok := lookups[name]; ok {
+			continue
+		}
+		ctx, cancel := m.requestContext()
+		cm, err := m.kubeClient.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+		cancel()
+		switch {
+		case apierrors.IsNotFound(err):
+			lookups[name] = configMapLookup{err: errors.New("not found")}
+		case apierrors.IsForbidden(err):
+			lookups[name] = configMapLookup{err: errors.New("not permitted to read it")}
+		case err != nil:
+			lookups[name] = configMapLookup{err: err}
+		default:
+			lookups[name] = configMapLookup{data: cm.Data}
+		}
+	}
+	return lookups
+}
+
+// envSources is what env var values are resolved from: the pod itself and the config maps it refers to
+type envSources struct {
+	pod        *corev1.Pod
+	configMaps map[string]configMapLookup
+}
+
+// formatEnvValue renders the value of an env var, resolving values that come from elsewhere and naming their source
+// Secret values are never read, so describing a container doesn't leak credentials
+func (s envSources) formatEnvValue(container corev1.Container, env corev1.EnvVar) string {
+	source := env.ValueFrom
+	switch {
+	case source == nil:
+		return env.Value
+	case source.SecretKeyRef != nil:
+		return fmt.Sprintf("(from secret %s/%s)", source.SecretKeyRef.Name, source.SecretKeyRef.Key)
+	case source.ConfigMapKeyRef != nil:
+		ref := source.ConfigMapKeyRef
+		from := fmt.Sprintf("from config map %s/%s", ref.Name, ref.Key)
+		cm := s.configMaps[ref.Name]
+		value, ok := cm.data[ref.Key]
+		switch {
+		case cm.err != nil:
+			return fmt.Sprintf("(%s, %v%s)", from, cm.err, optionalNote(ref.Optional))
+		case !ok:
+			return fmt.Sprintf("(%s, key not found%s)", from, optionalNote(ref.Optional))
+		}
+		return fmt.Sprintf("%s (%s)", value, from)
+	case source.FieldRef != nil:
+		if value, ok := podFieldValue(s.pod, source.FieldRef.FieldPath); ok {
+			return fmt.Sprintf("%s (from %s)", value, source.FieldRef.FieldPath)
+		}
+		return fmt.Sprintf("(from %s)", source.FieldRef.FieldPath)
+	case source.ResourceFieldRef != nil:
+		return formatResourceFieldRef(s.pod, container, source.ResourceFieldRef)
+	}
+	return "<unknown source>"
+}
+
+// optionalNote marks a reference the kubelet skips when it can't be resolved
+func optionalNote(optional *bool) string {
+	if optional != nil && *optional {
+		return ", optional"
+	}
+	return ""
+}
+
+// podFieldValue resolves the downward API field paths allowed in env vars
+func podFieldValue(pod *corev1.Pod, path string) (string, bool) {
+	switch path {
+	case "metadata.name":
+		return pod.Name, true
+	case "metadata.namespace":
+		return pod.Namespace, true
+	case "metadata.uid":
+		return string(pod.UID), true
+	case "spec.nodeName":
+		return pod.Spec.NodeName, true
+	case "spec.serviceAccountName":
+		return pod.Spec.ServiceAccountName, true
+	case "status.podIP":
+		return pod.Status.PodIP, true
+	case "status.hostIP":
+		return pod.Status.HostIP, true
+	}
+	// metadata.labels['key'] and metadata.annotations['key']
+	for prefix, values := range map[string]map[string]string{"metadata.labels": pod.Labels, "metadata.annotations": pod.Annotations} {
+		if key, ok := strings.CutPrefix(path, prefix+"['"); ok && strings.HasSuffix(key, "']") {
+			value, ok := values[strings.TrimSuffix(key, "']")]
+			return value, ok
+		}
+	}
+	return "", false
+}
+
+// formatResourceFieldRef resolves a request or limit exposed as an env var, e.g. "1Gi (from limits.memory)"
+// The divisor is left out, the quantity itself is what was configured
+func formatResourceFieldRef(pod *corev1.Pod, container corev1.Container, ref *corev1.ResourceFieldSelector) string {
+	from := "from " + ref.Resource
+	if ref.ContainerName != "" && ref.ContainerName != container.Name {
+		from += " of container " + ref.ContainerName
+		if other, _, ok := findContainer(pod.Spec, ref.ContainerName); ok {
+			container = other
+		}
+	}
+	kind, name, _ := strings.Cut(ref.Resource, ".")
+	resources := container.Resources.Requests
+	if kind == "limits" {
+		resources = container.Resources.Limits
+	}
+	quantity, ok := resources[corev1.ResourceName(name)]
+	if !ok {
+		if kind == "limits" {
+			return fmt.Sprintf("(%s, unset so the node's allocatable applies)", from)
+		}
+		return fmt.Sprintf("(%s, unset)", from)
+	}
+	return fmt.Sprintf("%s (%s)", quantity.String(), from)
+}
+
+// formatEnvFrom renders a whole secret or config map imported as env vars
+// A config map's keys are listed below it by describeContainer; a secret's stay hidden
+func formatEnvFrom(source corev1.EnvFromSource, configMaps map[string]configMapLookup) string {
+	var from string
+	switch {
+	case source.SecretRef != nil:
+		from = fmt.Sprintf("secret %s, values hidden", source.SecretRef.Name)
+	case source.ConfigMapRef != nil:
+		from = fmt.Sprintf("config map %s", source.ConfigMapRef.Name)
+		if err := configMaps[source.ConfigMapRef.Name].err; err != nil {
+			from += fmt.Sprintf(" (%v%s)", err, optionalNote(source.ConfigMapRef.Optional))
+		}
+	default:
+		from = "unknown source"
+	}
+	if source.Prefix != "" {
+		return fmt.Sprintf("all keys of %s, prefixed %s", from, source.Prefix)
+	}
+	return fmt.Sprintf("all keys of %s", from)
+}
+
+// sortedKeys returns the keys of a string map in order, so describes are stable between refreshes
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatResourceList renders resource quantities sorted by name, e.g. "cpu=100m, memory=1Gi"
+func formatResourceList(resources corev1.ResourceList) string {
+	if len(resources) == 0 {
+		return "<none>"
+	}
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		quantity := resources[corev1.ResourceName(name)]
+		pairs[i] = fmt.Sprintf("%s=%s", name, quantity.String())
+	}
+	return strings.Join(pairs, ", ")
+}
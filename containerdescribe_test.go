@@ -0,0 +1,142 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestDescribeContainer(t *testing.T) {
+	pod := testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"), runningContainer("backup-restore"))
+	sidecar := &pod.Spec.Containers[1]
+	sidecar.Command = []string{"etcdbrctl", "server"}
+	sidecar.Args = []string{"--schedule=0 */24 * * *"}
+	sidecar.Ports = []corev1.ContainerPort{{Name: "server", ContainerPort: 8080, Protocol: corev1.ProtocolTCP}}
+	sidecar.Env = []corev1.EnvVar{
+		{Name: "STORAGE_CONTAINER", Value: "backups"},
+		{Name: "AWS_SECRET_ACCESS_KEY", ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "etcd-backup"}, Key: "secretAccessKey"},
+		}},
+		{Name: "POD_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{APIVersion: "v1", FieldPath: "metadata.name"}}},
+		{Name: "ROLE", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels['role']"}}},
+		{Name: "PROVIDER", ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "etcd-bootstrap"}, Key: "provider",
+		}}},
+		{Name: "REGION", ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "etcd-bootstrap"}, Key: "region",
+		}}},
+		{Name: "ENDPOINT", ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "etcd-storage"}, Key: "endpoint", Optional: ptr(true),
+		}}},
+		{Name: "MEMORY_REQUEST", ValueFrom: &corev1.EnvVarSource{ResourceFieldRef: &corev1.ResourceFieldSelector{Resource: "requests.memory"}}},
+		{Name: "MEMORY_LIMIT", ValueFrom: &corev1.EnvVarSource{ResourceFieldRef: &corev1.ResourceFieldSelector{Resource: "limits.memory"}}},
+	}
+	pod.Labels = map[string]string{"role": "main"}
+	sidecar.EnvFrom = []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "etcd-bootstrap"}}}}
+	sidecar.Resources = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi"), corev1.ResourceCPU: resource.MustParse("50m")},
+	}
+	sidecar.VolumeMounts = []corev1.VolumeMount{{Name: "etcd-backup", MountPath: "/var/etcd-backup", ReadOnly: true}}
+	sidecar.Lifecycle = &corev1.Lifecycle{
+		PostStart: &corev1.LifecycleHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/ready", Port: intstr.FromInt32(8080)}},
+		PreStop:   &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"sh", "-c", "sleep 5"}}},
+	}
+	pod.Spec.TerminationGracePeriodSeconds = ptr(int64(30))
+
+	bootstrap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "etcd-bootstrap", Namespace: testNamespace},
+		Data:       map[string]string{"provider": "aws", "snapshotPeriod": "5m"},
+	}
+	m, _ := newTestModel([]runtime.Object{pod, bootstrap})
+	desc, err := m.describeContainer(testNamespace, "etcd-main-0", "backup-restore")
+	if err != nil {
+		t.Fatalf("describeContainer() error = %v", err)
+	}
+	for _, want := range []string{
+		"Name: backup-restore\n",
+		"Kind: container\n",
+		"State: Running",
+		`Command: etcdbrctl server`,
+		`Args: "--schedule=0 */24 * * *"`,
+		"8080/TCP (server)",
+		"STORAGE_CONTAINER: backups",
+		"AWS_SECRET_ACCESS_KEY: (from secret etcd-backup/secretAccessKey)",
+		"POD_NAME: etcd-main-0 (from metadata.name)",
+		"ROLE: main (from metadata.labels['role'])",
+		"PROVIDER: aws (from config map etcd-bootstrap/provider)",
+		"REGION: (from config map etcd-bootstrap/region, key not found)",
+		"ENDPOINT: (from config map etcd-storage/endpoint, not found, optional)",
+		"MEMORY_REQUEST: 128Mi (from requests.memory)",
+		"MEMORY_LIMIT: (from limits.memory, unset so the node's allocatable applies)",
+		"all keys of config map etcd-bootstrap\n",
+		"    snapshotPeriod: 5m\n",
+		"Requests: cpu=50m, memory=128Mi",
+		"Limits: <none>",
+		"/var/etcd-backup from etcd-backup (ro)",
+		"  Ready: true",
+		"\nLifecycle Hooks:\n  PostStart: http-get http://:8080/ready\n  PreStop: exec sh -c \"sleep 5\" (grace period 30s)\n",
+	} {
+		if !strings.Contains(desc, want) {
+			t.Errorf("describeContainer() missing %q:\n%s", want, desc)
+		}
+	}
+	// Only the chosen container is described
+	if strings.Contains(desc, "Name: etcd\n") {
+		t.Errorf("describeContainer() described another container:\n%s", desc)
+	}
+
+	// Without hooks the section is left out
+	if desc, err := m.describeContainer(testNamespace, "etcd-main-0", "etcd"); err != nil || strings.Contains(desc, "Lifecycle") {
+		t.Errorf("describeContainer() of etcd = %v:\n%s", err, desc)
+	}
+
+	if _, err := m.describeContainer(testNamespace, "etcd-main-0", "missing"); err == nil {
+		t.Error("describeContainer() of an unknown container succeeded")
+	}
+}
+
+func TestDescribeContainerFromSelection(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("l"))
+	m = update(t, m, containersLoadedMsg{[]Container{{Name: "etcd"}, {Name: "backup-restore"}}})
+	m.containerList.Select(1)
+
+	m = update(t, m, keyMsg("d"))
+	if m.state != DescribeState || m.describeOne != "backup-restore" {
+		t.Fatalf("d opened state %v for %q, want the backup-restore describe", m.state, m.describeOne)
+	}
+	m = update(t, m, keyMsg("esc"))
+	if m.state != ContainerSelectState {
+		t.Errorf("esc returned to state %v, want ContainerSelectState", m.state)
+	}
+
+	// Describing the pod afterwards covers the whole pod again
+	m = update(t, m, keyMsg("esc"))
+	m = update(t, m, keyMsg("d"))
+	if m.describeOne != "" {
+		t.Errorf("pod describe kept the container %q", m.describeOne)
+	}
+}
+
+func TestFormatArgs(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{nil, "<image default>"},
+		{[]string{"etcd", "--name=etcd-main-0"}, "etcd --name=etcd-main-0"},
+		{[]string{"sh", "-c", "echo hi"}, `sh -c "echo hi"`},
+		{[]string{""}, `""`},
+	}
+	for _, tt := range tests {
+		if got := formatArgs(tt.args); got != tt.want {
+			t.Errorf("formatArgs(%q) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// nodeCordonedMsg reports the outcome of cordoning or uncordoning a node
+type nodeCordonedMsg struct {
+	node          string
+	unschedulable bool
+	err           error
+}
+
+// toggleCordon flips spec.unschedulable on a node, like kubectl cordon/uncordon
+// The current state is read first so the key always does the opposite of what describe showed
+func (m *Model) toggleCordon(nodeName string) tea.Cmd {
+	m.beginOperation(cordonOperation(nodeName))
+	return func() tea.Msg {
+		ctx, cancel := m.requestContext()
+		defer cancel()
+		node, err := m.kubeClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			return nodeCordonedMsg{node: nodeName, err: explainCordonError(nodeName, "get", err)}
+		}
+
+		unschedulable := !node.Spec.Unschedulable
+		patch := fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable)
+		after, err := m.kubeClient.CoreV1().Nodes().Patch(ctx, nodeName,
+			types.StrategicMergePatchType, []byte(patch), m.patchOptions())
+		if err != nil {
+			return nodeCordonedMsg{node: nodeName, err: explainCordonError(nodeName, "patch", err)}
+		}
+		if m.dryRun {
+			verb := "uncordon"
+			if unschedulable {
+				verb = "cordon"
+			}
+			return dryRunResult(cordonOperation(nodeName), verb+" node "+nodeName, node, after)
+		}
+		return nodeCordonedMsg{node: nodeName, unschedulable: unschedulable}
+	}
+}
+
+// explainCordonError turns RBAC denials into a note about the missing permission
+func explainCordonError(nodeName, verb string, err error) error {
+	if apierrors.IsForbidden(err) {
+		return fmt.Errorf("not permitted to %s node %s", verb, nodeName)
+	}
+	return fmt.Errorf("failed to %s node %s: %w", verb, nodeName, err)
+}
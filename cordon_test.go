@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestToggleCordon(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}}
+	m, client := newTestModel([]runtime.Object{node})
+
+	for _, want := range []bool{true, false} {
+		msg, ok := m.toggleCordon("node-a")().(nodeCordonedMsg)
+		if !ok || msg.err != nil {
+			t.Fatalf("toggleCordon() = %+v", msg)
+		}
+		if msg.unschedulable != want {
+			t.Errorf("toggleCordon() unschedulable = %v, want %v", msg.unschedulable, want)
+		}
+		got, err := client.CoreV1().Nodes().Get(context.Background(), "node-a", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get node: %v", err)
+		}
+		if got.Spec.Unschedulable != want {
+			t.Errorf("node unschedulable = %v, want %v", got.Spec.Unschedulable, want)
+		}
+	}
+}
+
+func TestToggleCordonForbidden(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}}
+	m, client := newTestModel([]runtime.Object{node})
+	client.PrependReactor("patch", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "nodes"}, "node-a", nil)
+	})
+
+	msg := m.toggleCordon("node-a")().(nodeCordonedMsg)
+	if msg.err == nil || msg.err.Error() != "not permitted to patch node node-a" {
+		t.Errorf("toggleCordon() error = %v, want a permission note", msg.err)
+	}
+}
+
+func TestCordonGuardedByReadOnly(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m.readOnly = true
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0", Node: "node-a"}}})
+	m = update(t, m, keyMsg("d"))
+	m = update(t, m, keyMsg("c"))
+	if !strings.Contains(m.status, "read-only") {
+		t.Errorf("status = %q, want the read-only notice", m.status)
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+// errEtcdCRDNotInstalled is wrapped into errors reading the Etcd resource when the cluster doesn't serve the kind at all
+var errEtcdCRDNotInstalled = errors.New("Etcd CRD not installed on this cluster")
+
+// isEtcdCRDMissing reports whether err means the Etcd kind is unknown, as opposed to this Etcd being missing
+// The API server answers requests for an unserved resource with a NotFound that names no object
+func isEtcdCRDMissing(err error) bool {
+	if meta.IsNoMatchError(err) {
+		return true
+	}
+	var status apierrors.APIStatus
+	if !apierrors.IsNotFound(err) || !errors.As(err, &status) {
+		return false
+	}
+	details := status.Status().Details
+	return details == nil || details.Name == ""
+}
+
+// etcdCRDMissingNotice replaces a view of the Etcd resource when the CRD isn't installed
+// The pod views don't need the CRD, so it points back to them instead of ending on an error screen
+func etcdCRDMissingNotice() string {
+	return fmt.Sprintf("%s.\n\n"+
+		"The %s resource is served by etcd-druid, which doesn't seem to run on this cluster.\n"+
+		"Check that the kubeconfig context is the right cluster, or install etcd-druid and its CRDs.\n\n"+
+		"The pod views (logs, describe, yaml, metrics and events) still work, press esc to go back.\n",
+		errEtcdCRDNotInstalled, etcdGVR.GroupResource())
+}
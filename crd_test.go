@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// withoutEtcdCRD makes the fake API server answer every Etcd request like a cluster that doesn't serve the kind
+func withoutEtcdCRD(m Model) {
+	m.dynamicClient.(*dynamicfake.FakeDynamicClient).PrependReactor("*", "etcds",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewNotFound(schema.GroupResource{}, "")
+		})
+}
+
+func TestIsEtcdCRDMissing(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"no kind match", &meta.NoKindMatchError{GroupKind: schema.GroupKind{Group: etcdGVR.Group, Kind: "Etcd"}}, true},
+		{"resource not served", apierrors.NewNotFound(schema.GroupResource{}, ""), true},
+		{"etcd missing", apierrors.NewNotFound(etcdGVR.GroupResource(), testEtcdName), false},
+		{"forbidden", apierrors.NewForbidden(etcdGVR.GroupResource(), testEtcdName, errors.New("denied")), false},
+		{"other", errors.New("connection refused"), false},
+	}
+	for _, tt := range tests {
+		if got := isEtcdCRDMissing(tt.err); got != tt.want {
+			t.Errorf("isEtcdCRDMissing(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFetchEtcdResourceWithoutCRD(t *testing.T) {
+	m, _ := newTestModel(nil)
+	withoutEtcdCRD(m)
+	_, err := m.fetchEtcdResource()
+	if !errors.Is(err, errEtcdCRDNotInstalled) {
+		t.Errorf("fetchEtcdResource() error = %v, want errEtcdCRDNotInstalled", err)
+	}
+}
+
+func TestEtcdViewsWithoutCRD(t *testing.T) {
+	m, _ := newTestModel([]runtime.Object{testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))})
+	withoutEtcdCRD(m)
+	m = update(t, m, tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	// The pods still list, just without roles
+	pods, err := m.fetchEtcdPods()
+	if err != nil || len(pods) != 1 {
+		t.Fatalf("fetchEtcdPods() = %v, %v, want the pod despite the missing CRD", pods, err)
+	}
+	m = update(t, m, podsLoadedMsg{pods: pods})
+
+	m = update(t, m, keyMsg("D"))
+	m = update(t, m, m.loadEtcdDescribe()())
+	if m.err != nil {
+		t.Fatalf("Etcd describe ended on the error screen: %v", m.err)
+	}
+	if !strings.Contains(m.viewport.View(), "Etcd CRD not installed on this cluster") {
+		t.Errorf("Etcd describe = %q, want the CRD notice", m.viewport.View())
+	}
+
+	m = update(t, m, keyMsg("esc"))
+	if m.state != ListState {
+		t.Errorf("esc returned to state %v, want ListState", m.state)
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// quorumState summarizes whether enough members are ready for etcd to accept writes
+// A cluster of n members needs n/2+1 of them; below that it is read-only at best
+func quorumState(members []etcdMemberStatus) string {
+	if len(members) == 0 {
+		return "unknown, no members reported"
+	}
+	ready := 0
+	for _, member := range members {
+		if member.Status == "Ready" {
+			ready++
+		}
+	}
+	needed := len(members)/2 + 1
+	state := "healthy"
+	switch {
+	case ready < needed:
+		state = "LOST"
+	case ready < len(members):
+		state = "degraded"
+	}
+	return fmt.Sprintf("%s, %d of %d members ready, %d needed", state, ready, len(members), needed)
+}
+
+// fetchDashboard renders the at-a-glance health of the etcd: the Etcd status, quorum, each pod and any warnings
+// The pods are listed even when the Etcd resource can't be read, since they alone answer part of the question
+func (m *Model) fetchDashboard() (string, error) {
+	pods, err := m.fetchEtcdPods()
+	if err != nil {
+		return "", err
+	}
+
+	var out, warnings strings.Builder
+	etcd, err := m.fetchEtcdResource()
+	switch {
+	case errors.Is(err, errEtcdCRDNotInstalled):
+		out.WriteString(fmt.Sprintf("Etcd %s: %s\n", m.etcdName, errEtcdCRDNotInstalled))
+	case err != nil:
+		out.WriteString(fmt.Sprintf("Etcd %s: unavailable (%v)\n", m.etcdName, err))
+	default:
+		obj := etcd.Object
+		out.WriteString(fmt.Sprintf("Etcd %s: ready %s, %s of %s replicas ready\n", m.etcdName,
+			describeField(obj, "status", "ready"), describeField(obj, "status", "readyReplicas"), describeField(obj, "status", "replicas")))
+		// The columns kubectl get shows, as the CRD declares them
+		out.WriteString(m.renderEtcdTable([]unstructured.Unstructured{*etcd}, m.fetchPrinterColumns(), false))
+
+		// An undecodable members list leaves the quorum unknown instead of failing the dashboard
+		var decoded etcdStatusMembers
+		_ = runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &decoded)
+		leader := "none"
+		for _, member := range decoded.Status.Members {
+			if memberRole(member) == "leader" {
+				leader = member.Name
+			}
+		}
+		out.WriteString(fmt.Sprintf("Members: %d, leader %s\n", len(decoded.Status.Members), leader))
+		out.WriteString(fmt.Sprintf("Quorum: %s\n", quorumState(decoded.Status.Members)))
+
+		conditions := etcdConditions(obj)
+		out.WriteString(summarizeBackup(conditions, m.formatTimestamp) + "\n")
+		for _, condition := range conditions {
+			if condition.Status == "True" {
+				continue
+			}
+			line := fmt.Sprintf("  %s: %s", orNone(condition.Type), orNone(condition.Status))
+			if condition.Reason != "" {
+				line += fmt.Sprintf(" (%s)", condition.Reason)
+			}
+			if condition.Message != "" {
+				line += " - " + condition.Message
+			}
+			warnings.WriteString(m.theme.eventWarning.Render(line) + "\n")
+		}
+	}
+
+	out.WriteString("\nPods:\n")
+	if len(pods) == 0 {
+		out.WriteString("  none found\n")
+	}
+	var table strings.Builder
+	w := tabwriter.NewWriter(&table, 0, 0, 2, ' ', 0)
+	for _, pod := range pods {
+		role := pod.Role
+		if role == "" {
+			role = "not registered"
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\trestarts %d\n", pod.Name, pod.Status, pod.Ready, role, pod.Restarts)
+		if pod.OOMKilled != "" {
+			warnings.WriteString(m.theme.eventWarning.Render(fmt.Sprintf("  pod %s had OOMKilled containers: %s", pod.Name, pod.OOMKilled)) + "\n")
+		}
+		if !pod.AllReady {
+			warnings.WriteString(m.theme.eventWarning.Render(fmt.Sprintf("  pod %s is not ready (%s, %s)", pod.Name, pod.Status, pod.Ready)) + "\n")
+		}
+	}
+	w.Flush()
+	out.WriteString(table.String())
+
+	out.WriteString("\nWarnings:\n")
+	if warnings.Len() == 0 {
+		out.WriteString("  none\n")
+	}
+	out.WriteString(warnings.String())
+	return out.String(), nil
+}
+
+// dashboardLoadedMsg carries the rendered dashboard
+type dashboardLoadedMsg struct{ content string }
+
+// loadDashboard is a command that fetches the dashboard asynchronously
+func (m *Model) loadDashboard() tea.Cmd {
+	return func() tea.Msg {
+		content, err := retryFetch(m.fetchDashboard)
+		if err != nil {
+			return errMsg{err}
+		}
+		return dashboardLoadedMsg{content}
+	}
+}
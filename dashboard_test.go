@@ -0,0 +1,115 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestQuorumState(t *testing.T) {
+	member := func(status string) etcdMemberStatus { return etcdMemberStatus{Status: status} }
+	tests := []struct {
+		name    string
+		members []etcdMemberStatus
+		want    string
+	}{
+		{"none", nil, "unknown, no members reported"},
+		{"all ready", []etcdMemberStatus{member("Ready"), member("Ready"), member("Ready")}, "healthy, 3 of 3 members ready, 2 needed"},
+		{"one down", []etcdMemberStatus{member("Ready"), member("NotReady"), member("Ready")}, "degraded, 2 of 3 members ready, 2 needed"},
+		{"two down", []etcdMemberStatus{member("Ready"), member("NotReady"), member("Unknown")}, "LOST, 1 of 3 members ready, 2 needed"},
+	}
+	for _, tt := range tests {
+		if got := quorumState(tt.members); got != tt.want {
+			t.Errorf("quorumState(%s) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFetchDashboard(t *testing.T) {
+	etcd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"ready":         false,
+			"replicas":      int64(3),
+			"readyReplicas": int64(2),
+			"members": []interface{}{
+				map[string]interface{}{"name": "etcd-main-0", "role": "Leader", "status": "Ready"},
+				map[string]interface{}{"name": "etcd-main-1", "role": "Member", "status": "Ready"},
+				map[string]interface{}{"name": "etcd-main-2", "role": "Member", "status": "NotReady"},
+			},
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "BackupReady", "status": "True", "reason": "FullSnapshotTaken"},
+				map[string]interface{}{"type": "AllMembersReady", "status": "False", "reason": "NotAllMembersReady"},
+			},
+		},
+	}}
+	etcd.SetAPIVersion("druid.gardener.cloud/v1alpha1")
+	etcd.SetKind("Etcd")
+	etcd.SetNamespace(testNamespace)
+	etcd.SetName(testEtcdName)
+
+	m, _ := newTestModel([]runtime.Object{
+		testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd")),
+		testPod("etcd-main-2", corev1.PodPending, crashLoopingContainer("etcd")),
+	}, etcd)
+	got, err := m.fetchDashboard()
+	if err != nil {
+		t.Fatalf("fetchDashboard() error = %v", err)
+	}
+	for _, want := range []string{
+		"Etcd etcd-main: ready false, 2 of 3 replicas ready",
+		"Members: 3, leader etcd-main-0",
+		"Quorum: degraded, 2 of 3 members ready, 2 needed",
+		"Backup: True (FullSnapshotTaken)",
+		"AllMembersReady: False (NotAllMembersReady)",
+		"pod etcd-main-2 is not ready",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("fetchDashboard() missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "BackupReady: True") {
+		t.Errorf("fetchDashboard() warns about a healthy condition:\n%s", got)
+	}
+}
+
+func TestFetchDashboardWithoutEtcd(t *testing.T) {
+	m, _ := newTestModel([]runtime.Object{testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))})
+	got, err := m.fetchDashboard()
+	if err != nil {
+		t.Fatalf("fetchDashboard() error = %v", err)
+	}
+	// The pods still answer part of the question when the Etcd resource can't be read
+	if !strings.Contains(got, "Etcd etcd-main: unavailable") || !strings.Contains(got, "etcd-main-0") || !strings.Contains(got, "Warnings:\n  none") {
+		t.Errorf("fetchDashboard() =\n%s\nwant the Etcd reported unavailable next to the pods", got)
+	}
+}
+
+func TestDashboardNavigation(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, keyMsg("H"))
+	if m.state != DashboardState || !isLiveState(m.state) {
+		t.Fatalf("H opened state %v, want the live DashboardState", m.state)
+	}
+	m = update(t, m, dashboardLoadedMsg{"Quorum: healthy"})
+	if m.content != "Quorum: healthy" {
+		t.Errorf("content = %q, want the dashboard", m.content)
+	}
+	m = update(t, m, keyMsg("enter"))
+	if m.state != ListState {
+		t.Errorf("enter opened state %v, want the pod list", m.state)
+	}
+
+	// Landing on the dashboard, enter drills into the pod list too
+	m, _ = newTestModel(nil)
+	m.state = DashboardState
+	if m.Init() == nil {
+		t.Fatal("Init() = nil, want the dashboard loading")
+	}
+	m = update(t, m, keyMsg("enter"))
+	if m.state != ListState {
+		t.Errorf("enter from the landing dashboard opened state %v, want the pod list", m.state)
+	}
+}
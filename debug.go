@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+)
+
+// defaultDebugImage is offered when attaching an ephemeral debug container
+// etcd images are distroless, so a shell has to come from somewhere else
+const defaultDebugImage = "busybox"
+
+// debugStartTimeout bounds the wait for a debug container to start before its logs are shown
+const debugStartTimeout = 30 * time.Second
+
+// debugStartPoll is how often the pod is read while waiting for the debug container to start
+const debugStartPoll = 500 * time.Millisecond
+
+// debugContainerMsg reports the outcome of adding an ephemeral debug container
+type debugContainerMsg struct {
+	namespace string
+	podName   string
+	container string
+	err       error
+}
+
+// debugLogsMsg carries the containers of the pod once its debug container started, to open the debug container's logs
+type debugLogsMsg struct {
+	podName    string
+	container  string
+	containers []Container
+	err        error
+}
+
+// promptDebugContainer asks for an image and then targets the current container with a debug container
+func (m *Model) promptDebugContainer() tea.Cmd {
+	if ok, cmd := m.guardMutation("debug"); !ok {
+		return cmd
+	}
+	namespace, podName, target := m.podNamespace(m.selectedPod), m.selectedPod.Name, m.currentContainer()
+	return m.openPrompt("debug image", defaultDebugImage, func(m *Model, image string) tea.Cmd {
+		if image == "" {
+			return nil
+		}
+		return m.createDebugContainer(namespace, podName, target, image)
+	})
+}
+
+// createDebugContainer adds an ephemeral container to the pod through the ephemeralcontainers subresource
+// This mirrors `kubectl debug -it <pod> --image=<image> --target=<container>`
+func (m *Model) createDebugContainer(namespace, podName, target, image string) tea.Cmd {
+	m.beginOperation(debugOperation(podName))
+	return func() tea.Msg {
+		ctx, cancel := m.requestContext()
+		defer cancel()
+		pod, err := m.kubeClient.CoreV1().Pods(namespace).Get(
+			ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return debugContainerMsg{podName: podName, err: fmt.Errorf("failed to get pod %s: %w", podName, err)}
+		}
+
+		before := pod.DeepCopy()
+		name := "debugger-" + utilrand.String(5)
+		pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, corev1.EphemeralContainer{
+			EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+				Name:                     name,
+				Image:                    image,
+				ImagePullPolicy:          corev1.PullIfNotPresent,
+				TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+				// Keep a shell open so the container stays around to be attached to
+				Stdin: true,
+				TTY:   true,
+			},
+			// Sharing the target's process namespace makes its filesystem reachable via /proc/1/root
+			TargetContainerName: target,
+		})
+
+		after, err := m.kubeClient.CoreV1().Pods(namespace).UpdateEphemeralContainers(
+			ctx, podName, pod, m.updateOptions())
+		if err != nil {
+			return debugContainerMsg{podName: podName, err: explainEphemeralError(err)}
+		}
+		if m.dryRun {
+			return dryRunResult(debugOperation(podName), fmt.Sprintf("add debug container %s to pod %s", name, podName), before, after)
+		}
+		return debugContainerMsg{namespace: namespace, podName: podName, container: name}
+	}
+}
+
+// debugAttachHint tells how to attach to a debug container, which needs a terminal the viewer doesn't offer
+func debugAttachHint(msg debugContainerMsg) string {
+	return fmt.Sprintf("added %s; attach with: kubectl attach -it -n %s %s -c %s",
+		msg.container, msg.namespace, msg.podName, msg.container)
+}
+
+// promptDebugLogs offers to show the logs of the debug container just added, which is what it prints to its terminal
+func (m *Model) promptDebugLogs(msg debugContainerMsg) tea.Cmd {
+	label := fmt.Sprintf("added %s, show its logs? (y/N)", msg.container)
+	return m.openPrompt(label, "", func(m *Model, answer string) tea.Cmd {
+		if answer := strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+			return m.setStatus(debugAttachHint(msg))
+		}
+		return tea.Batch(m.setStatus(fmt.Sprintf("waiting for %s to start", msg.container)), m.openDebugLogs(msg))
+	})
+}
+
+// openDebugLogs is a command that waits for the debug container to start and lists the containers of the pod again
+// A container that hasn't started has no logs to read yet
+func (m *Model) openDebugLogs(msg debugContainerMsg) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), debugStartTimeout)
+		defer cancel()
+		for {
+			pod, err := m.kubeClient.CoreV1().Pods(msg.namespace).Get(ctx, msg.podName, metav1.GetOptions{})
+			if err != nil {
+				return debugLogsMsg{err: fmt.Errorf("failed to get pod %s: %w", msg.podName, err)}
+			}
+			if debugContainerStarted(pod, msg.container) {
+				break
+			}
+			select {
+			case <-ctx.Done():
+				return debugLogsMsg{err: fmt.Errorf("%s didn't start within %s; %s", msg.container, debugStartTimeout, debugAttachHint(msg))}
+			case <-time.After(debugStartPoll):
+			}
+		}
+		containers, err := m.fetchPodContainers(msg.namespace, msg.podName)
+		if err != nil {
+			return debugLogsMsg{err: err}
+		}
+		return debugLogsMsg{podName: msg.podName, container: msg.container, containers: containers}
+	}
+}
+
+// debugContainerStarted reports whether an ephemeral container is running, or already ran
+func debugContainerStarted(pod *corev1.Pod, name string) bool {
+	for _, status := range pod.Status.EphemeralContainerStatuses {
+		if status.Name == name {
+			return status.State.Running != nil || status.State.Terminated != nil
+		}
+	}
+	return false
+}
+
+// showDebugLogs selects the debug container and loads its logs, where esc returns to the screen the debug started from
+func (m *Model) showDebugLogs(msg debugLogsMsg) tea.Cmd {
+	if msg.err != nil {
+		return m.setStatus(msg.err.Error())
+	}
+	// The user moved on to another pod while the container started
+	if m.selectedPod.Name != msg.podName {
+		return nil
+	}
+	m.setContainers(msg.containers)
+	m.containerList.ResetFilter()
+	if i := slices.IndexFunc(msg.containers, func(c Container) bool { return c.Name == msg.container }); i >= 0 {
+		m.containerList.Select(i)
+	}
+	m.setMergedLogs(false)
+	return m.loadLogs(msg.container)
+}
+
+// explainEphemeralError turns API rejections into an actionable message
+// Clusters with the EphemeralContainers feature disabled don't serve the subresource at all
+func explainEphemeralError(err error) error {
+	switch {
+	case apierrors.IsNotFound(err), apierrors.IsMethodNotSupported(err):
+		return fmt.Errorf("ephemeral containers are not enabled on this cluster: %w", err)
+	case apierrors.IsForbidden(err):
+		return fmt.Errorf("not permitted to update pods/ephemeralcontainers: %w", err)
+	}
+	return fmt.Errorf("failed to add debug container: %w", err)
+}
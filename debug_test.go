@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestCreateDebugContainer(t *testing.T) {
+	tests := []struct {
+		name      string
+		updateErr error
+		wantErr   string
+	}{
+		{name: "added"},
+		{
+			name:      "feature disabled",
+			updateErr: apierrors.NewNotFound(corev1.Resource("pods/ephemeralcontainers"), "etcd-main-0"),
+			wantErr:   "ephemeral containers are not enabled",
+		},
+		{
+			name:      "forbidden",
+			updateErr: apierrors.NewForbidden(corev1.Resource("pods/ephemeralcontainers"), "etcd-main-0", nil),
+			wantErr:   "not permitted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, client := newTestModel([]runtime.Object{
+				testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd")),
+			})
+			if tt.updateErr != nil {
+				client.PrependReactor("update", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+					return action.GetSubresource() == "ephemeralcontainers", nil, tt.updateErr
+				})
+			}
+
+			msg := m.createDebugContainer(testNamespace, "etcd-main-0", "etcd", "busybox")().(debugContainerMsg)
+			if tt.wantErr != "" {
+				if msg.err == nil || !strings.Contains(msg.err.Error(), tt.wantErr) {
+					t.Fatalf("createDebugContainer() error = %v, want %q", msg.err, tt.wantErr)
+				}
+				return
+			}
+			if msg.err != nil {
+				t.Fatalf("createDebugContainer() error = %v", msg.err)
+			}
+
+			pod, err := client.CoreV1().Pods(testNamespace).Get(context.Background(), "etcd-main-0", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get pod: %v", err)
+			}
+			if len(pod.Spec.EphemeralContainers) != 1 {
+				t.Fatalf("pod has %d ephemeral containers, want 1", len(pod.Spec.EphemeralContainers))
+			}
+			ec := pod.Spec.EphemeralContainers[0]
+			if ec.Name != msg.container || ec.Image != "busybox" || ec.TargetContainerName != "etcd" {
+				t.Errorf("ephemeral container = %s/%s targeting %s, want %s/busybox targeting etcd",
+					ec.Name, ec.Image, ec.TargetContainerName, msg.container)
+			}
+
+			containers, err := m.fetchPodContainers(testNamespace, "etcd-main-0")
+			if err != nil {
+				t.Fatalf("fetchPodContainers() error = %v", err)
+			}
+			if last := containers[len(containers)-1]; !last.Ephemeral || last.Name != msg.container {
+				t.Errorf("fetchPodContainers() last = %+v, want the debug container", last)
+			}
+		})
+	}
+}
+
+func TestDebugContainerLogsPrompt(t *testing.T) {
+	pod := testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))
+	pod.Spec.EphemeralContainers = []corev1.EphemeralContainer{{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debugger-abcde", Image: "busybox"}}}
+	pod.Status.EphemeralContainerStatuses = []corev1.ContainerStatus{runningContainer("debugger-abcde")}
+	m, _ := newTestModel([]runtime.Object{pod})
+	m = update(t, m, tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("l"))
+	m = update(t, m, containersLoadedMsg{[]Container{{Name: "etcd"}, {Name: "backup-restore"}}})
+
+	added := debugContainerMsg{namespace: testNamespace, podName: "etcd-main-0", container: "debugger-abcde"}
+	m = update(t, m, added)
+	if m.prompt == nil || !strings.Contains(m.prompt.input.Prompt, "show its logs? (y/N)") {
+		t.Fatalf("adding a debug container didn't offer its logs, prompt %+v", m.prompt)
+	}
+
+	// Declining keeps the screen and tells how to attach instead
+	m.prompt.input.SetValue("n")
+	m = update(t, m, keyMsg("enter"))
+	if m.state != ContainerSelectState || !strings.Contains(m.status, "kubectl attach -it -n shoot--foo etcd-main-0 -c debugger-abcde") {
+		t.Errorf("declining: state %v, status %q", m.state, m.status)
+	}
+
+	msg := m.openDebugLogs(added)()
+	logs, ok := msg.(debugLogsMsg)
+	if !ok || logs.err != nil {
+		t.Fatalf("openDebugLogs() = %+v, want the containers", msg)
+	}
+	next, cmd := m.Update(logs)
+	m = next.(Model)
+	if cmd == nil || m.currentContainer() != "debugger-abcde" {
+		t.Fatalf("debug logs: selected %q, loading %v", m.currentContainer(), cmd != nil)
+	}
+	m = update(t, m, cmd())
+	if m.state != LogState {
+		t.Errorf("state = %v, want the logs of the debug container", m.state)
+	}
+	m = update(t, m, keyMsg("esc"))
+	if m.state != ContainerSelectState {
+		t.Errorf("esc from the debug logs went to %v, want the container list", m.state)
+	}
+}
+
+func TestDebugContainerNotStarted(t *testing.T) {
+	if !debugContainerStarted(&corev1.Pod{Status: corev1.PodStatus{EphemeralContainerStatuses: []corev1.ContainerStatus{runningContainer("debugger-abcde")}}}, "debugger-abcde") {
+		t.Error("a running debug container isn't started")
+	}
+	waiting := corev1.ContainerStatus{Name: "debugger-abcde", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}}
+	if debugContainerStarted(&corev1.Pod{Status: corev1.PodStatus{EphemeralContainerStatuses: []corev1.ContainerStatus{waiting}}}, "debugger-abcde") {
+		t.Error("a waiting debug container is started")
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openDebugLog opens the file given to --debug, appending so earlier sessions stay for comparison
+// Records are JSON lines, which keeps them easy to grep and to attach to a bug report
+func openDebugLog(path string) (*slog.Logger, io.Closer, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open debug log: %w", err)
+	}
+	return slog.New(slog.NewJSONHandler(file, &slog.HandlerOptions{Level: slog.LevelDebug})), file, nil
+}
+
+// debugModel wraps the Model to log every message it receives and every command it dispatches
+// The Model itself knows nothing about it, so running without --debug costs nothing
+type debugModel struct {
+	model tea.Model
+	log   *slog.Logger
+}
+
+func (d debugModel) Init() tea.Cmd {
+	d.log.Debug("init")
+	return d.traceCmd("init", d.model.Init())
+}
+
+func (d debugModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	msgType := fmt.Sprintf("%T", msg)
+	d.log.Debug("message received", append([]any{"type", msgType}, debugMsgAttrs(msg)...)...)
+	next, cmd := d.model.Update(msg)
+	d.model = next
+	return d, d.traceCmd(msgType, cmd)
+}
+
+func (d debugModel) View() string {
+	return d.model.View()
+}
+
+// debugMsgAttrs picks the fields of a message worth logging; contents such as logs or YAML are left out
+func debugMsgAttrs(msg tea.Msg) []any {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return []any{"key", msg.String()}
+	case tea.WindowSizeMsg:
+		return []any{"width", msg.Width, "height", msg.Height}
+	case errMsg:
+		return []any{"error", msg.err.Error()}
+	}
+	return nil
+}
+
+// traceCmd logs that cmd was dispatched in response to a message of type cause, and how long it ran
+// The commands of a batch are traced one by one, since they run concurrently
+func (d debugModel) traceCmd(cause string, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	d.log.Debug("command dispatched", "cause", cause)
+	return func() tea.Msg {
+		start := time.Now()
+		msg := cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for i, cmd := range batch {
+				batch[i] = d.traceCmd(cause, cmd)
+			}
+			return batch
+		}
+		d.log.Debug("command finished", "cause", cause, "result", fmt.Sprintf("%T", msg), "latency", time.Since(start))
+		return msg
+	}
+}
+
+// debugTransport logs every API request with its outcome and latency
+type debugTransport struct {
+	next http.RoundTripper
+	log  *slog.Logger
+}
+
+func (t debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	attrs := []any{"method", req.Method, "url", req.URL.String(), "latency", time.Since(start)}
+	if err != nil {
+		t.log.Debug("api call failed", append(attrs, "error", err.Error())...)
+		return resp, err
+	}
+	t.log.Debug("api call", append(attrs, "status", resp.StatusCode)...)
+	return resp, nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestDebugModel(t *testing.T) {
+	m, _ := newTestModel([]runtime.Object{testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))})
+	var out bytes.Buffer
+	var model tea.Model = debugModel{model: m, log: slog.New(slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug}))}
+
+	model, cmd := model.Update(keyMsg("r"))
+	if cmd == nil {
+		t.Fatal("r dispatched no command")
+	}
+	// The refresh is a batch, each of its commands is traced
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatalf("r dispatched %T, want a batch", cmd())
+	}
+	for _, cmd := range batch {
+		model, _ = model.Update(cmd())
+	}
+	if pods := model.(debugModel).model.(Model).pods; len(pods) != 1 {
+		t.Errorf("pods = %v, the wrapped model didn't get the loaded pods", pods)
+	}
+	for _, want := range []string{
+		`"msg":"message received","type":"tea.KeyMsg","key":"r"`,
+		`"msg":"command dispatched","cause":"tea.KeyMsg"`,
+		`"msg":"command finished","cause":"tea.KeyMsg","result":"main.podsLoadedMsg","latency":`,
+		`"msg":"message received","type":"main.podsLoadedMsg"`,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("debug log missing %s:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestDebugTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "debug.log")
+	logger, closer, err := openDebugLog(path)
+	if err != nil {
+		t.Fatalf("openDebugLog() error = %v", err)
+	}
+	client := &http.Client{Transport: debugTransport{next: http.DefaultTransport, log: logger}}
+	resp, err := client.Get(server.URL + "/api/v1/namespaces/" + testNamespace + "/pods")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	closer.Close()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the debug log: %v", err)
+	}
+	for _, want := range []string{`"msg":"api call","method":"GET"`, "/api/v1/namespaces/" + testNamespace + "/pods", `"status":404`} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("debug log missing %s:\n%s", want, content)
+		}
+	}
+}
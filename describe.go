@@ -0,0 +1,321 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// nodeSummaryConditions are the node conditions that usually explain evictions and scheduling failures
+var nodeSummaryConditions = []corev1.NodeConditionType{
+	corev1.NodeReady,
+	corev1.NodeMemoryPressure,
+	corev1.NodeDiskPressure,
+	corev1.NodePIDPressure,
+}
+
+// nodeSummaryResources are the resources compared between capacity and allocatable
+var nodeSummaryResources = []corev1.ResourceName{
+	corev1.ResourceCPU,
+	corev1.ResourceMemory,
+	corev1.ResourceEphemeralStorage,
+	corev1.ResourcePods,
+}
+
+// writeNodeSummary appends the conditions and allocatable resources of the pod's node
+// Errors reading the node are reported inline so the rest of the describe still renders
+func (m *Model) writeNodeSummary(desc *strings.Builder, nodeName string) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	desc.WriteString(fmt.Sprintf("\nNode %s:\n", nodeName))
+
+	node, err := m.kubeClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsForbidden(err) {
+			desc.WriteString("  (not permitted to read nodes)\n")
+		} else {
+			desc.WriteString(fmt.Sprintf("  (unavailable: %v)\n", err))
+		}
+		return
+	}
+
+	schedulable := "yes"
+	if node.Spec.Unschedulable {
+		schedulable = "no (cordoned)"
+	}
+	desc.WriteString(fmt.Sprintf("  Schedulable: %s\n", schedulable))
+
+	desc.WriteString("  Conditions:\n")
+	for _, conditionType := range nodeSummaryConditions {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type != conditionType {
+				continue
+			}
+			line := fmt.Sprintf("    %s: %s", condition.Type, condition.Status)
+			if condition.Reason != "" {
+				line += fmt.Sprintf(" (%s)", condition.Reason)
+			}
+			desc.WriteString(line + "\n")
+		}
+	}
+
+	desc.WriteString("  Allocatable / Capacity:\n")
+	for _, resource := range nodeSummaryResources {
+		capacity, ok := node.Status.Capacity[resource]
+		if !ok {
+			continue
+		}
+		allocatable := node.Status.Allocatable[resource]
+		desc.WriteString(fmt.Sprintf("    %s: %s / %s\n", resource, allocatable.String(), capacity.String()))
+	}
+}
+
+// containerStateSummary renders a container status in one line, e.g. "Terminated (Completed, exit code 0)"
+func containerStateSummary(status corev1.ContainerStatus) string {
+	state := status.State
+	switch {
+	case state.Terminated != nil:
+		return fmt.Sprintf("Terminated (%s, exit code %d)", state.Terminated.Reason, state.Terminated.ExitCode)
+	case state.Running != nil:
+		return fmt.Sprintf("Running (ready: %t)", status.Ready)
+	case state.Waiting != nil:
+		if state.Waiting.Message != "" {
+			return fmt.Sprintf("Waiting (%s: %s)", state.Waiting.Reason, state.Waiting.Message)
+		}
+		return fmt.Sprintf("Waiting (%s)", state.Waiting.Reason)
+	}
+	return "Unknown"
+}
+
+// writeImagePull appends the service account and pull secrets used to fetch the pod's images
+// Only names are shown; the secrets themselves are never read
+func writeImagePull(desc *strings.Builder, spec corev1.PodSpec) {
+	serviceAccount := spec.ServiceAccountName
+	if serviceAccount == "" {
+		serviceAccount = "<none>"
+	}
+	secrets := make([]string, len(spec.ImagePullSecrets))
+	for i, secret := range spec.ImagePullSecrets {
+		secrets[i] = secret.Name
+	}
+	pullSecrets := "<none>"
+	if len(secrets) > 0 {
+		pullSecrets = strings.Join(secrets, ", ")
+	}
+
+	desc.WriteString("\nImage Pull:\n")
+	desc.WriteString(fmt.Sprintf("  Service Account: %s\n", serviceAccount))
+	desc.WriteString(fmt.Sprintf("  Pull Secrets: %s\n", pullSecrets))
+}
+
+// writeScheduling appends the tolerations and affinity rules that decide where the pod may run
+// Anti-affinity usually spreads the members over zones, so its topology keys are shown first on each rule
+func writeScheduling(desc *strings.Builder, spec corev1.PodSpec) {
+	desc.WriteString("\nScheduling:\n")
+
+	desc.WriteString("  Tolerations:")
+	if len(spec.Tolerations) == 0 {
+		desc.WriteString(" <none>")
+	}
+	desc.WriteString("\n")
+	for _, toleration := range spec.Tolerations {
+		desc.WriteString(fmt.Sprintf("    %s\n", formatToleration(toleration)))
+	}
+
+	affinity := spec.Affinity
+	if affinity == nil {
+		affinity = &corev1.Affinity{}
+	}
+
+	desc.WriteString("  Node Affinity:")
+	nodeAffinity := affinity.NodeAffinity
+	if nodeAffinity == nil || (nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil &&
+		len(nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution) == 0) {
+		desc.WriteString(" <none>\n")
+	} else {
+		desc.WriteString("\n")
+		if required := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; required != nil {
+			// Terms are ORed, the requirements within a term ANDed
+			for _, term := range required.NodeSelectorTerms {
+				desc.WriteString(fmt.Sprintf("    required: %s\n", formatNodeSelectorTerm(term)))
+			}
+		}
+		for _, preferred := range nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+			desc.WriteString(fmt.Sprintf("    preferred (weight %d): %s\n", preferred.Weight, formatNodeSelectorTerm(preferred.Preference)))
+		}
+	}
+
+	var podAffinity, podAntiAffinity podAffinityRules
+	if affinity.PodAffinity != nil {
+		podAffinity = podAffinityRules{affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+			affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution}
+	}
+	if affinity.PodAntiAffinity != nil {
+		podAntiAffinity = podAffinityRules{affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+			affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution}
+	}
+	podAffinity.write(desc, "Pod Affinity")
+	podAntiAffinity.write(desc, "Pod Anti-Affinity")
+}
+
+// writeSchedulingGates appends the gates that keep a Pending pod from being scheduled until removed
+// Most pods have none, so the section is left out then
+func writeSchedulingGates(desc *strings.Builder, spec corev1.PodSpec) {
+	if len(spec.SchedulingGates) == 0 {
+		return
+	}
+	desc.WriteString("\nScheduling Gates:\n")
+	for _, gate := range spec.SchedulingGates {
+		desc.WriteString(fmt.Sprintf("  %s\n", gate.Name))
+	}
+}
+
+// writeReadinessGates appends the extra conditions the pod needs to be Ready, with their status
+// A condition nobody reported yet is shown as <none> like kubectl does, which is what keeps such a pod unready
+func writeReadinessGates(desc *strings.Builder, pod *corev1.Pod) {
+	if len(pod.Spec.ReadinessGates) == 0 {
+		return
+	}
+	desc.WriteString("\nReadiness Gates:\n")
+	for _, gate := range pod.Spec.ReadinessGates {
+		status := "<none>"
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == gate.ConditionType {
+				status = string(condition.Status)
+			}
+		}
+		desc.WriteString(fmt.Sprintf("  %s: %s\n", gate.ConditionType, status))
+	}
+}
+
+// podAffinityRules holds the terms of either pod affinity or pod anti-affinity, which share their shape
+type podAffinityRules struct {
+	required  []corev1.PodAffinityTerm
+	preferred []corev1.WeightedPodAffinityTerm
+}
+
+// write appends the rules under the given heading
+func (r podAffinityRules) write(desc *strings.Builder, heading string) {
+	desc.WriteString(fmt.Sprintf("  %s:", heading))
+	if len(r.required) == 0 && len(r.preferred) == 0 {
+		desc.WriteString(" <none>\n")
+		return
+	}
+	desc.WriteString("\n")
+	for _, term := range r.required {
+		desc.WriteString(fmt.Sprintf("    required: %s\n", formatPodAffinityTerm(term)))
+	}
+	for _, weighted := range r.preferred {
+		desc.WriteString(fmt.Sprintf("    preferred (weight %d): %s\n", weighted.Weight, formatPodAffinityTerm(weighted.PodAffinityTerm)))
+	}
+}
+
+// formatToleration renders a toleration the way kubectl describe does,
+// e.g. "node.kubernetes.io/not-ready:NoExecute op=Exists for 300s"
+func formatToleration(toleration corev1.Toleration) string {
+	out := toleration.Key
+	if toleration.Value != "" {
+		out += "=" + toleration.Value
+	}
+	if toleration.Effect != "" {
+		out += ":" + string(toleration.Effect)
+	}
+	if toleration.Operator == corev1.TolerationOpExists {
+		out += " op=Exists"
+	}
+	if toleration.TolerationSeconds != nil {
+		out += fmt.Sprintf(" for %ds", *toleration.TolerationSeconds)
+	}
+	return out
+}
+
+// formatNodeSelectorTerm renders the requirements of a node selector term joined by " && ",
+// e.g. "topology.kubernetes.io/zone In [eu-1a eu-1b]"
+func formatNodeSelectorTerm(term corev1.NodeSelectorTerm) string {
+	var requirements []string
+	for _, expr := range term.MatchExpressions {
+		requirements = append(requirements, formatNodeSelectorRequirement(expr))
+	}
+	for _, field := range term.MatchFields {
+		requirements = append(requirements, formatNodeSelectorRequirement(field))
+	}
+	if len(requirements) == 0 {
+		return "<any node>"
+	}
+	return strings.Join(requirements, " && ")
+}
+
+func formatNodeSelectorRequirement(req corev1.NodeSelectorRequirement) string {
+	if len(req.Values) == 0 {
+		return fmt.Sprintf("%s %s", req.Key, req.Operator)
+	}
+	return fmt.Sprintf("%s %s %v", req.Key, req.Operator, req.Values)
+}
+
+// formatPodAffinityTerm renders a pod affinity term with its topology key first,
+// e.g. "topologyKey=topology.kubernetes.io/zone pods=app.kubernetes.io/name=etcd-main"
+func formatPodAffinityTerm(term corev1.PodAffinityTerm) string {
+	out := fmt.Sprintf("topologyKey=%s pods=%s", term.TopologyKey, metav1.FormatLabelSelector(term.LabelSelector))
+	if len(term.Namespaces) > 0 {
+		out += " namespaces=" + strings.Join(term.Namespaces, ",")
+	}
+	return out
+}
+
+// writeProbes appends a container's probe definitions and whether it currently passes readiness, each line indented by indent
+func writeProbes(desc *strings.Builder, indent string, container corev1.Container, statuses []corev1.ContainerStatus) {
+	ready := "unknown"
+	for _, status := range statuses {
+		if status.Name == container.Name {
+			ready = strconv.FormatBool(status.Ready)
+		}
+	}
+	desc.WriteString(fmt.Sprintf("%sReady: %s\n", indent, ready))
+
+	probes := []struct {
+		name  string
+		probe *corev1.Probe
+	}{
+		{"Liveness", container.LivenessProbe},
+		{"Readiness", container.ReadinessProbe},
+		{"Startup", container.StartupProbe},
+	}
+	for _, p := range probes {
+		if p.probe != nil {
+			desc.WriteString(fmt.Sprintf("%s%s: %s\n", indent, p.name, formatProbe(p.probe)))
+		}
+	}
+}
+
+// formatProbe renders a probe the way kubectl describe does,
+// e.g. "http-get http://:8080/healthz delay=15s timeout=5s period=10s #success=1 #failure=3"
+func formatProbe(probe *corev1.Probe) string {
+	var action string
+	switch handler := probe.ProbeHandler; {
+	case handler.Exec != nil:
+		action = fmt.Sprintf("exec %v", handler.Exec.Command)
+	case handler.HTTPGet != nil:
+		scheme := strings.ToLower(string(handler.HTTPGet.Scheme))
+		if scheme == "" {
+			scheme = "http"
+		}
+		action = fmt.Sprintf("http-get %s://%s:%s%s", scheme, handler.HTTPGet.Host, handler.HTTPGet.Port.String(), handler.HTTPGet.Path)
+	case handler.TCPSocket != nil:
+		action = fmt.Sprintf("tcp-socket %s:%s", handler.TCPSocket.Host, handler.TCPSocket.Port.String())
+	case handler.GRPC != nil:
+		action = fmt.Sprintf("grpc <pod>:%d", handler.GRPC.Port)
+		if handler.GRPC.Service != nil && *handler.GRPC.Service != "" {
+			action += " " + *handler.GRPC.Service
+		}
+	default:
+		action = "unknown"
+	}
+
+	return fmt.Sprintf("%s delay=%ds timeout=%ds period=%ds #success=%d #failure=%d",
+		action, probe.InitialDelaySeconds, probe.TimeoutSeconds, probe.PeriodSeconds,
+		probe.SuccessThreshold, probe.FailureThreshold)
+}
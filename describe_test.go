@@ -0,0 +1,269 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestDescribePodNodeSummary(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-a"},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue, Reason: "KubeletHasInsufficientMemory"},
+				{Type: corev1.NodeDiskPressure, Status: corev1.ConditionFalse},
+			},
+			Capacity: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("7Gi"),
+			},
+		},
+	}
+	pod := testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))
+
+	tests := []struct {
+		name         string
+		objects      []runtime.Object
+		forbidden    bool
+		wantContains []string
+	}{
+		{
+			name:    "node readable",
+			objects: []runtime.Object{pod, node},
+			wantContains: []string{
+				"Node node-a:",
+				"Ready: True",
+				"MemoryPressure: True (KubeletHasInsufficientMemory)",
+				"DiskPressure: False",
+				"memory: 7Gi / 8Gi",
+			},
+		},
+		{
+			name:         "node missing",
+			objects:      []runtime.Object{pod},
+			wantContains: []string{"Node node-a:", "(unavailable:"},
+		},
+		{
+			name:         "nodes forbidden",
+			objects:      []runtime.Object{pod, node},
+			forbidden:    true,
+			wantContains: []string{"Name: etcd-main-0", "(not permitted to read nodes)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, client := newTestModel(tt.objects)
+			if tt.forbidden {
+				client.PrependReactor("get", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+					return true, nil, apierrors.NewForbidden(corev1.Resource("nodes"), "node-a", nil)
+				})
+			}
+
+			desc, err := m.describePod(testNamespace, "etcd-main-0")
+			if err != nil {
+				t.Fatalf("describePod() error = %v", err)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(desc, want) {
+					t.Errorf("describePod() output missing %q:\n%s", want, desc)
+				}
+			}
+		})
+	}
+}
+
+func TestDescribePodUnscheduled(t *testing.T) {
+	pod := testPod("etcd-main-0", corev1.PodPending)
+	pod.Spec.NodeName = ""
+	m, client := newTestModel([]runtime.Object{pod})
+
+	desc, err := m.describePod(testNamespace, "etcd-main-0")
+	if err != nil {
+		t.Fatalf("describePod() error = %v", err)
+	}
+	if strings.Contains(desc, "\nNode ") {
+		t.Errorf("describePod() rendered a node section for an unscheduled pod:\n%s", desc)
+	}
+	for _, action := range client.Actions() {
+		if action.GetResource().Resource == "nodes" {
+			t.Errorf("describePod() read nodes for an unscheduled pod")
+		}
+	}
+}
+
+func TestDescribePodProbes(t *testing.T) {
+	pod := testPod("etcd-main-0", corev1.PodRunning, corev1.ContainerStatus{Name: "etcd", Ready: false})
+	pod.Spec.NodeName = ""
+	pod.Spec.Containers = []corev1.Container{
+		{
+			Name:  "etcd",
+			Image: "etcd-wrapper:v0.4.0",
+			ReadinessProbe: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{
+					Path: "/readyz", Port: intstr.FromInt32(9095), Scheme: corev1.URISchemeHTTPS,
+				}},
+				InitialDelaySeconds: 15, TimeoutSeconds: 10, PeriodSeconds: 5, SuccessThreshold: 1, FailureThreshold: 5,
+			},
+			LivenessProbe: &corev1.Probe{
+				ProbeHandler:   corev1.ProbeHandler{Exec: &corev1.ExecAction{Command: []string{"/bin/check"}}},
+				TimeoutSeconds: 1, PeriodSeconds: 10, SuccessThreshold: 1, FailureThreshold: 3,
+			},
+		},
+		{Name: "backup-restore", Image: "etcdbrctl:v0.30.0"},
+	}
+	m, _ := newTestModel([]runtime.Object{pod})
+
+	desc, err := m.describePod(testNamespace, "etcd-main-0")
+	if err != nil {
+		t.Fatalf("describePod() error = %v", err)
+	}
+	for _, want := range []string{
+		"  etcd: etcd-wrapper:v0.4.0\n    Ready: false\n",
+		"    Liveness: exec [/bin/check] delay=0s timeout=1s period=10s #success=1 #failure=3\n",
+		"    Readiness: http-get https://:9095/readyz delay=15s timeout=10s period=5s #success=1 #failure=5\n",
+		// No status reported yet for the sidecar
+		"  backup-restore: etcdbrctl:v0.30.0\n    Ready: unknown\n",
+	} {
+		if !strings.Contains(desc, want) {
+			t.Errorf("describePod() missing %q:\n%s", want, desc)
+		}
+	}
+	if strings.Contains(desc, "Startup:") {
+		t.Errorf("describePod() shows a startup probe that isn't defined:\n%s", desc)
+	}
+}
+
+func TestDescribePodImagePull(t *testing.T) {
+	withSecrets := testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))
+	withSecrets.Spec.NodeName = ""
+	withSecrets.Spec.ServiceAccountName = "etcd-main"
+	withSecrets.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "registry"}, {Name: "mirror"}}
+	bare := testPod("etcd-main-1", corev1.PodRunning, runningContainer("etcd"))
+	bare.Spec.NodeName = ""
+	m, _ := newTestModel([]runtime.Object{withSecrets, bare})
+
+	tests := []struct {
+		pod  string
+		want string
+	}{
+		{"etcd-main-0", "\nImage Pull:\n  Service Account: etcd-main\n  Pull Secrets: registry, mirror\n"},
+		{"etcd-main-1", "\nImage Pull:\n  Service Account: <none>\n  Pull Secrets: <none>\n"},
+	}
+	for _, tt := range tests {
+		desc, err := m.describePod(testNamespace, tt.pod)
+		if err != nil {
+			t.Fatalf("describePod(%s) error = %v", tt.pod, err)
+		}
+		if !strings.Contains(desc, tt.want) {
+			t.Errorf("describePod(%s) missing %q:\n%s", tt.pod, tt.want, desc)
+		}
+	}
+}
+
+func TestDescribePodScheduling(t *testing.T) {
+	notReadySeconds := int64(300)
+	constrained := testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))
+	constrained.Spec.NodeName = ""
+	constrained.Spec.Tolerations = []corev1.Toleration{
+		{Key: "node.kubernetes.io/not-ready", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute, TolerationSeconds: &notReadySeconds},
+		{Key: "dedicated", Value: "etcd", Effect: corev1.TaintEffectNoSchedule},
+	}
+	constrained.Spec.Affinity = &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+				MatchExpressions: []corev1.NodeSelectorRequirement{
+					{Key: "topology.kubernetes.io/zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"eu-1a", "eu-1b"}},
+					{Key: "spot", Operator: corev1.NodeSelectorOpDoesNotExist},
+				},
+			}}},
+		},
+		// Pod affinity is left without rules to check that an empty block renders as none
+		PodAffinity: &corev1.PodAffinity{},
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+				Weight: 100,
+				PodAffinityTerm: corev1.PodAffinityTerm{
+					TopologyKey:   "topology.kubernetes.io/zone",
+					LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/name": testEtcdName}},
+				},
+			}},
+		},
+	}
+	bare := testPod("etcd-main-1", corev1.PodRunning, runningContainer("etcd"))
+	bare.Spec.NodeName = ""
+	m, _ := newTestModel([]runtime.Object{constrained, bare})
+
+	tests := []struct {
+		pod  string
+		want string
+	}{
+		{"etcd-main-0", "\nScheduling:\n" +
+			"  Tolerations:\n" +
+			"    node.kubernetes.io/not-ready:NoExecute op=Exists for 300s\n" +
+			"    dedicated=etcd:NoSchedule\n" +
+			"  Node Affinity:\n" +
+			"    required: topology.kubernetes.io/zone In [eu-1a eu-1b] && spot DoesNotExist\n" +
+			"  Pod Affinity: <none>\n" +
+			"  Pod Anti-Affinity:\n" +
+			"    preferred (weight 100): topologyKey=topology.kubernetes.io/zone pods=app.kubernetes.io/name=" + testEtcdName + "\n"},
+		{"etcd-main-1", "\nScheduling:\n" +
+			"  Tolerations: <none>\n" +
+			"  Node Affinity: <none>\n" +
+			"  Pod Affinity: <none>\n" +
+			"  Pod Anti-Affinity: <none>\n"},
+	}
+	for _, tt := range tests {
+		desc, err := m.describePod(testNamespace, tt.pod)
+		if err != nil {
+			t.Fatalf("describePod(%s) error = %v", tt.pod, err)
+		}
+		if !strings.Contains(desc, tt.want) {
+			t.Errorf("describePod(%s) missing %q:\n%s", tt.pod, tt.want, desc)
+		}
+	}
+}
+
+func TestDescribePodGates(t *testing.T) {
+	gated := testPod("etcd-main-0", corev1.PodPending)
+	gated.Spec.NodeName = ""
+	gated.Spec.SchedulingGates = []corev1.PodSchedulingGate{{Name: "example.com/quota"}}
+	gated.Spec.ReadinessGates = []corev1.PodReadinessGate{{ConditionType: "example.com/lb-ready"}, {ConditionType: "example.com/synced"}}
+	gated.Status.Conditions = []corev1.PodCondition{{Type: "example.com/synced", Status: corev1.ConditionFalse}}
+	bare := testPod("etcd-main-1", corev1.PodRunning, runningContainer("etcd"))
+	bare.Spec.NodeName = ""
+	m, _ := newTestModel([]runtime.Object{gated, bare})
+
+	desc, err := m.describePod(testNamespace, "etcd-main-0")
+	if err != nil {
+		t.Fatalf("describePod() error = %v", err)
+	}
+	for _, want := range []string{
+		"\nScheduling Gates:\n  example.com/quota\n",
+		"\nReadiness Gates:\n  example.com/lb-ready: <none>\n  example.com/synced: False\n",
+	} {
+		if !strings.Contains(desc, want) {
+			t.Errorf("describePod() missing %q:\n%s", want, desc)
+		}
+	}
+
+	// Pods without gates leave both sections out
+	desc, err = m.describePod(testNamespace, "etcd-main-1")
+	if err != nil {
+		t.Fatalf("describePod() error = %v", err)
+	}
+	if strings.Contains(desc, "Gates:") {
+		t.Errorf("describePod() rendered gates for a pod without any:\n%s", desc)
+	}
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+// checkOutcome is how a startup check ended
+type checkOutcome int
+
+const (
+	checkPassed checkOutcome = iota
+	checkFailed
+	checkSkipped // an earlier check failed, or the check doesn't apply to how the viewer was started
+)
+
+// diagnosticCheck is one line of the startup checklist
+// detail says what was found, or for a failure what to do about it
+type diagnosticCheck struct {
+	name    string
+	outcome checkOutcome
+	detail  string
+	err     error
+}
+
+// failedCheck records a failed check with the check's own hint
+// Credentials, the network and RBAC fail any check alike, so the guidance of those categories follows the hint;
+// what a missing resource means depends on the check, which its hint already says
+func failedCheck(name, hint string, err error) diagnosticCheck {
+	detail := hint
+	if category := classifyError(err).category; category != errorNotFound {
+		detail += " " + category.guidance()
+	}
+	return diagnosticCheck{name: name, outcome: checkFailed, detail: detail, err: err}
+}
+
+// serverVersion asks the API server for its version, giving up once ctx is done
+// The discovery client takes no context, so the request is left to finish in the background instead
+func (m *Model) serverVersion(ctx context.Context) (*version.Info, error) {
+	type result struct {
+		info *version.Info
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		info, err := m.kubeClient.Discovery().ServerVersion()
+		done <- result{info, err}
+	}()
+	select {
+	case r := <-done:
+		return r.info, r.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("failed to read the server version: %w", ctx.Err())
+	}
+}
+
+// runDiagnostics checks, in order, everything the viewer needs to show the Etcd
+// A check that failed skips the ones relying on it, so the first failure is the one to fix
+func (m *Model) runDiagnostics() []diagnosticCheck {
+	contextName := m.contextName
+	if contextName == "" {
+		contextName = "<none>"
+	}
+	checks := []diagnosticCheck{{name: "kubeconfig loaded", detail: "context " + contextName}}
+
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	info, err := m.serverVersion(ctx)
+	if err != nil {
+		checks = append(checks, failedCheck("API server reachable", "The kubeconfig points to an API server that didn't answer.", err))
+		for _, name := range []string{"namespace exists", "Etcd CRD installed", "Etcd resource found"} {
+			checks = append(checks, diagnosticCheck{name: name, outcome: checkSkipped, detail: "needs the API server"})
+		}
+		return checks
+	}
+	checks = append(checks, diagnosticCheck{name: "API server reachable", detail: "Kubernetes " + info.GitVersion})
+
+	if m.allNamespaces || m.namespaceSelector != "" {
+		checks = append(checks, diagnosticCheck{name: "namespace exists", outcome: checkSkipped, detail: "looking across namespaces"})
+	} else {
+		checks = append(checks, m.checkNamespace(ctx))
+	}
+
+	crdName := etcdGVR.GroupResource().String()
+	_, err = m.dynamicClient.Resource(crdGVR).Get(ctx, crdName, metav1.GetOptions{})
+	crdMissing := apierrors.IsNotFound(err)
+	switch {
+	case crdMissing:
+		checks = append(checks, failedCheck("Etcd CRD installed", fmt.Sprintf("CRD %s doesn't exist, so etcd-druid doesn't seem to run on this cluster.", crdName), err))
+	case apierrors.IsForbidden(err):
+		checks = append(checks, diagnosticCheck{name: "Etcd CRD installed", outcome: checkSkipped, detail: "reading CRDs is denied, the Etcd resource tells instead"})
+	case err != nil:
+		checks = append(checks, failedCheck("Etcd CRD installed", fmt.Sprintf("CRD %s can't be read.", crdName), err))
+	default:
+		checks = append(checks, diagnosticCheck{name: "Etcd CRD installed", detail: crdName})
+	}
+
+	switch {
+	case m.etcdName == "":
+		checks = append(checks, diagnosticCheck{name: "Etcd resource found", outcome: checkSkipped, detail: "picked on the next screen"})
+	case crdMissing:
+		checks = append(checks, diagnosticCheck{name: "Etcd resource found", outcome: checkSkipped, detail: "needs the Etcd CRD"})
+	default:
+		if _, err := m.fetchEtcdResource(); apierrors.IsNotFound(err) {
+			checks = append(checks, failedCheck("Etcd resource found", fmt.Sprintf("Etcd %s/%s doesn't exist, check the etcd name given on the command line; the pod views may still work.", m.namespace, m.etcdName), err))
+		} else if err != nil {
+			checks = append(checks, failedCheck("Etcd resource found", fmt.Sprintf("Etcd %s/%s can't be read; the pod views may still work.", m.namespace, m.etcdName), err))
+		} else {
+			checks = append(checks, diagnosticCheck{name: "Etcd resource found", detail: m.namespace + "/" + m.etcdName})
+		}
+	}
+	return checks
+}
+
+// checkNamespace checks that the namespace given on the command line exists
+func (m *Model) checkNamespace(ctx context.Context) diagnosticCheck {
+	_, err := m.kubeClient.CoreV1().Namespaces().Get(ctx, m.namespace, metav1.GetOptions{})
+	switch {
+	case apierrors.IsForbidden(err):
+		// Reading namespaces is often denied to users who can read the pods inside one, which is all the viewer needs
+		return diagnosticCheck{name: "namespace exists", outcome: checkSkipped, detail: "reading namespaces is denied, the pod views may still work"}
+	case apierrors.IsNotFound(err):
+		return failedCheck("namespace exists", fmt.Sprintf("Namespace %s doesn't exist, check the namespace given on the command line.", m.namespace), err)
+	case err != nil:
+		return failedCheck("namespace exists", fmt.Sprintf("Namespace %s can't be read.", m.namespace), err)
+	}
+	return diagnosticCheck{name: "namespace exists", detail: m.namespace}
+}
+
+// diagnosticsPassed reports whether no check failed
+func diagnosticsPassed(checks []diagnosticCheck) bool {
+	for _, check := range checks {
+		if check.outcome == checkFailed {
+			return false
+		}
+	}
+	return true
+}
+
+// renderDiagnostics lays out the checklist, with the guidance and error under each failed check
+func (m *Model) renderDiagnostics(checks []diagnosticCheck) string {
+	var out strings.Builder
+	for _, check := range checks {
+		switch check.outcome {
+		case checkPassed:
+			fmt.Fprintf(&out, "✓ %s: %s\n", check.name, check.detail)
+		case checkSkipped:
+			fmt.Fprintf(&out, "- %s: skipped, %s\n", check.name, check.detail)
+		case checkFailed:
+			out.WriteString(m.theme.eventWarning.Render("✗ "+check.name) + "\n")
+			fmt.Fprintf(&out, "    %s\n    Error: %v\n", check.detail, check.err)
+		}
+	}
+	if diagnosticsPassed(checks) {
+		out.WriteString("\nEverything needed is in place.\n")
+	} else {
+		out.WriteString("\nFix the first failed check, then press r to check again.\n")
+	}
+	return out.String()
+}
+
+// diagnosticsLoadedMsg carries the results of the startup checks
+type diagnosticsLoadedMsg struct{ checks []diagnosticCheck }
+
+// loadDiagnostics is a command that runs the startup checks asynchronously
+// The checks report failures themselves, so nothing is retried
+func (m *Model) loadDiagnostics() tea.Cmd {
+	return func() tea.Msg {
+		return diagnosticsLoadedMsg{m.runDiagnostics()}
+	}
+}
+
+// openDiagnostics runs the checks again on demand, from the pod list
+func (m *Model) openDiagnostics() tea.Cmd {
+	m.navigate(DiagnosticsState)
+	return m.loadDiagnostics()
+}
+
+// setDiagnostics shows the checklist; on launch a clean run continues straight to the first screen
+func (m *Model) setDiagnostics(msg diagnosticsLoadedMsg) tea.Cmd {
+	if m.state != DiagnosticsState {
+		return nil
+	}
+	if m.startupChecks && diagnosticsPassed(msg.checks) {
+		return m.leaveDiagnostics()
+	}
+	m.content = m.renderDiagnostics(msg.checks)
+	m.refreshViewport()
+	return nil
+}
+
+// leaveDiagnostics goes on to the screen the viewer was started on, or back where the checks were opened from
+func (m *Model) leaveDiagnostics() tea.Cmd {
+	if !m.startupChecks {
+		return m.back()
+	}
+	m.startupChecks = false
+	m.content = ""
+	m.state = m.diagnosticsNext
+	m.layout()
+	return m.Init()
+}
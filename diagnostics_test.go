@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newStartupModel builds a Model launched with the startup checks, like main does without --skip-diagnostics
+func newStartupModel(t *testing.T, etcdObjects ...runtime.Object) Model {
+	t.Helper()
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}}
+	m, _ := newTestModel([]runtime.Object{namespace}, etcdObjects...)
+	m.diagnosticsNext = m.state
+	m.state = DiagnosticsState
+	m.startupChecks = true
+	return update(t, m, tea.WindowSizeMsg{Width: 120, Height: 30})
+}
+
+func TestStartupDiagnosticsPass(t *testing.T) {
+	m := newStartupModel(t, testEtcdCRD(), testEtcd(testEtcdName))
+	msg := m.Init()()
+	checks := msg.(diagnosticsLoadedMsg).checks
+	if !diagnosticsPassed(checks) || len(checks) != 5 {
+		t.Fatalf("checks = %+v, want all five passed", checks)
+	}
+
+	// A clean run goes straight on to the pod list
+	m = update(t, m, msg)
+	if m.state != ListState || m.startupChecks {
+		t.Errorf("state = %v after passing checks, want the pod list", m.state)
+	}
+}
+
+func TestStartupDiagnosticsFailure(t *testing.T) {
+	m := newStartupModel(t)
+	m = update(t, m, m.Init()())
+	if m.state != DiagnosticsState {
+		t.Fatalf("state = %v, want the checklist kept open on a failure", m.state)
+	}
+	for _, want := range []string{
+		"✓ API server reachable",
+		"✓ namespace exists: " + testNamespace,
+		"✗ Etcd CRD installed",
+		"etcd-druid doesn't seem to run on this cluster.",
+		"- Etcd resource found: skipped, needs the Etcd CRD",
+	} {
+		if !strings.Contains(m.content, want) {
+			t.Errorf("checklist missing %q:\n%s", want, m.content)
+		}
+	}
+	// The namespace and etcd name were found, pointing to them would mislead
+	if strings.Contains(m.content, errorNotFound.guidance()) {
+		t.Errorf("checklist of a missing CRD suggests checking the namespace and etcd name:\n%s", m.content)
+	}
+
+	// enter carries on regardless
+	next, cmd := m.Update(keyMsg("enter"))
+	m = next.(Model)
+	if m.state != ListState || cmd == nil {
+		t.Errorf("enter went to %v, want the pod list loading", m.state)
+	}
+}
+
+func TestDiagnosticsOnDemand(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("!"))
+	if m.state != DiagnosticsState {
+		t.Fatalf("! went to %v, want the checklist", m.state)
+	}
+	m = update(t, m, m.loadDiagnostics()())
+	if m.state != DiagnosticsState || !strings.Contains(m.content, "✗ namespace exists") {
+		t.Errorf("content = %q, want the failed checks shown", m.content)
+	}
+	m = update(t, m, keyMsg("esc"))
+	if m.state != ListState {
+		t.Errorf("esc went to %v, want the pod list", m.state)
+	}
+}
+
+func TestDiagnosticsCheckGuidance(t *testing.T) {
+	m, _ := newTestModel(nil)
+	for _, want := range []string{"Namespace " + testNamespace + " doesn't exist", "check the namespace given on the command line"} {
+		if check := m.checkNamespace(context.Background()); !strings.Contains(check.detail, want) {
+			t.Errorf("missing namespace guidance = %q, want %q", check.detail, want)
+		}
+	}
+
+	// RBAC fails any check alike, so its guidance follows the hint
+	denied := failedCheck("namespace exists", "Namespace can't be read.", apierrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, testNamespace, errors.New("rbac")))
+	if !strings.HasSuffix(denied.detail, errorPermission.guidance()) {
+		t.Errorf("denied check detail = %q, want the RBAC guidance", denied.detail)
+	}
+}
+
+func TestDiagnosticsServerVersionTimeout(t *testing.T) {
+	m, client := newTestModel(nil)
+	m.requestTimeout = 10 * time.Millisecond
+	hang := make(chan struct{})
+	t.Cleanup(func() { close(hang) })
+	client.PrependReactor("get", "version", func(k8stesting.Action) (bool, runtime.Object, error) {
+		<-hang
+		return false, nil, nil
+	})
+
+	checks := m.runDiagnostics()
+	if checks[1].outcome != checkFailed || !isRequestTimeout(checks[1].err) {
+		t.Errorf("API server check = %+v, want a failure once the request timeout passed", checks[1])
+	}
+}
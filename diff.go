@@ -0,0 +1,215 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// diffContext is how many unchanged lines surround each change, as in diff -u
+const diffContext = 3
+
+// diffOp is one line of an edit script between two texts
+type diffOp struct {
+	kind byte // ' ' for a line in both, '-' only in the old text, '+' only in the new one
+	line string
+}
+
+// diffLines returns the edit script turning a into b, built from their longest common subsequence
+// Pod specs are a few hundred lines, so the quadratic table stays small
+func diffLines(a, b []string) []diffOp {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, diffOp{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, diffOp{'+', b[j]})
+	}
+	return ops
+}
+
+// unifiedDiff renders the differences between two texts in diff -u format
+// It returns an empty string when they are identical
+func unifiedDiff(fromName, toName, from, to string) string {
+	ops := diffLines(splitLines(from), splitLines(to))
+
+	var out strings.Builder
+	// oldLine and newLine count the lines of each text consumed before ops[start]
+	oldLine, newLine := 0, 0
+	for start := 0; start < len(ops); {
+		if ops[start].kind == ' ' {
+			oldLine++
+			newLine++
+			start++
+			continue
+		}
+
+		// Widen the hunk with leading context, then extend it while changes are close enough to merge
+		first := max(start-diffContext, 0)
+		for k := first; k < start; k++ {
+			oldLine--
+			newLine--
+		}
+		end, unchanged := start, 0
+		for k := start; k < len(ops) && unchanged <= 2*diffContext; k++ {
+			if ops[k].kind == ' ' {
+				unchanged++
+				continue
+			}
+			unchanged = 0
+			end = k + 1
+		}
+		last := min(end+diffContext, len(ops))
+
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		oldCount, newCount := 0, 0
+		for _, op := range ops[first:last] {
+			if op.kind != '+' {
+				oldCount++
+			}
+			if op.kind != '-' {
+				newCount++
+			}
+		}
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(oldLine, oldCount), hunkRange(newLine, newCount))
+		for _, op := range ops[first:last] {
+			out.WriteByte(op.kind)
+			out.WriteString(op.line)
+			out.WriteByte('\n')
+		}
+
+		oldLine += oldCount
+		newLine += newCount
+		start = last
+	}
+	return out.String()
+}
+
+// hunkRange formats the start,count pair of a hunk header, where start is the 1-based first line
+// An empty range names the line before it, as diff -u does
+func hunkRange(consumed, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", consumed)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", consumed+1)
+	}
+	return fmt.Sprintf("%d,%d", consumed+1, count)
+}
+
+// splitLines splits text into lines without a trailing empty one
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// colorDiff styles added, removed and hunk header lines of a unified diff
+func colorDiff(content string, th theme) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+++ "), strings.HasPrefix(line, "--- "):
+			lines[i] = th.diffFile.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = th.diffHunk.Render(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = th.diffAdded.Render(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = th.diffRemoved.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// toggleDiffMark marks or unmarks a pod for the diff view
+// Only two pods can be compared, so marking a third drops the one marked first
+func (m *Model) toggleDiffMark(pod Pod) {
+	for i, marked := range m.diffMarks {
+		if samePod(marked, pod) {
+			m.diffMarks = append(m.diffMarks[:i:i], m.diffMarks[i+1:]...)
+			m.setPods(m.pods)
+			return
+		}
+	}
+	m.diffMarks = append(m.diffMarks, pod)
+	if len(m.diffMarks) > 2 {
+		m.diffMarks = m.diffMarks[1:]
+	}
+	m.setPods(m.pods)
+}
+
+// fetchPodDiff returns the unified diff between the YAML of two pods
+func (m *Model) fetchPodDiff(from, to Pod) (string, error) {
+	fromYAML, err := m.fetchPodYAML(m.podNamespace(from), from.Name)
+	if err != nil {
+		return "", err
+	}
+	toYAML, err := m.fetchPodYAML(m.podNamespace(to), to.Name)
+	if err != nil {
+		return "", err
+	}
+	fromName, toName := m.podDisplayName(from), m.podDisplayName(to)
+	diff := unifiedDiff(fromName, toName, fromYAML, toYAML)
+	if diff == "" {
+		return fmt.Sprintf("%s and %s are identical\n", fromName, toName), nil
+	}
+	return diff, nil
+}
+
+// diffLoadedMsg carries the diff between the two marked pods
+type diffLoadedMsg struct{ content string }
+
+// diffNeedsMarksNotice replaces the diff when the marks changed after it opened, e.g. through back or a reload of the pods
+const diffNeedsMarksNotice = "Mark two pods with space in the list to compare them\n"
+
+// loadPodDiff is a command that diffs the two marked pods asynchronously
+func (m *Model) loadPodDiff() tea.Cmd {
+	if len(m.diffMarks) != 2 {
+		return func() tea.Msg { return diffLoadedMsg{diffNeedsMarksNotice} }
+	}
+	from, to := m.diffMarks[0], m.diffMarks[1]
+	return func() tea.Msg {
+		content, err := retryFetch(func() (string, error) {
+			return m.fetchPodDiff(from, to)
+		})
+		if err != nil {
+			return errMsg{err}
+		}
+		return diffLoadedMsg{content}
+	}
+}
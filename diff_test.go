@@ -0,0 +1,102 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		want     string
+	}{
+		{
+			name: "identical",
+			from: "a\nb\n",
+			to:   "a\nb\n",
+			want: "",
+		},
+		{
+			name: "changed line",
+			from: "a\nb\nc\n",
+			to:   "a\nB\nc\n",
+			want: "--- old\n+++ new\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			name: "append to empty",
+			from: "",
+			to:   "a\n",
+			want: "--- old\n+++ new\n@@ -0,0 +1 @@\n+a\n",
+		},
+		{
+			name: "distant changes make separate hunks",
+			from: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			to:   "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n",
+			want: "--- old\n+++ new\n" +
+				"@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n" +
+				"@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+twelve\n",
+		},
+		{
+			name: "nearby changes share a hunk",
+			from: "1\n2\n3\n4\n5\n",
+			to:   "one\n2\n3\n4\nfive\n",
+			want: "--- old\n+++ new\n@@ -1,5 +1,5 @@\n-1\n+one\n 2\n 3\n 4\n-5\n+five\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := unifiedDiff("old", "new", tt.from, tt.to); got != tt.want {
+				t.Errorf("unifiedDiff() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffMarkedPods(t *testing.T) {
+	updated := testPod("etcd-main-1", corev1.PodRunning, runningContainer("etcd"))
+	updated.Spec.Containers[0].Image = "etcd:v3.6"
+	m, _ := newTestModel([]runtime.Object{
+		testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd")),
+		updated,
+		testPod("etcd-main-2", corev1.PodRunning, runningContainer("etcd")),
+	})
+	m = update(t, m, m.loadPods()())
+
+	m = update(t, m, keyMsg("x"))
+	if m.state != ListState || m.status == "" {
+		t.Fatalf("diffing without two marks: state = %v, status = %q", m.state, m.status)
+	}
+
+	// Marking three pods keeps the last two
+	for range 3 {
+		m = update(t, m, keyMsg(" "))
+		m = update(t, m, keyMsg("down"))
+	}
+	if len(m.diffMarks) != 2 || m.diffMarks[0].Name != "etcd-main-1" || m.diffMarks[1].Name != "etcd-main-2" {
+		t.Fatalf("diffMarks = %v, want etcd-main-1 and etcd-main-2", m.diffMarks)
+	}
+	if !m.pods[1].Marked || m.pods[0].Marked {
+		t.Errorf("list marks = %v, %v, want only the marked pods flagged", m.pods[0].Marked, m.pods[1].Marked)
+	}
+
+	m = update(t, m, keyMsg("x"))
+	if m.state != DiffState {
+		t.Fatalf("state = %v, want DiffState", m.state)
+	}
+	m = update(t, m, m.loadPodDiff()())
+	if !strings.Contains(m.content, "-    image: etcd:v3.6\n+    image: etcd:v3.5\n") {
+		t.Errorf("diff doesn't show the image change:\n%s", m.content)
+	}
+
+	// The marks can change under the open diff, a refresh then explains instead of diffing
+	m.diffMarks = m.diffMarks[:1]
+	m = update(t, m, m.loadPodDiff()())
+	if m.content != diffNeedsMarksNotice || !strings.Contains(m.View(), "Diff") {
+		t.Errorf("diff with one mark = %q", m.content)
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// defaultEtcdDataPath is where etcd-druid mounts the data volume when it can't be read from the pod spec
+const defaultEtcdDataPath = "/var/etcd/data"
+
+// diskUsageGaugeWidth is the number of cells in the usage bar
+const diskUsageGaugeWidth = 20
+
+// diskUsage is the space used on the filesystem holding the etcd data
+type diskUsage struct {
+	usedKiB     int64
+	capacityKiB int64
+}
+
+// diskUsageMsg carries the rendered usage of a pod's data volume, or why it couldn't be read
+type diskUsageMsg struct {
+	podName string
+	usage   string
+	err     error
+}
+
+// etcdDataMount finds where the data PVC is mounted in each container of the pod
+// Containers that don't mount it are left out; distroless etcd images need a sidecar with df
+func etcdDataMount(pod *corev1.Pod) map[string]string {
+	claims := map[string]bool{}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil {
+			claims[volume.Name] = true
+		}
+	}
+
+	mounts := map[string]string{}
+	for _, container := range pod.Spec.Containers {
+		for _, mount := range container.VolumeMounts {
+			if claims[mount.Name] {
+				mounts[container.Name] = mount.MountPath
+				break
+			}
+		}
+	}
+	return mounts
+}
+
+// parseDF reads the usage line of `df -P -k` output
+// POSIX mode keeps each filesystem on one line so the columns can be split reliably
+func parseDF(output string) (diskUsage, error) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) < 2 {
+		return diskUsage{}, fmt.Errorf("unexpected df output: %q", output)
+	}
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(fields) < 4 {
+		return diskUsage{}, fmt.Errorf("unexpected df output: %q", output)
+	}
+	capacity, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return diskUsage{}, fmt.Errorf("failed to parse df capacity %q: %w", fields[1], err)
+	}
+	used, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return diskUsage{}, fmt.Errorf("failed to parse df usage %q: %w", fields[2], err)
+	}
+	return diskUsage{usedKiB: used, capacityKiB: capacity}, nil
+}
+
+// String renders the usage as a small gauge, e.g. "[████░░░░] 52% 5.2Gi/10.0Gi"
+func (u diskUsage) String() string {
+	percent := 0.0
+	if u.capacityKiB > 0 {
+		percent = float64(u.usedKiB) / float64(u.capacityKiB) * 100
+	}
+	filled := min(int(percent/100*diskUsageGaugeWidth+0.5), diskUsageGaugeWidth)
+	gauge := strings.Repeat("█", filled) + strings.Repeat("░", diskUsageGaugeWidth-filled)
+	return fmt.Sprintf("[%s] %.0f%% %s/%s", gauge, percent, formatKiB(u.usedKiB), formatKiB(u.capacityKiB))
+}
+
+// formatKiB renders a size in KiB with a binary unit
+func formatKiB(kib int64) string {
+	switch {
+	case kib >= 1<<20:
+		return fmt.Sprintf("%.1fGi", float64(kib)/(1<<20))
+	case kib >= 1<<10:
+		return fmt.Sprintf("%.1fMi", float64(kib)/(1<<10))
+	}
+	return fmt.Sprintf("%dKi", kib)
+}
+
+// execInPod runs a command in a container and returns its stdout, which a failing command may still have written
+func (m *Model) execInPod(namespace, podName, container string, command []string) (string, error) {
+	if m.restConfig == nil {
+		return "", errors.New("exec is not available without a cluster connection")
+	}
+	req := m.kubeClient.CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(namespace).Name(podName).SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	executor, err := remotecommand.NewSPDYExecutor(m.restConfig, "POST", req.URL())
+	if err != nil {
+		return "", fmt.Errorf("failed to set up exec: %w", err)
+	}
+
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	if err := executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: &stdout, Stderr: &stderr}); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return stdout.String(), fmt.Errorf("%w: %s", err, msg)
+		}
+		return stdout.String(), err
+	}
+	return stdout.String(), nil
+}
+
+// fetchDiskUsage measures the etcd data volume of a pod by running df in a container that mounts it
+// The etcd container is tried first; the backup sidecar mounts the same volume and usually has a shell
+func (m *Model) fetchDiskUsage(namespace, podName string) (diskUsage, error) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	pod, err := m.kubeClient.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return diskUsage{}, fmt.Errorf("failed to get pod %s: %w", podName, err)
+	}
+
+	mounts := etcdDataMount(pod)
+	var containers []string
+	for _, container := range pod.Spec.Containers {
+		if _, ok := mounts[container.Name]; ok {
+			containers = append(containers, container.Name)
+		}
+	}
+	if len(containers) == 0 && len(pod.Spec.Containers) > 0 {
+		containers = []string{pod.Spec.Containers[0].Name}
+		mounts[containers[0]] = defaultEtcdDataPath
+	}
+
+	var errs []error
+	for _, container := range containers {
+		output, err := m.execInPod(namespace, podName, container, []string{"df", "-P", "-k", mounts[container]})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", container, err))
+			continue
+		}
+		return parseDF(output)
+	}
+	return diskUsage{}, fmt.Errorf("failed to measure data volume of pod %s: %w", podName, errors.Join(errs...))
+}
+
+// loadDiskUsage is a command that measures the data volume of the selected pod asynchronously
+// Failures are reported in the footer rather than the error view, since many images lack df
+func (m *Model) loadDiskUsage() tea.Cmd {
+	namespace, podName := m.podNamespace(m.selectedPod), m.selectedPod.Name
+	m.beginOperation(execOperation(podName))
+	return func() tea.Msg {
+		usage, err := m.fetchDiskUsage(namespace, podName)
+		if err != nil {
+			return diskUsageMsg{podName: podName, err: err}
+		}
+		return diskUsageMsg{podName: podName, usage: usage.String()}
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestParseDF(t *testing.T) {
+	output := "Filesystem     1024-blocks    Used Available Capacity Mounted on\n" +
+		"/dev/sdb          10255636 5127818   5111434      51% /var/etcd/data\n"
+	got, err := parseDF(output)
+	if err != nil {
+		t.Fatalf("parseDF() error = %v", err)
+	}
+	if got.capacityKiB != 10255636 || got.usedKiB != 5127818 {
+		t.Errorf("parseDF() = %+v", got)
+	}
+	if want := "[██████████░░░░░░░░░░] 50% 4.9Gi/9.8Gi"; got.String() != want {
+		t.Errorf("String() = %q, want %q", got.String(), want)
+	}
+
+	for _, bad := range []string{"", "df: /var/etcd/data: No such file or directory", "Filesystem\n/dev/sdb x y z"} {
+		if _, err := parseDF(bad); err == nil {
+			t.Errorf("parseDF(%q) expected an error", bad)
+		}
+	}
+}
+
+func TestEtcdDataMount(t *testing.T) {
+	pod := testPod("etcd-main-0", corev1.PodRunning)
+	pod.Spec.Volumes = []corev1.Volume{
+		{Name: "etcd-config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}}},
+		{Name: "etcd-main", VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "etcd-main-etcd-main-0"},
+		}},
+	}
+	pod.Spec.Containers = []corev1.Container{
+		{Name: "etcd", VolumeMounts: []corev1.VolumeMount{
+			{Name: "etcd-config", MountPath: "/var/etcd/config"},
+			{Name: "etcd-main", MountPath: "/var/etcd/data"},
+		}},
+		{Name: "backup-restore", VolumeMounts: []corev1.VolumeMount{{Name: "etcd-main", MountPath: "/data"}}},
+		{Name: "sidecar"},
+	}
+
+	got := etcdDataMount(pod)
+	if len(got) != 2 || got["etcd"] != "/var/etcd/data" || got["backup-restore"] != "/data" {
+		t.Errorf("etcdDataMount() = %v", got)
+	}
+}
+
+func TestFetchDiskUsageWithoutExec(t *testing.T) {
+	pod := testPod("etcd-main-0", corev1.PodRunning)
+	pod.Spec.Containers = []corev1.Container{{Name: "etcd"}}
+	m, _ := newTestModel([]runtime.Object{pod})
+
+	_, err := m.fetchDiskUsage(testNamespace, "etcd-main-0")
+	if err == nil || !strings.Contains(err.Error(), "exec is not available") {
+		t.Errorf("fetchDiskUsage() error = %v, want exec to be reported unavailable", err)
+	}
+}
+
+func TestDiskUsageReadOnly(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m.readOnly = true
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	next, cmd := m.Update(keyMsg("u"))
+	m = next.(Model)
+	// df only reads the volume, so it isn't one of the actions read-only mode disables
+	if cmd == nil || m.status != "measuring data volume of etcd-main-0" {
+		t.Errorf("u in read-only mode: status %q, want the usage measured", m.status)
+	}
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// editTarget is the object being edited: a pod, or the Etcd resource itself
+type editTarget struct {
+	namespace string
+	name      string
+	etcd      bool
+}
+
+// kind names what the target is, "pod" or "etcd"
+func (t editTarget) kind() string {
+	if t.etcd {
+		return "etcd"
+	}
+	return "pod"
+}
+
+// String names the target in messages, e.g. "pod etcd-main-0"
+func (t editTarget) String() string { return t.kind() + " " + t.name }
+
+// Messages for the edit-in-$EDITOR flow
+// The object is written to a temp file, the TUI is suspended while the editor runs, then the result is applied
+type editReadyMsg struct {
+	target   editTarget
+	path     string
+	original []byte
+}
+type editorFinishedMsg struct {
+	target   editTarget
+	path     string
+	original []byte
+	err      error
+}
+
+// editedMsg reports an edit that was cancelled or failed; an applied one reports its diff with a changeMsg
+type editedMsg struct{ summary string }
+
+// editorCommand returns the user's preferred editor, following the same variables as kubectl edit
+func editorCommand() string {
+	for _, env := range []string{"KUBE_EDITOR", "VISUAL", "EDITOR"} {
+		// A blank setting names no editor, exec-ing it would fail on an empty command
+		if editor := strings.TrimSpace(os.Getenv(env)); editor != "" {
+			return editor
+		}
+	}
+	return "vi"
+}
+
+// fetchEditTarget gets the object to edit
+func (m *Model) fetchEditTarget(ctx context.Context, target editTarget) (runtime.Object, error) {
+	if target.etcd {
+		etcd, err := m.dynamicClient.Resource(etcdGVR).Namespace(target.namespace).Get(ctx, target.name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s: %w", target, err)
+		}
+		return etcd, nil
+	}
+	pod, err := m.kubeClient.CoreV1().Pods(target.namespace).Get(ctx, target.name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", target, err)
+	}
+	return pod, nil
+}
+
+// prepareEdit is a command that writes the YAML of a pod or the Etcd into a temp file for editing
+// managedFields only add noise; omitting them on update leaves them untouched
+func (m *Model) prepareEdit(target editTarget) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := m.requestContext()
+		defer cancel()
+		obj, err := m.fetchEditTarget(ctx, target)
+		if err != nil {
+			return errMsg{err}
+		}
+		original, err := changeYAML(obj)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		f, err := os.CreateTemp("", target.name+"-*.yaml")
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to create temp file: %w", err)}
+		}
+		defer f.Close()
+		if _, err := f.WriteString(original); err != nil {
+			os.Remove(f.Name())
+			return errMsg{fmt.Errorf("failed to write temp file: %w", err)}
+		}
+
+		return editReadyMsg{target: target, path: f.Name(), original: []byte(original)}
+	}
+}
+
+// openEditor suspends the TUI and runs the editor on the prepared file
+func openEditor(msg editReadyMsg) tea.Cmd {
+	// The editor setting may carry arguments, e.g. "code --wait"
+	args := strings.Fields(editorCommand())
+	cmd := exec.Command(args[0], append(args[1:], msg.path)...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editorFinishedMsg{target: msg.target, path: msg.path, original: msg.original, err: err}
+	})
+}
+
+// decodeEdit parses the edited YAML into the object to update
+// The name can't change, an update of another name would be a different object
+func decodeEdit(target editTarget, edited []byte) (runtime.Object, error) {
+	var obj interface {
+		runtime.Object
+		GetName() string
+	}
+	if target.etcd {
+		// Going through JSON keeps whole numbers int64, as the API machinery expects them
+		etcd := &unstructured.Unstructured{}
+		json, err := yaml.YAMLToJSON(edited)
+		if err == nil {
+			err = etcd.UnmarshalJSON(json)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("edited yaml is invalid: %w", err)
+		}
+		if etcd.GetKind() != "Etcd" {
+			return nil, fmt.Errorf("kind can't be changed (got %q)", etcd.GetKind())
+		}
+		obj = etcd
+	} else {
+		pod := &corev1.Pod{}
+		if err := yaml.UnmarshalStrict(edited, pod); err != nil {
+			return nil, fmt.Errorf("edited yaml is invalid: %w", err)
+		}
+		obj = pod
+	}
+	if obj.GetName() != target.name {
+		return nil, fmt.Errorf("%s name can't be changed (got %q)", target.kind(), obj.GetName())
+	}
+	return obj, nil
+}
+
+// updateEditTarget sends the edited object to the API server
+func (m *Model) updateEditTarget(ctx context.Context, target editTarget, obj runtime.Object) (runtime.Object, error) {
+	if target.etcd {
+		return m.dynamicClient.Resource(etcdGVR).Namespace(target.namespace).Update(ctx, obj.(*unstructured.Unstructured), m.updateOptions())
+	}
+	return m.kubeClient.CoreV1().Pods(target.namespace).Update(ctx, obj.(*corev1.Pod), m.updateOptions())
+}
+
+// applyEdit is a command that reads the edited file back and updates the object when it changed
+// It reports the diff between what was edited and what the server stored, which admission may have changed again
+func (m *Model) applyEdit(msg editorFinishedMsg) tea.Cmd {
+	m.beginOperation(editOperation)
+	return func() tea.Msg {
+		defer os.Remove(msg.path)
+
+		if msg.err != nil {
+			return editedMsg{fmt.Sprintf("editor failed: %v", msg.err)}
+		}
+		edited, err := os.ReadFile(msg.path)
+		if err != nil {
+			return editedMsg{fmt.Sprintf("failed to read edited file: %v", err)}
+		}
+		if string(edited) == string(msg.original) {
+			return editedMsg{fmt.Sprintf("edit cancelled, no changes to %s", msg.target.name)}
+		}
+		obj, err := decodeEdit(msg.target, edited)
+		if err != nil {
+			return editedMsg{err.Error()}
+		}
+
+		ctx, cancel := m.requestContext()
+		defer cancel()
+		after, err := m.updateEditTarget(ctx, msg.target, obj)
+		if err != nil {
+			return editedMsg{fmt.Sprintf("failed to update %s: %v", msg.target, err)}
+		}
+		stored, err := changeYAML(after)
+		if err != nil {
+			return changeMsg{operation: editOperation, err: err}
+		}
+		added, removed := lineDiffStats(string(msg.original), string(edited))
+		toName := "stored"
+		if m.dryRun {
+			toName = "dry run"
+		}
+		return changeMsg{
+			operation: editOperation,
+			title:     fmt.Sprintf("edit %s (+%d/-%d lines)", msg.target, added, removed),
+			diff:      changeDiff("live", toName, string(msg.original), stored),
+			dryRun:    m.dryRun,
+		}
+	}
+}
+
+// lineDiffStats counts lines added and removed between two texts, ignoring line order
+// It's a cheap summary for status messages rather than a real diff
+func lineDiffStats(before, after string) (added, removed int) {
+	remaining := map[string]int{}
+	for _, line := range strings.Split(before, "\n") {
+		remaining[line]++
+	}
+	for _, line := range strings.Split(after, "\n") {
+		if remaining[line] > 0 {
+			remaining[line]--
+			continue
+		}
+		added++
+	}
+	for _, count := range remaining {
+		removed += count
+	}
+	return added, removed
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// editWith edits a target through its temp file like the editor would, and applies the edit
+func editWith(t *testing.T, m *Model, target editTarget, edit func(original string) string) tea.Msg {
+	t.Helper()
+	ready, ok := m.prepareEdit(target)().(editReadyMsg)
+	if !ok {
+		t.Fatal("prepareEdit() did not produce an editReadyMsg")
+	}
+	if err := os.WriteFile(ready.path, []byte(edit(string(ready.original))), 0o600); err != nil {
+		t.Fatalf("failed to write edit: %v", err)
+	}
+	msg := m.applyEdit(editorFinishedMsg{target: ready.target, path: ready.path, original: ready.original})()
+	if _, err := os.Stat(ready.path); !os.IsNotExist(err) {
+		t.Errorf("applyEdit() left the temp file %s behind", filepath.Base(ready.path))
+	}
+	return msg
+}
+
+// editSummary is the title of an applied edit, or the summary of one that wasn't
+func editSummary(msg tea.Msg) string {
+	switch msg := msg.(type) {
+	case changeMsg:
+		return msg.title
+	case editedMsg:
+		return msg.summary
+	}
+	return ""
+}
+
+func TestApplyPodEdit(t *testing.T) {
+	tests := []struct {
+		name        string
+		edit        func(original string) string
+		wantSummary string
+		wantLabel   string
+	}{
+		{
+			name:        "label added",
+			edit:        func(s string) string { return strings.Replace(s, "labels:\n", "labels:\n    debug: \"true\"\n", 1) },
+			wantSummary: "edit pod etcd-main-0 (+1/-0 lines)",
+			wantLabel:   "true",
+		},
+		{
+			name:        "unchanged",
+			edit:        func(s string) string { return s },
+			wantSummary: "edit cancelled",
+		},
+		{
+			name:        "invalid yaml",
+			edit:        func(s string) string { return s + "\n: [" },
+			wantSummary: "edited yaml is invalid",
+		},
+		{
+			name:        "renamed",
+			edit:        func(s string) string { return strings.Replace(s, "name: etcd-main-0", "name: etcd-main-9", 1) },
+			wantSummary: "pod name can't be changed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, client := newTestModel([]runtime.Object{
+				testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd")),
+			})
+
+			msg := editWith(t, &m, editTarget{namespace: testNamespace, name: "etcd-main-0"}, tt.edit)
+			if got := editSummary(msg); !strings.HasPrefix(got, tt.wantSummary) {
+				t.Errorf("applyEdit() summary = %q, want prefix %q", got, tt.wantSummary)
+			}
+			if tt.wantLabel != "" && !strings.Contains(msg.(changeMsg).diff, "+    debug: \"true\"") {
+				t.Errorf("applyEdit() diff doesn't show the added label:\n%s", msg.(changeMsg).diff)
+			}
+
+			pod, err := client.CoreV1().Pods(testNamespace).Get(context.Background(), "etcd-main-0", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get pod: %v", err)
+			}
+			if got := pod.Labels["debug"]; got != tt.wantLabel {
+				t.Errorf("pod label debug = %q, want %q", got, tt.wantLabel)
+			}
+		})
+	}
+}
+
+func TestApplyEtcdEdit(t *testing.T) {
+	etcd := testEtcd(testEtcdName)
+	if err := unstructured.SetNestedField(etcd.Object, int64(3), "spec", "replicas"); err != nil {
+		t.Fatal(err)
+	}
+	m, _ := newTestModel(nil, etcd)
+	target := editTarget{namespace: testNamespace, name: testEtcdName, etcd: true}
+
+	msg := editWith(t, &m, target, func(s string) string { return strings.Replace(s, "replicas: 3", "replicas: 5", 1) })
+	change, ok := msg.(changeMsg)
+	if !ok {
+		t.Fatalf("applyEdit() returned %T (%q), want changeMsg", msg, editSummary(msg))
+	}
+	// The diff goes from the live object the edit started from to what the server stored
+	if change.title != "edit etcd etcd-main (+1/-1 lines)" || !strings.Contains(change.diff, "+  replicas: 5") ||
+		!strings.HasPrefix(change.diff, "--- live\n+++ stored\n") {
+		t.Errorf("applyEdit() title %q, diff:\n%s", change.title, change.diff)
+	}
+	got, err := m.dynamicClient.Resource(etcdGVR).Namespace(testNamespace).Get(context.Background(), testEtcdName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get etcd: %v", err)
+	}
+	if replicas, _, _ := unstructured.NestedInt64(got.Object, "spec", "replicas"); replicas != 5 {
+		t.Errorf("etcd replicas = %d, want 5", replicas)
+	}
+
+	msg = editWith(t, &m, target, func(s string) string { return strings.Replace(s, "kind: Etcd", "kind: Pod", 1) })
+	if got := editSummary(msg); got != `kind can't be changed (got "Pod")` {
+		t.Errorf("applyEdit() of a changed kind = %q", got)
+	}
+}
+
+func TestEditKeyReadOnly(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m.readOnly = true
+	m.state = YamlState
+	m.yamlEtcd = true
+	m = update(t, m, keyMsg("E"))
+	if m.status != "read-only mode: edit is disabled" {
+		t.Errorf("status = %q, want the edit refused", m.status)
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("KUBE_EDITOR", "  ")
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "code --wait")
+	if got := editorCommand(); got != "code --wait" {
+		t.Errorf("editorCommand() = %q, want the blank KUBE_EDITOR skipped", got)
+	}
+	t.Setenv("EDITOR", "\t")
+	if got := editorCommand(); got != "vi" {
+		t.Errorf("editorCommand() = %q, want vi when every setting is blank", got)
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// errorCategory groups errors by what the user can do about them
+type errorCategory int
+
+const (
+	errorInternal errorCategory = iota // anything unrecognised
+	errorAuth
+	errorTransient   // the API server is there but restarting, overloaded or dropped the connection
+	errorUnreachable // the API server can't be reached or didn't answer in time, e.g. a DNS failure or a bad --server
+	errorNotFound
+	errorPermission
+)
+
+func (c errorCategory) String() string {
+	switch c {
+	case errorAuth:
+		return "auth"
+	case errorTransient:
+		return "transient"
+	case errorUnreachable:
+		return "unreachable"
+	case errorNotFound:
+		return "notfound"
+	case errorPermission:
+		return "permission"
+	}
+	return "internal"
+}
+
+// icon marks the category on the error screen
+func (c errorCategory) icon() string {
+	switch c {
+	case errorAuth:
+		return "🔑"
+	case errorTransient:
+		return "⏳"
+	case errorUnreachable:
+		return "📡"
+	case errorNotFound:
+		return "🔍"
+	case errorPermission:
+		return "⛔"
+	}
+	return "💥"
+}
+
+// title heads the error screen
+func (c errorCategory) title() string {
+	switch c {
+	case errorAuth:
+		return "Not authenticated"
+	case errorTransient:
+		return "API server unavailable"
+	case errorUnreachable:
+		return "API server unreachable"
+	case errorNotFound:
+		return "Not found"
+	case errorPermission:
+		return "Permission denied"
+	}
+	return "Unexpected error"
+}
+
+// guidance suggests what to check for the category
+func (c errorCategory) guidance() string {
+	switch c {
+	case errorAuth:
+		return "The API server rejected the credentials. Check that the kubeconfig context is still logged in, or that --token hasn't expired."
+	case errorTransient:
+		return "The API server is restarting or overloaded, retrying in a moment should work."
+	case errorUnreachable:
+		return "Check the connection to the cluster, e.g. a VPN or --server, and whether the API server is up."
+	case errorNotFound:
+		return "Check the namespace and etcd name, the resource may also have just been deleted."
+	case errorPermission:
+		return "RBAC doesn't allow this request, ask for access to the resource or use an identity that has it."
+	}
+	return "Retrying may help; if it keeps happening the cause is below."
+}
+
+// viewerError is an error along with its category, which the error screen and retryFetch act on
+type viewerError struct {
+	category errorCategory
+	err      error
+}
+
+func (e *viewerError) Error() string { return e.err.Error() }
+func (e *viewerError) Unwrap() error { return e.err }
+
+// classifyError wraps err with its category, keeping one that was already categorized
+func classifyError(err error) *viewerError {
+	var categorized *viewerError
+	if errors.As(err, &categorized) {
+		return categorized
+	}
+	return &viewerError{category: categorize(err), err: err}
+}
+
+// categorize tells the category from the API status or the network error underneath err
+// A request that already waited requestTimeout counts as unreachable, retrying it would only multiply the wait
+func categorize(err error) errorCategory {
+	switch {
+	case apierrors.IsUnauthorized(err):
+		return errorAuth
+	case apierrors.IsForbidden(err):
+		return errorPermission
+	case apierrors.IsNotFound(err):
+		return errorNotFound
+	case isRequestTimeout(err):
+		return errorUnreachable
+	case apierrors.IsTimeout(err), apierrors.IsServerTimeout(err),
+		apierrors.IsTooManyRequests(err), apierrors.IsServiceUnavailable(err),
+		errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, syscall.ECONNRESET), errors.Is(err, io.ErrUnexpectedEOF):
+		return errorTransient
+	}
+	// A host that doesn't resolve won't start resolving by itself, even when the lookup timed out
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return errorUnreachable
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return errorTransient
+		}
+		return errorUnreachable
+	}
+	return errorInternal
+}
+
+// errorView renders the error screen: what went wrong, what to check, and the error itself
+func (m Model) errorView() string {
+	classified := classifyError(m.err)
+	detail := m.err.Error()
+	if isRequestTimeout(m.err) {
+		detail = fmt.Sprintf("request timed out after %s", m.requestTimeout)
+	}
+	headline := m.theme.eventWarning.Bold(true).Render(fmt.Sprintf("%s %s", classified.category.icon(), classified.category.title()))
+	return fmt.Sprintf("%s\n%s\n\nError: %s\nPress 'r' to retry or 'q' to quit.",
+		headline, classified.category.guidance(), detail)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestClassifyError(t *testing.T) {
+	pods := schema.GroupResource{Resource: "pods"}
+	tests := []struct {
+		name string
+		err  error
+		want errorCategory
+	}{
+		{"unauthorized", apierrors.NewUnauthorized("token expired"), errorAuth},
+		{"forbidden", fmt.Errorf("failed to list etcd pods: %w", apierrors.NewForbidden(pods, "", errors.New("rbac"))), errorPermission},
+		{"not found", apierrors.NewNotFound(pods, "etcd-main-0"), errorNotFound},
+		{"service unavailable", apierrors.NewServiceUnavailable("restarting"), errorTransient},
+		{"connection refused", &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, errorTransient},
+		{"unknown host", fmt.Errorf("failed to list etcd pods: %w", &net.DNSError{Err: "no such host", Name: "api"}), errorUnreachable},
+		{"dns timeout", &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "i/o timeout", Name: "api", IsTimeout: true}}, errorUnreachable},
+		{"request timeout", fmt.Errorf("failed to list etcd pods: %w", context.DeadlineExceeded), errorUnreachable},
+		{"unknown", errors.New("failed to marshal pod to yaml"), errorInternal},
+		{"already classified", fmt.Errorf("wrapped: %w", &viewerError{category: errorAuth, err: errors.New("login")}), errorAuth},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyError(tt.err)
+			if got.category != tt.want {
+				t.Errorf("classifyError(%v) category = %v, want %v", tt.err, got.category, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorViewGuidesByCategory(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, errMsg{fmt.Errorf("failed to list etcd pods: %w",
+		apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("rbac")))})
+
+	var classified *viewerError
+	if !errors.As(m.err, &classified) || classified.category != errorPermission {
+		t.Fatalf("m.err = %#v, want it categorized as permission", m.err)
+	}
+	view := m.View()
+	for _, want := range []string{errorPermission.icon() + " Permission denied", "RBAC doesn't allow", "Error: failed to list etcd pods", "'r' to retry"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q:\n%s", want, view)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	tea "github.com/charmbracelet/bubbletea"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// etcdctlTLS is one place a client certificate for etcdctl is usually mounted
+type etcdctlTLS struct {
+	name   string
+	cacert string
+	cert   string
+	key    string
+}
+
+// etcdctlTLSLayouts are the certificate mounts tried in order, newest etcd-druid first
+var etcdctlTLSLayouts = []etcdctlTLS{
+	{"etcd-druid", "/var/etcd/ssl/ca/bundle.crt", "/var/etcd/ssl/client/tls.crt", "/var/etcd/ssl/client/tls.key"},
+	{"etcd-druid (legacy)", "/var/etcd/ssl/client/ca/bundle.crt", "/var/etcd/ssl/client/client/tls.crt", "/var/etcd/ssl/client/client/tls.key"},
+	{"kubeadm", "/etc/kubernetes/pki/etcd/ca.crt", "/etc/kubernetes/pki/etcd/healthcheck-client.crt", "/etc/kubernetes/pki/etcd/healthcheck-client.key"},
+}
+
+// errEtcdctlMissing means the container has no etcdctl binary, e.g. a distroless etcd image
+var errEtcdctlMissing = errors.New("etcdctl not found in the container")
+
+// etcdctlArgs builds an etcdctl command against the local member; a nil tls talks plaintext
+// --cluster makes etcdctl look up every member from the local one, so one exec covers the whole cluster
+func etcdctlArgs(tls *etcdctlTLS, args ...string) []string {
+	command := []string{"etcdctl"}
+	if tls == nil {
+		command = append(command, "--endpoints=http://localhost:2379")
+	} else {
+		command = append(command, "--endpoints=https://localhost:2379",
+			"--cacert="+tls.cacert, "--cert="+tls.cert, "--key="+tls.key)
+	}
+	return append(append(command, args...), "--cluster", "-w", "json")
+}
+
+// isEtcdctlMissing reports whether an exec failed because etcdctl isn't installed
+func isEtcdctlMissing(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "etcdctl") && strings.Contains(msg, "executable file not found")
+}
+
+// isMissingCertFile reports whether etcdctl failed to open one of the certificate files of tls
+func isMissingCertFile(err error, tls etcdctlTLS) bool {
+	msg := err.Error()
+	if !strings.Contains(msg, "no such file or directory") {
+		return false
+	}
+	return strings.Contains(msg, tls.cacert) || strings.Contains(msg, tls.cert) || strings.Contains(msg, tls.key)
+}
+
+// explainEtcdctlError turns the usual etcdctl failures into something to act on
+func explainEtcdctlError(err error) error {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "x509:"), strings.Contains(msg, "tls:"), strings.Contains(msg, "authentication handshake failed"):
+		return fmt.Errorf("etcd rejected the client certificate or its CA doesn't match: %w", err)
+	case strings.Contains(msg, "context deadline exceeded"):
+		return fmt.Errorf("etcd didn't answer in time, the member may be down or quorum lost: %w", err)
+	case strings.Contains(msg, "connection refused"):
+		return fmt.Errorf("nothing listens on localhost:2379 in the pod: %w", err)
+	}
+	return err
+}
+
+// endpointStatus is one entry of `etcdctl endpoint status -w json`
+type endpointStatus struct {
+	Endpoint string `json:"Endpoint"`
+	Status   struct {
+		Header struct {
+			MemberID uint64 `json:"member_id"`
+		} `json:"header"`
+		Version     string `json:"version"`
+		DBSize      int64  `json:"dbSize"`
+		DBSizeInUse int64  `json:"dbSizeInUse"`
+		Leader      uint64 `json:"leader"`
+		RaftTerm    uint64 `json:"raftTerm"`
+		RaftIndex   uint64 `json:"raftIndex"`
+		IsLearner   bool   `json:"isLearner"`
+	} `json:"Status"`
+}
+
+// endpointHealth is one entry of `etcdctl endpoint health -w json`
+type endpointHealth struct {
+	Endpoint string `json:"endpoint"`
+	Health   bool   `json:"health"`
+	Took     string `json:"took"`
+	Error    string `json:"error,omitempty"`
+}
+
+// parseEtcdctlJSON decodes the JSON etcdctl printed
+func parseEtcdctlJSON[T any](command, output string) ([]T, error) {
+	var entries []T
+	if err := json.Unmarshal([]byte(strings.TrimSpace(output)), &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse etcdctl %s output %q: %w", command, output, err)
+	}
+	return entries, nil
+}
+
+// quorumReport is the parsed etcdctl output of a cluster
+type quorumReport struct {
+	statuses []endpointStatus
+	health   []endpointHealth
+}
+
+// etcdctlRunner runs etcdctl in a container of the pod, returning its stdout even when it fails
+type etcdctlRunner func(container string, command []string) (string, error)
+
+// checkQuorum runs endpoint status and health in the first container that has etcdctl
+// Each known certificate layout is tried until one exists, then plaintext
+func checkQuorum(run etcdctlRunner, containers []string) (quorumReport, error) {
+	var errs []error
+	for _, container := range containers {
+		report, err := checkQuorumIn(run, container)
+		if errors.Is(err, errEtcdctlMissing) {
+			errs = append(errs, fmt.Errorf("%s: %w", container, err))
+			continue
+		}
+		if err != nil {
+			return quorumReport{}, fmt.Errorf("failed to run etcdctl in container %s: %w", container, err)
+		}
+		return report, nil
+	}
+	return quorumReport{}, fmt.Errorf("no container has etcdctl, the etcd image may be distroless: %w", errors.Join(errs...))
+}
+
+// checkQuorumIn tries the certificate layouts in one container
+func checkQuorumIn(run etcdctlRunner, container string) (quorumReport, error) {
+	var missing []string
+	for i := range etcdctlTLSLayouts {
+		tls := &etcdctlTLSLayouts[i]
+		report, err := runQuorumCommands(run, container, tls)
+		if err != nil && isMissingCertFile(err, *tls) {
+			missing = append(missing, tls.name)
+			continue
+		}
+		return report, err
+	}
+	report, err := runQuorumCommands(run, container, nil)
+	if err != nil {
+		return quorumReport{}, fmt.Errorf("no client certificate at the %s paths, and plaintext failed: %w", strings.Join(missing, ", "), err)
+	}
+	return report, nil
+}
+
+// runQuorumCommands runs endpoint status and endpoint health with one certificate layout
+func runQuorumCommands(run etcdctlRunner, container string, tls *etcdctlTLS) (quorumReport, error) {
+	statuses, err := runEtcdctl[endpointStatus](run, container, tls, "endpoint", "status")
+	if err != nil {
+		return quorumReport{}, err
+	}
+	health, err := runEtcdctl[endpointHealth](run, container, tls, "endpoint", "health")
+	if err != nil {
+		return quorumReport{}, err
+	}
+	return quorumReport{statuses: statuses, health: health}, nil
+}
+
+// runEtcdctl runs one etcdctl command and decodes its JSON output
+// With a member down etcdctl exits non-zero, yet still prints the endpoints it reached, so those are kept
+func runEtcdctl[T any](run etcdctlRunner, container string, tls *etcdctlTLS, args ...string) ([]T, error) {
+	command := strings.Join(args, " ")
+	output, runErr := run(container, etcdctlArgs(tls, args...))
+	if runErr != nil {
+		switch {
+		case isEtcdctlMissing(runErr):
+			return nil, errEtcdctlMissing
+		case tls != nil && isMissingCertFile(runErr, *tls):
+			return nil, runErr
+		}
+	}
+	entries, err := parseEtcdctlJSON[T](command, output)
+	if runErr != nil && (err != nil || len(entries) == 0) {
+		return nil, explainEtcdctlError(runErr)
+	}
+	return entries, err
+}
+
+// renderQuorum lays out a row per endpoint, the unhealthy ones colored, under a quorum summary
+func (m *Model) renderQuorum(r quorumReport, podName string) string {
+	endpoints := make([]string, 0, len(r.health))
+	healthOf := map[string]endpointHealth{}
+	for _, h := range r.health {
+		endpoints = append(endpoints, h.Endpoint)
+		healthOf[h.Endpoint] = h
+	}
+	statusOf := map[string]endpointStatus{}
+	for _, s := range r.statuses {
+		statusOf[s.Endpoint] = s
+		if !slices.Contains(endpoints, s.Endpoint) {
+			endpoints = append(endpoints, s.Endpoint)
+		}
+	}
+	slices.Sort(endpoints)
+
+	healthy := 0
+	for _, h := range r.health {
+		if h.Health {
+			healthy++
+		}
+	}
+	needed := len(endpoints)/2 + 1
+	quorum := fmt.Sprintf("quorum ok, %d of %d needed", needed, len(endpoints))
+	if healthy < needed {
+		quorum = m.theme.eventWarning.Render(fmt.Sprintf("QUORUM LOST, %d of %d needed", needed, len(endpoints)))
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "etcdctl in pod %s: %d/%d endpoints healthy, %s\n\n", podName, healthy, len(endpoints), quorum)
+
+	// Align columns first, then color whole rows so escape codes don't skew the widths
+	var table strings.Builder
+	w := tabwriter.NewWriter(&table, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ENDPOINT\tHEALTH\tLEADER\tDB SIZE\tIN USE\tRAFT TERM\tRAFT INDEX\tVERSION")
+	var errorLines []string
+	for _, endpoint := range endpoints {
+		h, hasHealth := healthOf[endpoint]
+		health := "unknown"
+		if hasHealth {
+			health = "unhealthy"
+			if h.Health {
+				health = "healthy (" + h.Took + ")"
+			}
+			if h.Error != "" {
+				errorLines = append(errorLines, fmt.Sprintf("  %s: %s", endpoint, h.Error))
+			}
+		}
+		s, ok := statusOf[endpoint]
+		if !ok {
+			fmt.Fprintf(w, "%s\t%s\t-\t-\t-\t-\t-\t-\n", endpoint, health)
+			continue
+		}
+		leader := "no"
+		switch {
+		case s.Status.Leader == s.Status.Header.MemberID:
+			leader = "yes"
+		case s.Status.IsLearner:
+			leader = "learner"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%d\t%s\n", endpoint, health, leader,
+			formatKiB(s.Status.DBSize/1024), formatKiB(s.Status.DBSizeInUse/1024), s.Status.RaftTerm, s.Status.RaftIndex, s.Status.Version)
+	}
+	w.Flush()
+
+	lines := strings.Split(strings.TrimSuffix(table.String(), "\n"), "\n")
+	for i, endpoint := range endpoints {
+		if h, ok := healthOf[endpoint]; !ok || !h.Health {
+			lines[i+1] = m.theme.eventWarning.Render(lines[i+1])
+		}
+	}
+	out.WriteString(strings.Join(lines, "\n") + "\n")
+	if len(errorLines) > 0 {
+		out.WriteString("\nErrors:\n" + strings.Join(errorLines, "\n") + "\n")
+	}
+	return out.String()
+}
+
+// quorumOperation names the etcdctl exec for the quit confirmation
+func quorumOperation(podName string) string { return "etcdctl health check in pod " + podName }
+
+// fetchQuorum execs etcdctl in the pod, preferring the etcd container, and renders the cluster health
+func (m *Model) fetchQuorum(namespace, podName string) (string, error) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	pod, err := m.kubeClient.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get pod %s: %w", podName, err)
+	}
+	var containers []string
+	for _, container := range pod.Spec.Containers {
+		containers = append(containers, container.Name)
+	}
+	if i := slices.Index(containers, etcdContainer); i > 0 {
+		containers = append([]string{etcdContainer}, slices.Delete(containers, i, i+1)...)
+	}
+
+	report, err := checkQuorum(func(container string, command []string) (string, error) {
+		return m.execInPod(namespace, podName, container, command)
+	}, containers)
+	if err != nil {
+		return "", err
+	}
+	return m.renderQuorum(report, podName), nil
+}
+
+// quorumLoadedMsg carries the rendered cluster health, or why etcdctl couldn't report it
+type quorumLoadedMsg struct {
+	podName string
+	content string
+	err     error
+}
+
+// loadQuorum is a command that runs the etcdctl health check in the selected pod asynchronously
+func (m *Model) loadQuorum() tea.Cmd {
+	namespace, podName := m.podNamespace(m.selectedPod), m.selectedPod.Name
+	m.beginOperation(quorumOperation(podName))
+	return func() tea.Msg {
+		content, err := m.fetchQuorum(namespace, podName)
+		return quorumLoadedMsg{podName: podName, content: content, err: err}
+	}
+}
+
+// openQuorum shows the etcdctl view of the cluster from the selected pod
+// The health check only reads, so it runs in read-only mode too
+func (m *Model) openQuorum() tea.Cmd {
+	m.navigate(QuorumState)
+	return tea.Batch(m.startLoading("running etcdctl in pod "+m.selectedPod.Name), m.loadQuorum())
+}
+
+// setQuorum shows a health check that arrived, explaining a failure in place of the table
+func (m *Model) setQuorum(msg quorumLoadedMsg) {
+	m.endOperation(quorumOperation(msg.podName))
+	if m.state != QuorumState {
+		return
+	}
+	m.stopLoading()
+	m.content = msg.content
+	if msg.err != nil {
+		m.content = fmt.Sprintf("The etcdctl health check failed:\n\n  %v\n", msg.err)
+	}
+	m.refreshViewport()
+}
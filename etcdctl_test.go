@@ -0,0 +1,136 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	testEndpointStatus = `[{"Endpoint":"https://10.0.0.1:2379","Status":{"header":{"member_id":1,"raft_term":4},"version":"3.5.9","dbSize":20971520,"dbSizeInUse":10485760,"leader":1,"raftTerm":4,"raftIndex":1200}},` +
+		`{"Endpoint":"https://10.0.0.2:2379","Status":{"header":{"member_id":2,"raft_term":4},"version":"3.5.9","dbSize":20971520,"dbSizeInUse":10485760,"leader":1,"raftTerm":4,"raftIndex":1200}}]`
+	testEndpointHealth = `[{"endpoint":"https://10.0.0.1:2379","health":true,"took":"2ms"},{"endpoint":"https://10.0.0.2:2379","health":true,"took":"3ms"},` +
+		`{"endpoint":"https://10.0.0.3:2379","health":false,"took":"5s","error":"context deadline exceeded"}]`
+)
+
+// fakeEtcdctl answers etcdctl commands in the given container once its argument contains certPath
+// Other certificate paths fail the way etcdctl does when a file isn't mounted
+func fakeEtcdctl(container, certPath string) etcdctlRunner {
+	return func(c string, command []string) (string, error) {
+		if c != container {
+			return "", errors.New(`command terminated with exit code 126: exec: "etcdctl": executable file not found in $PATH`)
+		}
+		joined := strings.Join(command, " ")
+		if !strings.Contains(joined, certPath) {
+			for _, arg := range command {
+				if path, ok := strings.CutPrefix(arg, "--cacert="); ok {
+					return "", fmt.Errorf("command terminated with exit code 1: Error: open %s: no such file or directory", path)
+				}
+			}
+			return "", errors.New("command terminated with exit code 1: context deadline exceeded")
+		}
+		if strings.Contains(joined, "endpoint status") {
+			return testEndpointStatus, errors.New("command terminated with exit code 1: Failed to get the status of endpoint https://10.0.0.3:2379")
+		}
+		return testEndpointHealth, errors.New("command terminated with exit code 1: unhealthy cluster")
+	}
+}
+
+func TestCheckQuorum(t *testing.T) {
+	// etcdctl lives in the second container, with the kubeadm certificates
+	report, err := checkQuorum(fakeEtcdctl("etcd", etcdctlTLSLayouts[2].cacert), []string{"backup-restore", "etcd"})
+	if err != nil {
+		t.Fatalf("checkQuorum() error = %v", err)
+	}
+	if len(report.statuses) != 2 || len(report.health) != 3 {
+		t.Fatalf("checkQuorum() = %+v, want the reachable statuses and every health entry despite the exit codes", report)
+	}
+
+	m, _ := newTestModel(nil)
+	content := m.renderQuorum(report, "etcd-main-0")
+	for _, want := range []string{
+		"2/3 endpoints healthy, quorum ok, 2 of 3 needed",
+		"https://10.0.0.1:2379  healthy (2ms)  yes     20.0Mi   10.0Mi  4          1200        3.5.9",
+		"https://10.0.0.2:2379  healthy (3ms)  no",
+		"https://10.0.0.3:2379  unhealthy      -",
+		"https://10.0.0.3:2379: context deadline exceeded",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("renderQuorum() missing %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestCheckQuorumFailures(t *testing.T) {
+	tests := []struct {
+		name string
+		run  etcdctlRunner
+		want string
+	}{
+		{
+			name: "etcdctl missing",
+			run:  fakeEtcdctl("other", ""),
+			want: "no container has etcdctl, the etcd image may be distroless",
+		},
+		{
+			name: "no known certificate",
+			run:  fakeEtcdctl("etcd", "/somewhere/else"),
+			want: "no client certificate at the etcd-druid, etcd-druid (legacy), kubeadm paths, and plaintext failed: etcd didn't answer in time",
+		},
+		{
+			name: "certificate rejected",
+			run: func(string, []string) (string, error) {
+				return "", errors.New("command terminated with exit code 1: x509: certificate signed by unknown authority")
+			},
+			want: "etcd rejected the client certificate",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := checkQuorum(tt.run, []string{"etcd"})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("checkQuorum() error = %v, want it to say %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuorumLost(t *testing.T) {
+	report := quorumReport{health: []endpointHealth{
+		{Endpoint: "https://10.0.0.1:2379", Health: true},
+		{Endpoint: "https://10.0.0.2:2379", Error: "connection refused"},
+		{Endpoint: "https://10.0.0.3:2379", Error: "connection refused"},
+	}}
+	m, _ := newTestModel(nil)
+	if content := m.renderQuorum(report, "etcd-main-0"); !strings.Contains(content, "QUORUM LOST, 2 of 3 needed") {
+		t.Errorf("renderQuorum() doesn't report the lost quorum:\n%s", content)
+	}
+}
+
+func TestQuorumViewWithoutExec(t *testing.T) {
+	pod := testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))
+	m, _ := newTestModel([]runtime.Object{pod})
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+
+	next, cmd := m.Update(keyMsg("Q"))
+	m = next.(Model)
+	if m.state != QuorumState || cmd == nil || len(m.activeOperations()) != 1 {
+		t.Fatalf("state = %v, operations = %v, want the etcdctl check running", m.state, m.activeOperations())
+	}
+	m = update(t, m, m.loadQuorum()())
+	if !strings.Contains(m.content, "The etcdctl health check failed") || m.loading != "" {
+		t.Errorf("content = %q, want the failure explained in place", m.content)
+	}
+
+	// Reading the health changes nothing, so read-only mode allows it
+	m.readOnly = true
+	m = update(t, m, keyMsg("esc"))
+	m = update(t, m, keyMsg("Q"))
+	if m.state != QuorumState {
+		t.Errorf("Q in read-only mode went to %v, want the health check", m.state)
+	}
+}
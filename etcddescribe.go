@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// describeEtcdResource renders the Etcd resource like kubectl describe, picking out the fields that matter for a health check
+// The object is unstructured, so every lookup tolerates missing fields and unexpected types
+func (m *Model) describeEtcdResource() (string, error) {
+	etcd, err := m.fetchEtcdResource()
+	if err != nil {
+		return "", err
+	}
+	obj := etcd.Object
+
+	var desc strings.Builder
+	desc.WriteString(fmt.Sprintf("Name: %s\n", etcd.GetName()))
+	desc.WriteString(fmt.Sprintf("Namespace: %s\n", etcd.GetNamespace()))
+	desc.WriteString(fmt.Sprintf("Created: %s\n", m.formatTimestamp(etcd.GetCreationTimestamp().Time)))
+	desc.WriteString(fmt.Sprintf("Generation: %d (observed %s)\n", etcd.GetGeneration(), describeField(obj, "status", "observedGeneration")))
+	describeOperationAnnotations(&desc, etcd.GetAnnotations())
+
+	desc.WriteString("\nSpec:\n")
+	desc.WriteString(fmt.Sprintf("  Replicas: %s\n", describeField(obj, "spec", "replicas")))
+	desc.WriteString(fmt.Sprintf("  Storage Capacity: %s\n", describeField(obj, "spec", "storageCapacity")))
+	desc.WriteString(fmt.Sprintf("  Storage Class: %s\n", describeField(obj, "spec", "storageClass")))
+	desc.WriteString(fmt.Sprintf("  Quota: %s\n", describeField(obj, "spec", "etcd", "quota")))
+	desc.WriteString(fmt.Sprintf("  Defragmentation Schedule: %s\n", describeField(obj, "spec", "etcd", "defragmentationSchedule")))
+
+	desc.WriteString("  TLS:\n")
+	desc.WriteString(fmt.Sprintf("    Client: %s\n", describeEnabled(obj, "spec", "etcd", "clientUrlTls")))
+	desc.WriteString(fmt.Sprintf("    Peer: %s\n", describeEnabled(obj, "spec", "etcd", "peerUrlTls")))
+	desc.WriteString(fmt.Sprintf("    Backup: %s\n", describeEnabled(obj, "spec", "backup", "tls")))
+
+	desc.WriteString("  Backup:\n")
+	if _, found, _ := unstructured.NestedFieldNoCopy(obj, "spec", "backup", "store"); !found {
+		desc.WriteString("    Store: <none>\n")
+	} else {
+		desc.WriteString(fmt.Sprintf("    Provider: %s\n", describeField(obj, "spec", "backup", "store", "provider")))
+		desc.WriteString(fmt.Sprintf("    Container: %s\n", describeField(obj, "spec", "backup", "store", "container")))
+		desc.WriteString(fmt.Sprintf("    Prefix: %s\n", describeField(obj, "spec", "backup", "store", "prefix")))
+	}
+	desc.WriteString(fmt.Sprintf("    Full Snapshot Schedule: %s\n", describeField(obj, "spec", "backup", "fullSnapshotSchedule")))
+	desc.WriteString(fmt.Sprintf("    Delta Snapshot Period: %s\n", describeField(obj, "spec", "backup", "deltaSnapshotPeriod")))
+	desc.WriteString(fmt.Sprintf("    Garbage Collection: %s every %s\n",
+		describeField(obj, "spec", "backup", "garbageCollectionPolicy"),
+		describeField(obj, "spec", "backup", "garbageCollectionPeriod")))
+
+	desc.WriteString("\nStatus:\n")
+	desc.WriteString(fmt.Sprintf("  Ready: %s\n", describeField(obj, "status", "ready")))
+	desc.WriteString(fmt.Sprintf("  Replicas: %s (ready %s)\n",
+		describeField(obj, "status", "replicas"), describeField(obj, "status", "readyReplicas")))
+
+	desc.WriteString("  Conditions:\n")
+	conditions := describeList(obj, "status", "conditions")
+	if len(conditions) == 0 {
+		desc.WriteString("    <none>\n")
+	}
+	for _, condition := range conditions {
+		line := fmt.Sprintf("    %s: %s", describeField(condition, "type"), describeField(condition, "status"))
+		if reason := describeField(condition, "reason"); reason != "<unset>" {
+			line += fmt.Sprintf(" (%s)", reason)
+		}
+		if message := describeField(condition, "message"); message != "<unset>" {
+			line += " - " + message
+		}
+		desc.WriteString(line + "\n")
+	}
+
+	if lastErrors := describeList(obj, "status", "lastErrors"); len(lastErrors) > 0 {
+		desc.WriteString("  Last Errors:\n")
+		for _, lastError := range lastErrors {
+			desc.WriteString(fmt.Sprintf("    %s: %s\n", describeField(lastError, "code"), describeField(lastError, "description")))
+		}
+	}
+
+	return desc.String(), nil
+}
+
+// describeField renders the value at path, or "<unset>" when it is missing or empty
+// Maps are flattened to sorted key=value pairs so unexpected nesting still prints on one line
+func describeField(obj map[string]interface{}, fields ...string) string {
+	value, found, err := unstructured.NestedFieldNoCopy(obj, fields...)
+	if err != nil || !found || value == nil {
+		return "<unset>"
+	}
+	switch value := value.(type) {
+	case string:
+		if value == "" {
+			return "<unset>"
+		}
+		return value
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		pairs := make([]string, len(keys))
+		for i, k := range keys {
+			pairs[i] = fmt.Sprintf("%s=%s", k, describeField(value, k))
+		}
+		return strings.Join(pairs, ", ")
+	}
+	return fmt.Sprint(value)
+}
+
+// describeEnabled reports whether an optional block such as a TLS config is present
+func describeEnabled(obj map[string]interface{}, fields ...string) string {
+	if value, found, _ := unstructured.NestedFieldNoCopy(obj, fields...); found && value != nil {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+// describeList returns the objects in the list at path, skipping entries that aren't objects
+func describeList(obj map[string]interface{}, fields ...string) []map[string]interface{} {
+	value, found, err := unstructured.NestedFieldNoCopy(obj, fields...)
+	if err != nil || !found {
+		return nil
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	var objects []map[string]interface{}
+	for _, item := range items {
+		if object, ok := item.(map[string]interface{}); ok {
+			objects = append(objects, object)
+		}
+	}
+	return objects
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestDescribeEtcdResource(t *testing.T) {
+	etcd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas":        int64(3),
+			"storageCapacity": "25Gi",
+			"etcd": map[string]interface{}{
+				"clientUrlTls": map[string]interface{}{"tlsCASecretRef": map[string]interface{}{"name": "ca"}},
+				"quota":        "8Gi",
+			},
+			"backup": map[string]interface{}{
+				"store":                map[string]interface{}{"provider": "S3", "container": "backups", "prefix": "shoot--foo"},
+				"fullSnapshotSchedule": "0 */24 * * *",
+			},
+		},
+		"status": map[string]interface{}{
+			"ready":         false,
+			"replicas":      int64(3),
+			"readyReplicas": int64(2),
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "AllMembersReady", "status": "False", "reason": "NotAllMembersReady", "message": "1 member not ready"},
+				// Unexpected shapes are skipped rather than breaking the describe
+				"garbage",
+			},
+		},
+	}}
+	etcd.SetAPIVersion("druid.gardener.cloud/v1alpha1")
+	etcd.SetKind("Etcd")
+	etcd.SetNamespace(testNamespace)
+	etcd.SetName(testEtcdName)
+
+	m, _ := newTestModel(nil, etcd)
+	got, err := m.describeEtcdResource()
+	if err != nil {
+		t.Fatalf("describeEtcdResource() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"Name: " + testEtcdName,
+		"  Replicas: 3\n",
+		"Storage Capacity: 25Gi",
+		"Storage Class: <unset>",
+		"Quota: 8Gi",
+		"Client: enabled",
+		"Peer: disabled",
+		"Provider: S3",
+		"Full Snapshot Schedule: 0 */24 * * *",
+		"Ready: false",
+		"Replicas: 3 (ready 2)",
+		"AllMembersReady: False (NotAllMembersReady) - 1 member not ready",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("describeEtcdResource() missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestDescribeField(t *testing.T) {
+	obj := map[string]interface{}{
+		"scalar": "value",
+		"empty":  "",
+		"nested": map[string]interface{}{"b": int64(2), "a": "x"},
+	}
+	tests := map[string]string{
+		"scalar":  "value",
+		"empty":   "<unset>",
+		"missing": "<unset>",
+		"nested":  "a=x, b=2",
+	}
+	for field, want := range tests {
+		if got := describeField(obj, field); got != want {
+			t.Errorf("describeField(%s) = %q, want %q", field, got, want)
+		}
+	}
+	// Walking through a non-object must not panic
+	if got := describeField(obj, "scalar", "deeper"); got != "<unset>" {
+		t.Errorf("describeField(scalar.deeper) = %q, want <unset>", got)
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// fetchEtcds lists the Etcd resources of the namespace sorted by name, for picking one when no name was given
+// With --namespace-label-selector they come from every selected namespace instead
+func (m *Model) fetchEtcds() ([]unstructured.Unstructured, error) {
+	if m.namespaceSelector != "" {
+		return m.fetchTenantEtcds()
+	}
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	etcdList, err := m.dynamicClient.Resource(etcdGVR).Namespace(m.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Etcd resources in %s: %w", m.namespace, err)
+	}
+	etcds := etcdList.Items
+	sort.Slice(etcds, func(i, j int) bool { return etcds[i].GetName() < etcds[j].GetName() })
+	return etcds, nil
+}
+
+// fetchEtcdNames names the Etcd resources to pick from, as namespace/name with --namespace-label-selector
+func (m *Model) fetchEtcdNames() ([]string, error) {
+	etcds, err := m.fetchEtcds()
+	if err != nil {
+		return nil, err
+	}
+	return m.etcdNames(etcds), nil
+}
+
+// etcdNames names listed Etcd resources the way the name prompt takes them
+func (m *Model) etcdNames(etcds []unstructured.Unstructured) []string {
+	names := make([]string, len(etcds))
+	for i, etcd := range etcds {
+		names[i] = etcd.GetName()
+		if m.namespaceSelector != "" {
+			names[i] = etcd.GetNamespace() + "/" + etcd.GetName()
+		}
+	}
+	return names
+}
+
+// etcdNamesMsg carries the Etcd resources to pick from, along with their printer columns laid out as a table
+// denied is set when RBAC allows getting a named Etcd but not listing them, so the name has to be typed
+type etcdNamesMsg struct {
+	names  []string
+	table  string
+	denied bool
+}
+
+// loadEtcdNames is a command that lists the Etcd resources asynchronously
+// A forbidden list isn't an error: the Etcd can still be named explicitly
+func (m *Model) loadEtcdNames() tea.Cmd {
+	return func() tea.Msg {
+		etcds, err := retryFetch(m.fetchEtcds)
+		if apierrors.IsForbidden(err) {
+			return etcdNamesMsg{denied: true}
+		}
+		if err != nil {
+			return errMsg{err}
+		}
+		table := m.renderEtcdTable(etcds, m.fetchPrinterColumns(), m.namespaceSelector != "")
+		return etcdNamesMsg{names: m.etcdNames(etcds), table: table}
+	}
+}
+
+// showEtcdNames explains what can be picked and asks for the Etcd name
+// A single Etcd is picked right away, there is nothing to choose between
+func (m *Model) showEtcdNames(msg etcdNamesMsg) tea.Cmd {
+	if len(msg.names) == 1 {
+		return m.pickEtcd(msg.names[0])
+	}
+
+	var suggestion string
+	switch {
+	case msg.denied:
+		m.content = fmt.Sprintf("Listing Etcd resources in %s was denied, most likely by RBAC.\n"+
+			"Getting a named Etcd may still be allowed, so type its name, or pass it as the second argument.\n", m.etcdScope())
+	case len(msg.names) == 0:
+		m.content = fmt.Sprintf("No Etcd resources found in %s.\n", m.etcdScope())
+	default:
+		m.content = fmt.Sprintf("Etcd resources in %s:\n\n%s", m.etcdScope(), msg.table)
+		suggestion = msg.names[0]
+	}
+	m.refreshViewport()
+	return m.promptEtcdName(suggestion)
+}
+
+// promptEtcdName asks for the name of the Etcd to view
+func (m *Model) promptEtcdName(value string) tea.Cmd {
+	label := "etcd name"
+	if m.namespaceSelector != "" {
+		label = "etcd namespace/name"
+	}
+	return m.openPrompt(label, value, func(m *Model, value string) tea.Cmd {
+		return m.pickEtcd(strings.TrimSpace(value))
+	})
+}
+
+// pickEtcd switches to the Etcd picked on the selection screen, which names its namespace with --namespace-label-selector
+func (m *Model) pickEtcd(name string) tea.Cmd {
+	if m.namespaceSelector != "" && name != "" {
+		return m.selectTenantEtcd(name)
+	}
+	return m.selectEtcd(name)
+}
+
+// selectEtcd switches to the pod list of the named Etcd
+func (m *Model) selectEtcd(name string) tea.Cmd {
+	if name == "" {
+		return m.setStatus("no etcd name given, press enter to type one")
+	}
+	m.etcdName = name
+	m.content = ""
+	m.state = ListState
+	m.layout()
+	return tea.Batch(m.list.StartSpinner(), m.loadPods(), m.loadBackupSummary())
+}
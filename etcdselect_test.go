@@ -0,0 +1,97 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// testEtcd builds an Etcd resource in the test namespace
+func testEtcd(name string) *unstructured.Unstructured {
+	etcd := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	etcd.SetAPIVersion("druid.gardener.cloud/v1alpha1")
+	etcd.SetKind("Etcd")
+	etcd.SetNamespace(testNamespace)
+	etcd.SetName(name)
+	return etcd
+}
+
+// newEtcdSelectModel builds a Model started with only the namespace, like etcd-pod-viewer <namespace>
+func newEtcdSelectModel(t *testing.T, etcdObjects ...runtime.Object) Model {
+	t.Helper()
+	m, _ := newTestModel(nil, etcdObjects...)
+	m.etcdName = ""
+	m.state = EtcdSelectState
+	return update(t, m, tea.WindowSizeMsg{Width: 80, Height: 40})
+}
+
+func TestFetchEtcdNames(t *testing.T) {
+	m := newEtcdSelectModel(t, testEtcd("etcd-main"), testEtcd("etcd-events"))
+	names, err := m.fetchEtcdNames()
+	if err != nil {
+		t.Fatalf("fetchEtcdNames() error = %v", err)
+	}
+	if got := strings.Join(names, ","); got != "etcd-events,etcd-main" {
+		t.Errorf("fetchEtcdNames() = %s, want both sorted", got)
+	}
+}
+
+func TestEtcdSelectPicksTheOnlyEtcd(t *testing.T) {
+	m := newEtcdSelectModel(t, testEtcd("etcd-main"))
+	m = update(t, m, m.Init()())
+	if m.state != ListState || m.etcdName != "etcd-main" {
+		t.Errorf("state = %v with etcd %q, want the pod list of etcd-main", m.state, m.etcdName)
+	}
+}
+
+func TestEtcdSelectPromptsBetweenSeveral(t *testing.T) {
+	m := newEtcdSelectModel(t, testEtcd("etcd-main"), testEtcd("etcd-events"))
+	m = update(t, m, m.Init()())
+	if m.state != EtcdSelectState || m.prompt == nil {
+		t.Fatalf("state = %v, want the name prompt", m.state)
+	}
+	if !strings.Contains(m.content, "\n  etcd-events  -") || !strings.Contains(m.content, "\n  etcd-main    -") || m.prompt.input.Value() != "etcd-events" {
+		t.Errorf("content = %q with prompt %q, want both in the table and the first suggested", m.content, m.prompt.input.Value())
+	}
+
+	m.prompt.input.SetValue("etcd-main")
+	m = update(t, m, keyMsg("enter"))
+	if m.state != ListState || m.etcdName != "etcd-main" {
+		t.Errorf("state = %v with etcd %q, want the pod list of etcd-main", m.state, m.etcdName)
+	}
+}
+
+func TestEtcdSelectWhenListingIsDenied(t *testing.T) {
+	m := newEtcdSelectModel(t)
+	m.dynamicClient.(*dynamicfake.FakeDynamicClient).PrependReactor("list", "etcds",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewForbidden(etcdGVR.GroupResource(), "", nil)
+		})
+
+	msg := m.Init()()
+	if names, ok := msg.(etcdNamesMsg); !ok || !names.denied {
+		t.Fatalf("Init() = %#v, want the listing reported denied", msg)
+	}
+	m = update(t, m, msg)
+	if m.err != nil || m.prompt == nil || !strings.Contains(m.content, "was denied") {
+		t.Fatalf("err = %v, content = %q, want a note and the name prompt instead of an error", m.err, m.content)
+	}
+
+	// An empty name keeps asking
+	m = update(t, m, keyMsg("enter"))
+	if m.state != EtcdSelectState || !strings.Contains(m.status, "no etcd name given") {
+		t.Errorf("state = %v with status %q, want to stay and ask again", m.state, m.status)
+	}
+	m = update(t, m, keyMsg("enter"))
+	m = update(t, m, keyMsg("etcd-main"))
+	m = update(t, m, keyMsg("enter"))
+	if m.state != ListState || m.etcdName != "etcd-main" {
+		t.Errorf("state = %v with etcd %q, want the pod list of the typed etcd", m.state, m.etcdName)
+	}
+}
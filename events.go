@@ -0,0 +1,455 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// eventTime returns the most recent time an event was observed
+// Different event sources fill in different timestamp fields
+func eventTime(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+// etcdRelatedObjects returns "Kind/name" keys for everything that makes up our etcd cluster:
+// the Etcd CR, its StatefulSet, the member pods and the PVCs they mount
+func (m *Model) etcdRelatedObjects() (map[string]bool, error) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	related := map[string]bool{
+		"Etcd/" + m.etcdName:        true,
+		"StatefulSet/" + m.etcdName: true,
+	}
+
+	podList, err := m.kubeClient.CoreV1().Pods(m.namespace).List(
+		ctx, metav1.ListOptions{LabelSelector: m.podLabelSelector()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list etcd pods: %w", err)
+	}
+	for _, pod := range podList.Items {
+		related["Pod/"+pod.Name] = true
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil {
+				related["PersistentVolumeClaim/"+volume.PersistentVolumeClaim.ClaimName] = true
+			}
+		}
+	}
+	return related, nil
+}
+
+// etcdEvents are the events of the objects related to the Etcd, as listed for the events view
+// resourceVersion is that of the list, which the watch continues from
+type etcdEvents struct {
+	events          []corev1.Event
+	related         map[string]bool
+	resourceVersion string
+}
+
+// listEtcdEvents lists the events of all objects related to the Etcd resource, oldest first
+func (m *Model) listEtcdEvents() (etcdEvents, error) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	related, err := m.etcdRelatedObjects()
+	if err != nil {
+		return etcdEvents{}, err
+	}
+
+	eventList, err := m.kubeClient.CoreV1().Events(m.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return etcdEvents{}, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	var events []corev1.Event
+	for _, event := range eventList.Items {
+		if related[eventObject(&event)] {
+			events = append(events, event)
+		}
+	}
+	sortEvents(events)
+	return etcdEvents{events: events, related: related, resourceVersion: eventList.ResourceVersion}, nil
+}
+
+// sortEvents orders events oldest first, so a reconcile can be watched unfolding from top to bottom
+func sortEvents(events []corev1.Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(events[i]).Before(eventTime(events[j]))
+	})
+}
+
+// fetchEtcdEvents renders recent events for all objects related to the Etcd resource, newest last
+func (m *Model) fetchEtcdEvents() (string, error) {
+	listed, err := m.listEtcdEvents()
+	if err != nil {
+		return "", err
+	}
+	return m.renderEvents(listed.events, nil), nil
+}
+
+// eventCount returns how many times an event fired
+// The core API counts repeats in Count, the events.k8s.io API in Series; either may be unset for a single occurrence
+func eventCount(event corev1.Event) int32 {
+	count := event.Count
+	if event.Series != nil && event.Series.Count > count {
+		count = event.Series.Count
+	}
+	return max(count, 1)
+}
+
+// eventGroup is the events of the same type, reason and message, shown as one row
+// event is the one seen last, objects the "kind/name" of everything the group is about, in the order they fired
+type eventGroup struct {
+	event   corev1.Event
+	objects []string
+	count   int32
+	fresh   bool
+}
+
+// aggregateEvents folds repeated events into groups, so a flood of identical scheduling failures across members reads as one row
+// events come oldest first and so do the groups, by when they were last seen; byCount puts the most repeated first instead
+func aggregateEvents(events []corev1.Event, fresh func(corev1.Event) bool, byCount bool) []eventGroup {
+	var groups []eventGroup
+	index := map[string]int{}
+	for _, event := range events {
+		key := event.Type + "\x00" + event.Reason + "\x00" + strings.TrimSpace(event.Message)
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, eventGroup{})
+		}
+		group := &groups[i]
+		if !ok || !eventTime(event).Before(eventTime(group.event)) {
+			group.event = event
+		}
+		object := strings.ToLower(event.InvolvedObject.Kind) + "/" + event.InvolvedObject.Name
+		if !slices.Contains(group.objects, object) {
+			group.objects = append(group.objects, object)
+		}
+		group.count += eventCount(event)
+		group.fresh = group.fresh || (fresh != nil && fresh(event))
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if byCount && groups[i].count != groups[j].count {
+			return groups[i].count > groups[j].count
+		}
+		return eventTime(groups[i].event).Before(eventTime(groups[j].event))
+	})
+	return groups
+}
+
+// eventObjects names what a group is about, e.g. "pod/etcd-main-0 +2" when it repeats on other objects too
+func (g eventGroup) eventObjects() string {
+	if len(g.objects) == 1 {
+		return g.objects[0]
+	}
+	return fmt.Sprintf("%s +%d", g.objects[0], len(g.objects)-1)
+}
+
+// renderEvents lays out events as a table of their groups, highlighting those fresh reports as just arrived
+func (m *Model) renderEvents(events []corev1.Event, fresh func(corev1.Event) bool) string {
+	if len(events) == 0 {
+		return "No recent events for this etcd\n"
+	}
+	groups := aggregateEvents(events, fresh, m.eventsByCount)
+
+	// Align columns first, then color whole rows so escape codes don't skew the widths
+	var table strings.Builder
+	w := tabwriter.NewWriter(&table, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LAST SEEN\tTYPE\tREASON\tOBJECT\tCOUNT\tMESSAGE")
+	for _, group := range groups {
+		event := group.event
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n",
+			m.formatTimestamp(eventTime(event)), event.Type, event.Reason,
+			group.eventObjects(), group.count, strings.TrimSpace(event.Message))
+	}
+	w.Flush()
+
+	lines := strings.Split(strings.TrimSuffix(table.String(), "\n"), "\n")
+	for i, group := range groups {
+		style, styled := lipgloss.NewStyle(), false
+		if group.event.Type == corev1.EventTypeWarning {
+			style, styled = m.theme.eventWarning, true
+		}
+		if group.fresh {
+			style, styled = style.Inherit(m.theme.eventNew), true
+		}
+		if styled {
+			lines[i+1] = style.Render(lines[i+1])
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// eventHighlightDuration is how long an event that streamed in stays highlighted
+const eventHighlightDuration = 5 * time.Second
+
+// eventsWatch streams the events of the Etcd into the events view, see loadEventsWatch
+// resourceVersion is the last one seen, from a bookmark or an event, so a reconnect misses nothing
+// pending are events of objects that may have been created since the list, held while relating re-lists them
+type eventsWatch struct {
+	id              int
+	watcher         watch.Interface
+	resourceVersion string
+	pending         []watch.Event
+	relating        bool
+}
+
+// eventsWatchStartedMsg carries a watch opened for the events view
+type eventsWatchStartedMsg struct {
+	id      int
+	watcher watch.Interface
+	err     error
+}
+
+// eventsWatchEventMsg carries one event of the watch; closed is set once the API server ended it
+type eventsWatchEventMsg struct {
+	id     int
+	event  watch.Event
+	closed bool
+}
+
+// eventsHighlightMsg re-renders the events once highlights may have expired
+type eventsHighlightMsg struct{ id int }
+
+// eventsRelatedMsg carries the objects related to the Etcd, re-listed for events of objects unknown to the list
+type eventsRelatedMsg struct {
+	id      int
+	related map[string]bool
+	err     error
+}
+
+// loadEventsWatch is a command that opens a watch on the events from resourceVersion
+// Bookmarks keep the version current while quiet, so reopening after the API server ended the watch doesn't replay or miss events
+func (m *Model) loadEventsWatch(id int, resourceVersion string) tea.Cmd {
+	namespace := m.namespace
+	return func() tea.Msg {
+		// The watch outlives the request timeout, it ends when stopped
+		watcher, err := m.kubeClient.CoreV1().Events(namespace).Watch(context.Background(), metav1.ListOptions{
+			ResourceVersion:     resourceVersion,
+			AllowWatchBookmarks: true,
+		})
+		if err != nil {
+			return eventsWatchStartedMsg{id: id, err: fmt.Errorf("failed to watch events: %w", err)}
+		}
+		return eventsWatchStartedMsg{id: id, watcher: watcher}
+	}
+}
+
+// nextEventsEvent is a command that waits for the next event of the events watch
+func nextEventsEvent(id int, watcher watch.Interface) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-watcher.ResultChan()
+		return eventsWatchEventMsg{id: id, event: event, closed: !ok}
+	}
+}
+
+// setEvents shows listed events and starts streaming the ones after them
+func (m *Model) setEvents(listed etcdEvents) tea.Cmd {
+	m.stopEventsWatch()
+	m.events = listed.events
+	m.eventsRelated = listed.related
+	m.freshEvents = map[types.UID]time.Time{}
+	m.renderEventsView()
+	// Keep the newest events in view as they arrive
+	m.viewport.GotoBottom()
+	m.eventsWatchID++
+	m.eventsWatch = &eventsWatch{id: m.eventsWatchID, resourceVersion: listed.resourceVersion}
+	return m.loadEventsWatch(m.eventsWatchID, listed.resourceVersion)
+}
+
+// stopEventsWatch ends the events watch, e.g. when leaving the events view
+func (m *Model) stopEventsWatch() {
+	if m.eventsWatch != nil && m.eventsWatch.watcher != nil {
+		m.eventsWatch.watcher.Stop()
+	}
+	m.eventsWatch = nil
+}
+
+// setEventsWatch keeps the opened watch and waits for its first event; a failed one is reported and the view stays as listed
+func (m *Model) setEventsWatch(msg eventsWatchStartedMsg) tea.Cmd {
+	if m.eventsWatch == nil || msg.id != m.eventsWatch.id || m.state != EventsState {
+		if msg.watcher != nil {
+			msg.watcher.Stop()
+		}
+		return nil
+	}
+	if msg.err != nil {
+		m.eventsWatch = nil
+		return m.setStatus(msg.err.Error() + ", press r to list them again")
+	}
+	m.eventsWatch.watcher = msg.watcher
+	return nextEventsEvent(msg.id, msg.watcher)
+}
+
+// applyEventsEvent adds, updates or removes the event the watch reported
+// An expired resourceVersion, or any other watch error, starts over with a fresh list
+func (m *Model) applyEventsEvent(msg eventsWatchEventMsg) tea.Cmd {
+	w := m.eventsWatch
+	if w == nil || msg.id != w.id {
+		return nil
+	}
+	if m.state != EventsState {
+		m.stopEventsWatch()
+		return nil
+	}
+	switch {
+	case msg.closed:
+		w.watcher = nil
+		return m.loadEventsWatch(w.id, w.resourceVersion)
+	case msg.event.Type == watch.Error:
+		m.stopEventsWatch()
+		return m.loadEvents()
+	}
+	if object, ok := msg.event.Object.(metav1.Object); ok && object.GetResourceVersion() != "" {
+		w.resourceVersion = object.GetResourceVersion()
+	}
+	event, ok := msg.event.Object.(*corev1.Event)
+	if !ok || msg.event.Type == watch.Bookmark {
+		return nextEventsEvent(w.id, w.watcher)
+	}
+	if !m.eventsRelated[eventObject(event)] {
+		// A member recreated or added since the list, or the claim of a new one, isn't known yet
+		if !m.mayBeRelated(event) {
+			return nextEventsEvent(w.id, w.watcher)
+		}
+		w.pending = append(w.pending, msg.event)
+		if w.relating {
+			return nextEventsEvent(w.id, w.watcher)
+		}
+		w.relating = true
+		return tea.Batch(nextEventsEvent(w.id, w.watcher), m.loadEventsRelated(w.id))
+	}
+
+	m.storeEvent(msg.event.Type, event)
+	m.showStoredEvents()
+	return tea.Batch(nextEventsEvent(w.id, w.watcher), m.scheduleEventHighlights(w.id))
+}
+
+// eventObject returns the "Kind/name" key of the object an event is about, as in eventsRelated
+func eventObject(event *corev1.Event) string {
+	return event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name
+}
+
+// mayBeRelated reports whether an event of an object missing from eventsRelated could be about a member created since
+// StatefulSet pods are named after the Etcd with an ordinal, their PVCs after the claim template and the pod
+func (m *Model) mayBeRelated(event *corev1.Event) bool {
+	name := event.InvolvedObject.Name
+	switch event.InvolvedObject.Kind {
+	case "Pod":
+		return strings.HasPrefix(name, m.etcdName+"-")
+	case "PersistentVolumeClaim":
+		return strings.Contains(name, m.etcdName+"-")
+	}
+	return false
+}
+
+// loadEventsRelated is a command that lists the objects related to the Etcd again
+func (m *Model) loadEventsRelated(id int) tea.Cmd {
+	return func() tea.Msg {
+		related, err := m.etcdRelatedObjects()
+		return eventsRelatedMsg{id: id, related: related, err: err}
+	}
+}
+
+// applyEventsRelated adds the held events whose object turned out to be related; the others are dropped
+// A failed re-list keeps the objects known so far
+func (m *Model) applyEventsRelated(msg eventsRelatedMsg) tea.Cmd {
+	w := m.eventsWatch
+	if w == nil || msg.id != w.id || m.state != EventsState {
+		return nil
+	}
+	pending := w.pending
+	w.pending, w.relating = nil, false
+	if msg.err == nil {
+		m.eventsRelated = msg.related
+	}
+	stored := false
+	for _, e := range pending {
+		event := e.Object.(*corev1.Event)
+		if m.eventsRelated[eventObject(event)] {
+			m.storeEvent(e.Type, event)
+			stored = true
+		}
+	}
+	if !stored {
+		return nil
+	}
+	m.showStoredEvents()
+	return m.scheduleEventHighlights(w.id)
+}
+
+// storeEvent adds, updates or removes a watched event of a related object
+func (m *Model) storeEvent(eventType watch.EventType, event *corev1.Event) {
+	i := slices.IndexFunc(m.events, func(e corev1.Event) bool { return e.UID == event.UID })
+	switch {
+	case eventType == watch.Deleted:
+		if i >= 0 {
+			m.events = slices.Delete(slices.Clone(m.events), i, i+1)
+		}
+	case i >= 0:
+		// A repeated event comes back with a higher count and moves down to when it was last seen
+		m.events = slices.Clone(m.events)
+		m.events[i] = *event
+		m.freshEvents[event.UID] = time.Now()
+	default:
+		m.events = append(slices.Clone(m.events), *event)
+		m.freshEvents[event.UID] = time.Now()
+	}
+}
+
+// showStoredEvents re-renders the events after storeEvent, keeping the newest in view if they were
+func (m *Model) showStoredEvents() {
+	sortEvents(m.events)
+	atBottom := m.viewport.AtBottom()
+	m.renderEventsView()
+	if atBottom {
+		m.viewport.GotoBottom()
+	}
+}
+
+// scheduleEventHighlights is a command that re-renders the events once the new ones are no longer highlighted
+func (m *Model) scheduleEventHighlights(id int) tea.Cmd {
+	return tea.Tick(eventHighlightDuration, func(time.Time) tea.Msg { return eventsHighlightMsg{id} })
+}
+
+// expireEventHighlights drops the highlights that outlasted eventHighlightDuration
+func (m *Model) expireEventHighlights(msg eventsHighlightMsg) {
+	if m.eventsWatch == nil || msg.id != m.eventsWatch.id || m.state != EventsState {
+		return
+	}
+	for uid, arrived := range m.freshEvents {
+		if time.Since(arrived) >= eventHighlightDuration {
+			delete(m.freshEvents, uid)
+		}
+	}
+	m.renderEventsView()
+}
+
+// renderEventsView renders the events into the viewport, keeping the scroll position
+func (m *Model) renderEventsView() {
+	m.content = m.renderEvents(m.events, func(event corev1.Event) bool {
+		_, ok := m.freshEvents[event.UID]
+		return ok
+	})
+	m.refreshViewport()
+}
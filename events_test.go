@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func testEvent(name, kind, object, eventType, reason string, age time.Duration) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: testNamespace, UID: types.UID(name)},
+		InvolvedObject: corev1.ObjectReference{Kind: kind, Name: object, Namespace: testNamespace},
+		Type:           eventType,
+		Reason:         reason,
+		Message:        reason + " happened",
+		LastTimestamp:  metav1.NewTime(time.Now().Add(-age)),
+	}
+}
+
+func TestFetchEtcdEvents(t *testing.T) {
+	pod := testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))
+	pod.Spec.Volumes = []corev1.Volume{{
+		Name: "etcd-main",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "etcd-main-etcd-main-0"},
+		},
+	}}
+
+	m, _ := newTestModel([]runtime.Object{
+		pod,
+		testEvent("e1", "Pod", "etcd-main-0", corev1.EventTypeNormal, "Started", time.Minute),
+		testEvent("e2", "StatefulSet", testEtcdName, corev1.EventTypeNormal, "SuccessfulCreate", time.Hour),
+		testEvent("e3", "PersistentVolumeClaim", "etcd-main-etcd-main-0", corev1.EventTypeWarning, "ProvisioningFailed", 2*time.Minute),
+		testEvent("e4", "Etcd", testEtcdName, corev1.EventTypeNormal, "Reconciled", 30*time.Second),
+		testEvent("e5", "Pod", "unrelated", corev1.EventTypeWarning, "BackOff", time.Second),
+	})
+
+	content, err := m.fetchEtcdEvents()
+	if err != nil {
+		t.Fatalf("fetchEtcdEvents() error = %v", err)
+	}
+	if strings.Contains(content, "BackOff") {
+		t.Errorf("fetchEtcdEvents() included an unrelated event:\n%s", content)
+	}
+
+	// Oldest first so the latest activity sits at the bottom
+	wantOrder := []string{"SuccessfulCreate", "ProvisioningFailed", "Started", "Reconciled"}
+	last := -1
+	for _, reason := range wantOrder {
+		i := strings.Index(content, reason)
+		if i < 0 {
+			t.Fatalf("fetchEtcdEvents() missing %s:\n%s", reason, content)
+		}
+		if i < last {
+			t.Errorf("fetchEtcdEvents() has %s out of order:\n%s", reason, content)
+		}
+		last = i
+	}
+}
+
+func TestFetchEtcdEventsEmpty(t *testing.T) {
+	m, _ := newTestModel(nil)
+	content, err := m.fetchEtcdEvents()
+	if err != nil {
+		t.Fatalf("fetchEtcdEvents() error = %v", err)
+	}
+	if !strings.Contains(content, "No recent events") {
+		t.Errorf("fetchEtcdEvents() = %q, want the empty notice", content)
+	}
+}
+
+func TestEventTimesToggle(t *testing.T) {
+	m, _ := newTestModel([]runtime.Object{
+		testEvent("e1", "StatefulSet", testEtcdName, corev1.EventTypeNormal, "SuccessfulCreate", time.Hour),
+	})
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("v"))
+
+	content, err := m.fetchEtcdEvents()
+	if err != nil {
+		t.Fatalf("fetchEtcdEvents() error = %v", err)
+	}
+	if !strings.Contains(content, "1h0m0s ago") {
+		t.Errorf("fetchEtcdEvents() = %q, want the relative time", content)
+	}
+
+	next, cmd := m.Update(keyMsg("t"))
+	m = next.(Model)
+	if !m.absoluteTimes || cmd == nil {
+		t.Fatalf("t in the events view: absoluteTimes = %v, want them on and the events reloading", m.absoluteTimes)
+	}
+	content, _ = m.fetchEtcdEvents()
+	if strings.Contains(content, " ago") || !strings.Contains(content, time.Now().Add(-time.Hour).Format("2006-01-02")) {
+		t.Errorf("fetchEtcdEvents() with absolute times = %q, want RFC3339", content)
+	}
+}
+
+func TestEventsWatch(t *testing.T) {
+	m, client := newTestModel([]runtime.Object{
+		testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd")),
+		testEvent("e1", "Pod", "etcd-main-0", corev1.EventTypeNormal, "Started", time.Minute),
+	})
+	var watchedFrom []string
+	client.PrependWatchReactor("events", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		watchedFrom = append(watchedFrom, action.(k8stesting.WatchActionImpl).WatchRestrictions.ResourceVersion)
+		return false, nil, nil
+	})
+	m = update(t, m, tea.WindowSizeMsg{Width: 200, Height: 30})
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("v"))
+
+	// The list comes first, then the watch continuing from it
+	next, cmd := m.Update(m.loadEvents()())
+	m = next.(Model)
+	next, cmd = m.Update(cmd())
+	m = next.(Model)
+	if m.eventsWatch == nil || cmd == nil || !strings.Contains(m.View(), "Events: etcd-main (live)") {
+		t.Fatalf("events view isn't watching:\n%s", m.View())
+	}
+
+	// A new event of a related object streams in highlighted, one of another object is left out
+	ctx := context.Background()
+	unrelated := testEvent("e2", "Pod", "unrelated", corev1.EventTypeWarning, "BackOff", 0)
+	if _, err := client.CoreV1().Events(testNamespace).Create(ctx, unrelated, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+	related := testEvent("e3", "StatefulSet", testEtcdName, corev1.EventTypeWarning, "FailedCreate", 0)
+	if _, err := client.CoreV1().Events(testNamespace).Create(ctx, related, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+	waitEvent := cmd
+	next, cmd = m.Update(waitEvent())
+	m = next.(Model)
+	if strings.Contains(m.content, "BackOff") || len(m.events) != 1 {
+		t.Errorf("an unrelated event streamed in:\n%s", m.content)
+	}
+	next, cmd = m.Update(cmd())
+	m = next.(Model)
+	if len(m.events) != 2 || m.events[1].Reason != "FailedCreate" || !strings.Contains(m.content, "FailedCreate happened") {
+		t.Fatalf("events = %v, want FailedCreate appended", m.events)
+	}
+	if _, ok := m.freshEvents["e3"]; !ok {
+		t.Errorf("the new event isn't highlighted")
+	}
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok || len(batch) != 2 {
+		t.Fatalf("an event didn't wait for the next one and schedule the highlight to end")
+	}
+
+	// The highlight expires
+	m.freshEvents["e3"] = time.Now().Add(-eventHighlightDuration)
+	m = update(t, m, eventsHighlightMsg{m.eventsWatch.id})
+	if len(m.freshEvents) != 0 {
+		t.Errorf("highlight still shown after %s", eventHighlightDuration)
+	}
+
+	// A bookmark moves the version on, which the watch reopens from once the API server ends it
+	id := m.eventsWatch.id
+	m = update(t, m, eventsWatchEventMsg{id: id, event: watch.Event{Type: watch.Bookmark, Object: &corev1.Event{ObjectMeta: metav1.ObjectMeta{ResourceVersion: "42"}}}})
+	next, cmd = m.Update(eventsWatchEventMsg{id: id, closed: true})
+	m = next.(Model)
+	if cmd == nil {
+		t.Fatal("a closed watch wasn't reopened")
+	}
+	m = update(t, m, cmd())
+	if got := watchedFrom[len(watchedFrom)-1]; got != "42" {
+		t.Errorf("watch reopened from resourceVersion %q, want the bookmark's 42", got)
+	}
+
+	m = update(t, m, keyMsg("esc"))
+	if m.eventsWatch != nil {
+		t.Errorf("leaving the events view kept the watch")
+	}
+}
+
+func TestEventsWatchNewMember(t *testing.T) {
+	m, client := newTestModel([]runtime.Object{testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))})
+	m = update(t, m, tea.WindowSizeMsg{Width: 200, Height: 30})
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("v"))
+	m = update(t, m, m.loadEvents()())
+	id := m.eventsWatch.id
+
+	// The member scaled up after the list isn't known until the related objects are listed again
+	if _, err := client.CoreV1().Pods(testNamespace).Create(context.Background(), testPod("etcd-main-1", corev1.PodPending), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create pod: %v", err)
+	}
+	scheduled := testEvent("e1", "Pod", "etcd-main-1", corev1.EventTypeNormal, "Scheduled", 0)
+	gone := testEvent("e2", "Pod", "etcd-main-2", corev1.EventTypeNormal, "Killing", 0)
+	other := testEvent("e3", "Pod", "kube-apiserver-0", corev1.EventTypeWarning, "BackOff", 0)
+	next, cmd := m.Update(eventsWatchEventMsg{id: id, event: watch.Event{Type: watch.Added, Object: scheduled}})
+	m = next.(Model)
+	if !m.eventsWatch.relating || cmd == nil || len(m.events) != 0 {
+		t.Fatalf("an event of an unknown member: relating %v, events %v, want them listed again first", m.eventsWatch.relating, m.events)
+	}
+	m = update(t, m, eventsWatchEventMsg{id: id, event: watch.Event{Type: watch.Added, Object: gone}})
+	m = update(t, m, eventsWatchEventMsg{id: id, event: watch.Event{Type: watch.Added, Object: other}})
+	if len(m.eventsWatch.pending) != 2 {
+		t.Errorf("pending = %v, want the events of both possible members and not the other pod", m.eventsWatch.pending)
+	}
+
+	m = update(t, m, m.loadEventsRelated(id)())
+	if len(m.events) != 1 || m.events[0].Reason != "Scheduled" || m.eventsWatch.relating || len(m.eventsWatch.pending) != 0 {
+		t.Errorf("events = %v after listing again, want only the new member's", m.events)
+	}
+	if _, ok := m.freshEvents["e1"]; !ok {
+		t.Errorf("the new member's event isn't highlighted")
+	}
+}
+
+func TestRenderEventsAggregates(t *testing.T) {
+	failed := func(name, pod string, count int32, age time.Duration) corev1.Event {
+		event := *testEvent(name, "Pod", pod, corev1.EventTypeWarning, "FailedScheduling", age)
+		event.Message = "0/3 nodes are available: 3 Insufficient memory."
+		event.Count = count
+		return event
+	}
+	started := *testEvent("e4", "Pod", "etcd-main-0", corev1.EventTypeNormal, "Started", 10*time.Second)
+	started.Series = &corev1.EventSeries{Count: 2}
+	events := []corev1.Event{
+		failed("e1", "etcd-main-1", 5, 3*time.Minute),
+		failed("e2", "etcd-main-2", 7, time.Minute),
+		*testEvent("e3", "StatefulSet", testEtcdName, corev1.EventTypeNormal, "SuccessfulCreate", 20*time.Second),
+		started,
+	}
+	sortEvents(events)
+
+	groups := aggregateEvents(events, nil, false)
+	if len(groups) != 3 {
+		t.Fatalf("aggregateEvents() = %d groups, want 3: %+v", len(groups), groups)
+	}
+	scheduling := groups[0]
+	if scheduling.count != 12 || scheduling.eventObjects() != "pod/etcd-main-1 +1" || scheduling.event.Name != "e2" {
+		t.Errorf("FailedScheduling group: count %d, objects %q, last %s", scheduling.count, scheduling.eventObjects(), scheduling.event.Name)
+	}
+	if groups[1].count != 1 || groups[2].count != 2 {
+		t.Errorf("counts = %d, %d, want 1 and the series count 2", groups[1].count, groups[2].count)
+	}
+
+	m, _ := newTestModel(nil)
+	content := m.renderEvents(events, nil)
+	if strings.Count(content, "FailedScheduling") != 1 || !strings.Contains(content, "COUNT") {
+		t.Errorf("renderEvents() didn't fold the repeats into one row:\n%s", content)
+	}
+
+	// By count the repeated failures stay first, the rest by recency
+	byCount := aggregateEvents(events, nil, true)
+	if byCount[0].event.Reason != "FailedScheduling" || byCount[1].event.Reason != "Started" || byCount[2].event.Reason != "SuccessfulCreate" {
+		t.Errorf("aggregateEvents() by count = %s, %s, %s", byCount[0].event.Reason, byCount[1].event.Reason, byCount[2].event.Reason)
+	}
+}
+
+func TestEventsSortKey(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, tea.WindowSizeMsg{Width: 200, Height: 30})
+	m.navigate(EventsState)
+	e1 := *testEvent("e1", "Pod", "etcd-main-0", corev1.EventTypeNormal, "Started", time.Minute)
+	e2 := *testEvent("e2", "Pod", "etcd-main-0", corev1.EventTypeWarning, "BackOff", 30*time.Second)
+	e2.Count = 9
+	m = update(t, m, eventsLoadedMsg{etcdEvents{events: []corev1.Event{e1, e2}}})
+	if strings.Index(m.content, "BackOff") < strings.Index(m.content, "Started") {
+		t.Fatalf("events by recency:\n%s", m.content)
+	}
+
+	m = update(t, m, keyMsg("s"))
+	if !m.eventsByCount || strings.Index(m.content, "BackOff") > strings.Index(m.content, "Started") {
+		t.Errorf("s didn't sort by count:\n%s", m.content)
+	}
+	if !strings.Contains(m.View(), "s: sorted by count") {
+		t.Errorf("help doesn't show the count sort: %s", m.View())
+	}
+	m = update(t, m, keyMsg("s"))
+	if m.eventsByCount {
+		t.Error("s again didn't sort by recency")
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// isFinishedPhase reports whether a pod ran to completion, like the backup and compaction jobs etcd-druid leaves behind
+func isFinishedPhase(phase corev1.PodPhase) bool {
+	return phase == corev1.PodSucceeded || phase == corev1.PodFailed
+}
+
+// hidesFinished reports whether finished pods are left out of the list
+// An active --status filter decides on its own, it may ask for the finished pods explicitly
+func (m *Model) hidesFinished() bool {
+	if len(m.statusFilter) > 0 && !m.allPhases {
+		return false
+	}
+	return !m.showFinished
+}
+
+// listedPods returns the pods the list shows
+func (m *Model) listedPods() []Pod {
+	if !m.hidesFinished() {
+		return m.pods
+	}
+	var pods []Pod
+	for _, pod := range m.pods {
+		if !pod.Finished {
+			pods = append(pods, pod)
+		}
+	}
+	return pods
+}
+
+// finishedTitle notes the finished pods hidden from the list for the header, "" when none are
+func (m *Model) finishedTitle() string {
+	if !m.hidesFinished() {
+		return ""
+	}
+	hidden := len(m.pods) - len(m.listedPods())
+	if hidden == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" [%d finished hidden]", hidden)
+}
+
+// toggleFinishedPods shows or hides the finished pods, keeping the selected pod selected where it is still listed
+func (m *Model) toggleFinishedPods() {
+	selected, ok := m.selectedListPod()
+	m.showFinished = !m.showFinished
+	m.setPods(m.pods)
+	if ok {
+		m.selectListPod(selected)
+	}
+}
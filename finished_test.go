@@ -0,0 +1,70 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestFinishedPodsHidden(t *testing.T) {
+	m, _ := newTestModel([]runtime.Object{
+		testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd")),
+		testPod("etcd-main-compact-job", corev1.PodSucceeded),
+		testPod("etcd-main-backup-job", corev1.PodFailed),
+		testPod("etcd-main-1", corev1.PodRunning, runningContainer("etcd")),
+	})
+	m = update(t, m, m.loadPods()())
+	if len(m.pods) != 4 || len(m.list.Items()) != 2 {
+		t.Fatalf("listed %d of %d pods, want the two members", len(m.list.Items()), len(m.pods))
+	}
+	if view := m.View(); !strings.Contains(view, "[2 finished hidden]") {
+		t.Errorf("header doesn't count the hidden pods:\n%s", view)
+	}
+
+	m.list.Select(1)
+	m = update(t, m, keyMsg("a"))
+	if len(m.list.Items()) != 4 || strings.Contains(m.View(), "finished hidden") {
+		t.Fatalf("a listed %d pods, want all of them", len(m.list.Items()))
+	}
+	if pod, _ := m.selectedListPod(); pod.Name != "etcd-main-1" {
+		t.Errorf("selection moved to %s, want it to stay on etcd-main-1", pod.Name)
+	}
+
+	m = update(t, m, keyMsg("a"))
+	if len(m.list.Items()) != 2 {
+		t.Errorf("a again listed %d pods, want the finished ones hidden", len(m.list.Items()))
+	}
+
+	// --status asking for a terminal phase shows it regardless
+	m.statusFilter = []string{"Succeeded"}
+	m = update(t, m, m.loadPods()())
+	if len(m.list.Items()) != 1 || strings.Contains(m.View(), "finished hidden") {
+		t.Errorf("--status Succeeded listed %d pods, want the succeeded job", len(m.list.Items()))
+	}
+}
+
+func TestFinishedPodsHiddenFromClusterLogsAndPalette(t *testing.T) {
+	m, _ := newTestModel([]runtime.Object{
+		testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd")),
+		testPod("etcd-main-compact-job", corev1.PodSucceeded),
+	})
+	m = update(t, m, m.loadPods()())
+
+	if slices.Contains(m.paletteSuggestions(), "logs etcd-main-compact-job") {
+		t.Error("the palette completes a hidden pod")
+	}
+	if _, ok := m.findPod("etcd-main-compact-job"); ok {
+		t.Error("the palette finds a hidden pod")
+	}
+
+	m = update(t, m, keyMsg("L"))
+	if m.clusterLogs == nil || len(m.clusterLogs.streams) != 1 || len(m.clusterLogTags()) != 1 {
+		t.Fatalf("cluster logs stream %+v, want only etcd-main-0", m.clusterLogs)
+	}
+	if _, ok := m.clusterLogs.streams["etcd-main-0"]; !ok {
+		t.Error("the cluster logs don't stream etcd-main-0")
+	}
+}
@@ -0,0 +1,317 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// maxStreamBatch bounds how many streamed log lines are rendered at once, so a burst doesn't stall the view
+const maxStreamBatch = 500
+
+// fetchPodUID returns the UID of the pod instance currently behind a name, or "" when it can't be read
+// A StatefulSet recreates a member under the same name, so only the UID tells the instances apart
+func (m *Model) fetchPodUID(namespace, podName string) types.UID {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	pod, err := m.kubeClient.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return ""
+	}
+	return pod.UID
+}
+
+// logSource identifies what a log load was read from, e.g. "shoot--dev/etcd-main-0/etcd"
+func logSource(namespace, podName, container string) string {
+	return namespace + "/" + podName + "/" + container
+}
+
+// recreatedNote marks where the logs of a recreated pod's new instance start
+func recreatedNote(podName string, previous, current types.UID) string {
+	return fmt.Sprintf("--- pod %s was recreated (uid %s → %s), logs of the new instance follow ---", podName, previous, current)
+}
+
+// setFollowedLogs replaces the log content with a fresh load, keeping the logs of earlier pod instances above it
+// It reports whether the pod was recreated since the previous load; a load from another pod or container starts over
+func (m *Model) setFollowedLogs(msg logsLoadedMsg) bool {
+	switched := msg.source != m.logSource
+	if switched {
+		m.logSource, m.logPodUID, m.previousLogs, m.lastLogTime = msg.source, msg.uid, "", time.Time{}
+	}
+	recreated := msg.uid != "" && m.logPodUID != "" && msg.uid != m.logPodUID
+	if recreated {
+		m.previousLogs = m.content + "\n" + recreatedNote(m.selectedPod.Name, m.logPodUID, msg.uid) + "\n"
+	}
+	if msg.uid != "" {
+		m.logPodUID = msg.uid
+	}
+	if !msg.latest.IsZero() {
+		m.lastLogTime = msg.latest
+	}
+	// An appended load continues the same instance; the first load of a new instance starts below the note
+	if msg.appended && !recreated && !switched {
+		m.appendToLogs(msg.content)
+		return false
+	}
+	m.content = m.previousLogs + msg.content
+	return recreated
+}
+
+// isLive reports whether the current screen re-fetches its data every refreshInterval
+// The log views only do while following logs that can't be streamed, see streamsLogs and streamsClusterLogs
+func (m *Model) isLive() bool {
+	return isLiveState(m.state) || (m.state == LogState && m.followLogs && !m.streamsLogs()) ||
+		(m.state == ClusterLogsState && !m.streamsClusterLogs())
+}
+
+// streamsLogs reports whether following reads a log stream rather than re-fetching every refreshInterval
+// The merged view and a time window are re-fetched, so lines a replaced pod wrote after the last fetch are missed there
+func (m *Model) streamsLogs() bool {
+	return m.followLogs && !m.mergedLogs && !m.logWindow.active()
+}
+
+// toggleFollowLogs starts or stops following the log view, which keeps following the pod through recreations
+func (m *Model) toggleFollowLogs() tea.Cmd {
+	m.followLogs = !m.followLogs
+	if !m.followLogs {
+		m.stopLogStream()
+		return m.setStatus("stopped following")
+	}
+	if !m.streamsLogs() {
+		return tea.Batch(m.reloadLogs(), m.scheduleRefresh(),
+			m.setStatus(fmt.Sprintf("following every %s, lines a replaced pod writes after the last fetch are missed", m.refreshInterval)))
+	}
+	return m.reloadLogs()
+}
+
+// logStream streams the followed container's logs into the log view, see startLogStream
+// uid is the pod instance streamed, so a reopened stream can tell the pod was recreated in between; opened is set once the first stream replaced the snapshot
+type logStream struct {
+	id        int
+	namespace string
+	podName   string
+	container string
+	uid       types.UID
+	opened    bool
+	lines     <-chan string
+	cancel    context.CancelFunc
+}
+
+// logStreamStartedMsg carries a log stream opened for the log view; fresh is set for the first stream of a follow
+type logStreamStartedMsg struct {
+	id     int
+	uid    types.UID
+	fresh  bool
+	lines  <-chan string
+	cancel context.CancelFunc
+	err    error
+}
+
+// logStreamLinesMsg carries the lines read from the log stream; closed is set once it ended
+type logStreamLinesMsg struct {
+	id     int
+	lines  []string
+	closed bool
+}
+
+// logStreamRetryMsg reopens an ended log stream
+type logStreamRetryMsg struct{ id int }
+
+// startLogStream replaces the log view with a stream of the current container's logs, starting at the tail setting
+func (m *Model) startLogStream() tea.Cmd {
+	m.stopLogStream()
+	m.logStreamID++
+	container := m.currentContainer()
+	m.logStream = &logStream{id: m.logStreamID, namespace: m.podNamespace(m.selectedPod), podName: m.selectedPod.Name, container: container}
+	m.logSource, m.logPodUID, m.previousLogs, m.lastLogTime = logSource(m.logStream.namespace, m.logStream.podName, container), "", "", time.Time{}
+	return m.openLogStream(m.logStream)
+}
+
+// stopLogStream ends the log stream, e.g. when leaving the log view
+func (m *Model) stopLogStream() {
+	if m.logStream != nil && m.logStream.cancel != nil {
+		m.logStream.cancel()
+	}
+	m.logStream = nil
+}
+
+// openLogStream is a command that opens a follow stream of the container's logs
+// A reopened stream continues after the last line shown, or reads the new instance from its start when the pod was recreated
+func (m *Model) openLogStream(s *logStream) tea.Cmd {
+	id, namespace, podName, previous, fresh := s.id, s.namespace, s.podName, s.uid, !s.opened
+	opts := m.podLogOptions(s.container)
+	opts.Follow, opts.Timestamps = true, true
+	since := m.lastLogTime
+	return func() tea.Msg {
+		// The UID is read first, so lines of a pod recreated in between are attributed to the new instance
+		uid := m.fetchPodUID(namespace, podName)
+		switch {
+		case fresh:
+		case previous != "" && uid != "" && uid != previous:
+			opts.SinceTime, opts.SinceSeconds, opts.TailLines = nil, nil, nil
+		case !since.IsZero():
+			sinceTime := metav1.NewTime(since)
+			opts.SinceTime, opts.SinceSeconds, opts.TailLines = &sinceTime, nil, nil
+		}
+		// The stream outlives the request timeout, it ends when stopped
+		ctx, cancel := context.WithCancel(context.Background())
+		logs, err := m.kubeClient.CoreV1().Pods(namespace).GetLogs(podName, opts).Stream(ctx)
+		if err != nil {
+			cancel()
+			return logStreamStartedMsg{id: id, fresh: fresh, err: fmt.Errorf("failed to follow logs for pod %s (container %s): %w", podName, opts.Container, err)}
+		}
+		lines := make(chan string, maxStreamBatch)
+		go readLogLines(ctx, logs, lines)
+		return logStreamStartedMsg{id: id, uid: uid, fresh: fresh, lines: lines, cancel: cancel}
+	}
+}
+
+// readLogLines sends the lines of a log stream until it ends or ctx is cancelled, then closes lines
+func readLogLines(ctx context.Context, logs io.ReadCloser, lines chan<- string) {
+	defer close(lines)
+	defer logs.Close()
+	reader := bufio.NewReader(logs)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			select {
+			case lines <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// nextLogLines is a command that waits for the next lines of the log stream, taking those already read along with them
+func nextLogLines(id int, lines <-chan string) tea.Cmd {
+	return func() tea.Msg {
+		batch, closed := readLogBatch(lines)
+		return logStreamLinesMsg{id: id, lines: batch, closed: closed}
+	}
+}
+
+// readLogBatch waits for the next line of a stream and takes up to maxStreamBatch lines already read along with it
+// closed is set once the stream ended
+func readLogBatch(lines <-chan string) (batch []string, closed bool) {
+	line, ok := <-lines
+	if !ok {
+		return nil, true
+	}
+	batch = []string{line}
+	for len(batch) < maxStreamBatch {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				return batch, true
+			}
+			batch = append(batch, line)
+		default:
+			return batch, false
+		}
+	}
+	return batch, false
+}
+
+// currentLogStream returns the log stream a message is for, or nil when it was stopped or replaced since or the view left
+func (m *Model) currentLogStream(id int) *logStream {
+	if m.logStream == nil || m.logStream.id != id || m.state != LogState || !m.followLogs {
+		return nil
+	}
+	return m.logStream
+}
+
+// setLogStream keeps the opened stream and waits for its lines; a stream that failed to open is retried after refreshInterval
+// A new UID under the same name is the StatefulSet recreating the member, whose logs continue below a note
+func (m *Model) setLogStream(msg logStreamStartedMsg) tea.Cmd {
+	s := m.currentLogStream(msg.id)
+	if s == nil {
+		if msg.cancel != nil {
+			msg.cancel()
+		}
+		m.dropLogStream(msg.id)
+		return nil
+	}
+	if msg.err != nil {
+		return tea.Batch(m.setStatus(msg.err.Error()), m.retryLogStream(s.id))
+	}
+	s.opened, s.lines, s.cancel = true, msg.lines, msg.cancel
+	var status tea.Cmd
+	switch {
+	case msg.fresh:
+		m.content, m.logsTruncated = "", false
+	case s.uid != "" && msg.uid != "" && msg.uid != s.uid:
+		m.appendToLogs(recreatedNote(s.podName, s.uid, msg.uid) + "\n")
+		m.lastLogTime = time.Time{}
+		m.refreshViewport()
+		status = m.setStatus(fmt.Sprintf("pod %s was recreated, following the new instance", s.podName))
+	}
+	if msg.uid != "" {
+		s.uid, m.logPodUID = msg.uid, msg.uid
+	}
+	return tea.Batch(nextLogLines(s.id, msg.lines), status)
+}
+
+// applyLogLines appends streamed lines to the log view, keeping it at the bottom if it was there
+// A reopened stream starts in the second of the last line shown, whose lines are dropped again
+func (m *Model) applyLogLines(msg logStreamLinesMsg) tea.Cmd {
+	s := m.currentLogStream(msg.id)
+	if s == nil {
+		m.dropLogStream(msg.id)
+		return nil
+	}
+	if len(msg.lines) > 0 {
+		content, latest := newLogLines(strings.Join(msg.lines, ""), m.lastLogTime, m.timestamps)
+		m.lastLogTime = latest
+		atBottom := m.viewport.AtBottom()
+		m.appendToLogs(content)
+		m.refreshViewport()
+		if atBottom {
+			m.viewport.GotoBottom()
+		}
+	}
+	if msg.closed {
+		// The container exited or the pod is gone; reopening picks up its restart or the recreated pod
+		if s.cancel != nil {
+			s.cancel()
+		}
+		s.lines, s.cancel = nil, nil
+		return m.retryLogStream(s.id)
+	}
+	return nextLogLines(s.id, s.lines)
+}
+
+// retryLogStream is a command that reopens the log stream after refreshInterval
+func (m *Model) retryLogStream(id int) tea.Cmd {
+	return tea.Tick(m.refreshInterval, func(time.Time) tea.Msg {
+		return logStreamRetryMsg{id}
+	})
+}
+
+// reopenLogStream reopens an ended log stream unless the log view stopped following since
+func (m *Model) reopenLogStream(msg logStreamRetryMsg) tea.Cmd {
+	s := m.currentLogStream(msg.id)
+	if s == nil {
+		m.dropLogStream(msg.id)
+		return nil
+	}
+	return m.openLogStream(s)
+}
+
+// dropLogStream stops the log stream a stale message is for, if it is still the current one
+// It is, when the log view was left or stopped following without stopping it
+func (m *Model) dropLogStream(id int) {
+	if m.logStream != nil && m.logStream.id == id {
+		m.stopLogStream()
+	}
+}
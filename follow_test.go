@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestFollowMergedLogsAcrossRecreation(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m.selectedPod = m.pods[0]
+	m.setMergedLogs(true)
+	source := logSource(testNamespace, "etcd-main-0", "*")
+	m = update(t, m, logsLoadedMsg{content: "starting", source: source, merged: true})
+	if m.state != LogState || m.followLogs {
+		t.Fatalf("state %v, following %v, want a snapshot log view", m.state, m.followLogs)
+	}
+
+	// The merged view can't be streamed, so following re-fetches it
+	next, cmd := m.Update(keyMsg("f"))
+	m = next.(Model)
+	if !m.followLogs || cmd == nil || !m.isLive() || m.logStream != nil {
+		t.Fatalf("f did not start re-fetching the merged logs")
+	}
+	if !strings.Contains(m.status, "missed") {
+		t.Errorf("status = %q, want a note about the lines missed between fetches", m.status)
+	}
+	m = update(t, m, logsLoadedMsg{content: "old instance", source: source, merged: true, uid: "uid-1"})
+	if m.content != "old instance" {
+		t.Errorf("content = %q, want only the current instance", m.content)
+	}
+
+	// A new UID under the same name is the StatefulSet recreating the member
+	m = update(t, m, logsLoadedMsg{content: "new instance", source: source, merged: true, uid: "uid-2"})
+	for _, want := range []string{"old instance\n", recreatedNote("etcd-main-0", "uid-1", "uid-2"), "new instance"} {
+		if !strings.Contains(m.content, want) {
+			t.Errorf("content after the recreation missing %q:\n%s", want, m.content)
+		}
+	}
+	if !strings.Contains(m.status, "recreated") {
+		t.Errorf("status = %q, want a note about the recreation", m.status)
+	}
+	// Later loads of the new instance keep the old logs above them
+	m = update(t, m, logsLoadedMsg{content: "new instance, later", source: source, merged: true, uid: "uid-2"})
+	if !strings.HasPrefix(m.content, "old instance\n") || !strings.HasSuffix(m.content, "new instance, later") {
+		t.Errorf("content = %q, want the old logs followed by the latest load", m.content)
+	}
+
+	// Another pod starts over
+	m = update(t, m, logsLoadedMsg{content: "other pod", source: logSource(testNamespace, "etcd-main-1", "*"), merged: true, uid: "uid-3"})
+	if m.content != "other pod" {
+		t.Errorf("content = %q after switching pods, want only the new pod", m.content)
+	}
+
+	m = update(t, m, keyMsg("f"))
+	if m.followLogs || m.isLive() {
+		t.Error("f did not stop following")
+	}
+}
+
+func TestFollowLogsStreams(t *testing.T) {
+	pod := testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))
+	pod.UID = "uid-1"
+	m, client := newTestModel([]runtime.Object{pod})
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m.selectedPod = m.pods[0]
+	m = update(t, m, logsLoadedMsg{content: "snapshot", source: logSource(testNamespace, "etcd-main-0", "etcd")})
+
+	next, cmd := m.Update(keyMsg("f"))
+	m = next.(Model)
+	if !m.followLogs || m.logStream == nil || cmd == nil || m.isLive() || !strings.Contains(m.View(), "(following)") {
+		t.Fatalf("f did not start streaming the logs")
+	}
+	started, ok := cmd().(logStreamStartedMsg)
+	if !ok || started.err != nil || started.uid != "uid-1" || !started.fresh {
+		t.Fatalf("opening the stream = %+v, want a fresh stream of uid-1", started)
+	}
+	next, cmd = m.Update(started)
+	m = next.(Model)
+	// The stream starts at the tail setting, so it replaces the snapshot
+	if m.content != "" || cmd == nil {
+		t.Fatalf("content = %q after opening the stream, want it cleared and waiting for lines", m.content)
+	}
+	lines, ok := cmd().(logStreamLinesMsg)
+	if !ok || len(lines.lines) == 0 {
+		t.Fatalf("reading the stream = %+v, want its lines", lines)
+	}
+	m = update(t, m, lines)
+	if !strings.Contains(m.content, "fake logs") {
+		t.Errorf("content = %q, want the streamed lines", m.content)
+	}
+	started.cancel()
+
+	id := m.logStream.id
+	m.content = ""
+	line := "2026-10-14T10:00:00Z member ready\n"
+	m = update(t, m, logStreamLinesMsg{id: id, lines: []string{line}})
+	// A reopened stream sends the lines of the last second again
+	m = update(t, m, logStreamLinesMsg{id: id, lines: []string{line, "2026-10-14T10:00:01Z leader elected\n"}})
+	if m.content != "member ready\nleader elected\n" {
+		t.Errorf("content = %q, want each line once and without its timestamp", m.content)
+	}
+
+	// The stream ends with the pod; the StatefulSet recreates it under the same name
+	next, cmd = m.Update(logStreamLinesMsg{id: id, closed: true})
+	m = next.(Model)
+	if cmd == nil || m.logStream.lines != nil {
+		t.Fatalf("an ended stream is not reopened")
+	}
+	if err := client.CoreV1().Pods(testNamespace).Delete(context.Background(), "etcd-main-0", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	recreated := pod.DeepCopy()
+	recreated.UID = "uid-2"
+	if _, err := client.CoreV1().Pods(testNamespace).Create(context.Background(), recreated, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	started, ok = m.reopenLogStream(logStreamRetryMsg{id})().(logStreamStartedMsg)
+	if !ok || started.err != nil || started.uid != "uid-2" || started.fresh {
+		t.Fatalf("reopening the stream = %+v, want a stream of uid-2", started)
+	}
+	defer started.cancel()
+	m = update(t, m, started)
+	for _, want := range []string{"leader elected\n", recreatedNote("etcd-main-0", "uid-1", "uid-2")} {
+		if !strings.Contains(m.content, want) {
+			t.Errorf("content after the recreation missing %q:\n%s", want, m.content)
+		}
+	}
+	if !strings.Contains(m.status, "recreated") {
+		t.Errorf("status = %q, want a note about the recreation", m.status)
+	}
+
+	m = update(t, m, keyMsg("esc"))
+	if m.logStream != nil {
+		t.Error("leaving the log view did not stop the stream")
+	}
+}
+
+func TestLoadLogsReadsUIDWhileFollowing(t *testing.T) {
+	pod := testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))
+	pod.UID = "uid-1"
+	m, _ := newTestModel([]runtime.Object{pod})
+	m.selectedPod = Pod{Name: "etcd-main-0"}
+
+	if msg := m.loadLogs("etcd")().(logsLoadedMsg); msg.uid != "" {
+		t.Errorf("snapshot load read uid %q, want none", msg.uid)
+	}
+	m.followLogs = true
+	if msg := m.loadLogs("etcd")().(logsLoadedMsg); msg.uid != "uid-1" {
+		t.Errorf("followed load read uid %q, want uid-1", msg.uid)
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/formatters"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// chromaFormatterFor picks the chroma ANSI formatter matching the terminal's color profile
+// An empty name means the terminal has no color support
+func chromaFormatterFor(profile termenv.Profile) string {
+	switch profile {
+	case termenv.TrueColor:
+		return "terminal16m"
+	case termenv.ANSI256:
+		return "terminal256"
+	case termenv.ANSI:
+		return "terminal"
+	}
+	return ""
+}
+
+// highlightYAML renders YAML with ANSI syntax coloring for keys, values and comments, using the named chroma style
+// The input is returned unchanged when the terminal can't show colors or highlighting fails
+func highlightYAML(content, styleName string) string {
+	formatterName := chromaFormatterFor(lipgloss.ColorProfile())
+	if formatterName == "" {
+		return content
+	}
+
+	lexer := lexers.Get("yaml")
+	if lexer == nil {
+		return content
+	}
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, content)
+	if err != nil {
+		return content
+	}
+
+	var buf bytes.Buffer
+	if err := formatters.Get(formatterName).Format(&buf, styles.Get(styleName), iterator); err != nil {
+		return content
+	}
+	return buf.String()
+}
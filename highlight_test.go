@@ -0,0 +1,63 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+)
+
+func TestChromaFormatterFor(t *testing.T) {
+	tests := []struct {
+		profile termenv.Profile
+		want    string
+	}{
+		{termenv.TrueColor, "terminal16m"},
+		{termenv.ANSI256, "terminal256"},
+		{termenv.ANSI, "terminal"},
+		{termenv.Ascii, ""},
+	}
+	for _, tt := range tests {
+		if got := chromaFormatterFor(tt.profile); got != tt.want {
+			t.Errorf("chromaFormatterFor(%v) = %q, want %q", tt.profile, got, tt.want)
+		}
+	}
+}
+
+func TestHighlightYAML(t *testing.T) {
+	content := "# the pod\nmetadata:\n  name: etcd-main-0\n  replicas: 3\n"
+	tests := []struct {
+		name        string
+		profile     termenv.Profile
+		highlighted bool
+	}{
+		{"true color", termenv.TrueColor, true},
+		{"256 colors", termenv.ANSI256, true},
+		{"16 colors", termenv.ANSI, true},
+		{"no colors", termenv.Ascii, false},
+	}
+	previous := lipgloss.ColorProfile()
+	t.Cleanup(func() { lipgloss.SetColorProfile(previous) })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lipgloss.SetColorProfile(tt.profile)
+			got := highlightYAML(content, "monokai")
+			if !tt.highlighted {
+				if got != content {
+					t.Errorf("highlightYAML() = %q, want the content unchanged", got)
+				}
+				return
+			}
+			if !strings.Contains(got, "\x1b[") {
+				t.Errorf("highlightYAML() = %q, want ANSI colors", got)
+			}
+			// Coloring only wraps the text, it never changes it
+			if plain := ansi.Strip(got); plain != content {
+				t.Errorf("highlightYAML() without colors = %q, want %q", plain, content)
+			}
+		})
+	}
+}
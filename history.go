@@ -0,0 +1,103 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// splitLogTimestamp separates the RFC3339 timestamp the kubelet prefixes a line with from the line itself
+func splitLogTimestamp(line string) (time.Time, string, bool) {
+	stamp, rest, ok := strings.Cut(line, " ")
+	if !ok {
+		stamp, rest = line, ""
+	}
+	t, err := time.Parse(time.RFC3339Nano, stamp)
+	if err != nil {
+		return time.Time{}, line, false
+	}
+	return t, rest, true
+}
+
+// newLogLines keeps the lines of a timestamped log fetch that are later than since, and returns the latest timestamp seen
+// sinceTime only has second precision, so the kubelet sends the lines of that second again and they are dropped here
+// The timestamps are stripped again unless the log view shows them
+func newLogLines(logs string, since time.Time, keepTimestamps bool) (string, time.Time) {
+	latest := since
+	var kept strings.Builder
+	for _, line := range strings.SplitAfter(logs, "\n") {
+		if line == "" {
+			continue
+		}
+		t, rest, ok := splitLogTimestamp(line)
+		if ok && !t.After(since) {
+			continue
+		}
+		if ok && t.After(latest) {
+			latest = t
+		}
+		if ok && !keepTimestamps {
+			line = rest
+		}
+		kept.WriteString(line)
+	}
+	return kept.String(), latest
+}
+
+// fetchLogHistory reads a container's logs with timestamps, only those after since unless it is zero
+// The timestamps tell where the next refresh picks up
+func (m *Model) fetchLogHistory(namespace, podName, container string, since time.Time) (string, bool, time.Time, error) {
+	opts := m.podLogOptions(container)
+	opts.Timestamps = true
+	if !since.IsZero() {
+		sinceTime := metav1.NewTime(since)
+		opts.SinceTime, opts.SinceSeconds, opts.TailLines = &sinceTime, nil, nil
+	}
+	logs, truncated, err := m.streamPodLogs(namespace, podName, opts)
+	if err != nil {
+		return "", false, time.Time{}, err
+	}
+	content, latest := newLogLines(logs, since, m.timestamps)
+	return content, truncated, latest, nil
+}
+
+// appendToLogs adds newly fetched lines below the logs already shown, still keeping at most maxLogBytes
+func (m *Model) appendToLogs(content string) {
+	if m.content != "" && !strings.HasSuffix(m.content, "\n") {
+		m.content += "\n"
+	}
+	buffer := newTailBuffer(maxLogBytes)
+	buffer.Write([]byte(m.content + content))
+	m.content = buffer.String()
+	m.logsTruncated = m.logsTruncated || buffer.Truncated()
+}
+
+// refreshLogs re-fetches the log view on r and while following
+// In append mode only the lines since the last fetch are read; the merged view, a time window and a first fetch read everything
+func (m *Model) refreshLogs() tea.Cmd {
+	if m.appendLogs && !m.mergedLogs && !m.lastLogTime.IsZero() && !m.logWindow.active() {
+		return m.loadLogsSince(m.currentContainer(), m.lastLogTime)
+	}
+	return m.reloadLogs()
+}
+
+// toggleAppendLogs switches refreshes between replacing the log view and appending the new lines to it
+// Turning it on fetches again, so there is a timestamp to continue from
+func (m *Model) toggleAppendLogs() tea.Cmd {
+	m.appendLogs = !m.appendLogs
+	m.lastLogTime = time.Time{}
+	if !m.appendLogs {
+		return m.setStatus("refresh replaces the logs")
+	}
+	return tea.Batch(m.setStatus("refresh appends new lines"), m.reloadLogs())
+}
+
+// logRefreshMode names what a refresh of the log view does, for the help line
+func (m *Model) logRefreshMode() string {
+	if m.appendLogs {
+		return "append"
+	}
+	return "replace"
+}
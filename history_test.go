@@ -0,0 +1,70 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestNewLogLines(t *testing.T) {
+	logs := "2026-10-14T10:00:00.100000000Z first\n" +
+		"2026-10-14T10:00:00.200000000Z second\n" +
+		"2026-10-14T10:00:01.000000000Z third\n"
+	since := time.Date(2026, 10, 14, 10, 0, 0, 100000000, time.UTC)
+
+	content, latest := newLogLines(logs, since, false)
+	if content != "second\nthird\n" {
+		t.Errorf("content = %q, want the lines after since without timestamps", content)
+	}
+	if want := time.Date(2026, 10, 14, 10, 0, 1, 0, time.UTC); !latest.Equal(want) {
+		t.Errorf("latest = %v, want %v", latest, want)
+	}
+
+	content, _ = newLogLines(logs, time.Time{}, true)
+	if content != logs {
+		t.Errorf("content = %q, want every line with its timestamp", content)
+	}
+}
+
+func TestAppendLogsOnRefresh(t *testing.T) {
+	m, client := newTestModel(nil)
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m.selectedPod = m.pods[0]
+	source := logSource(testNamespace, "etcd-main-0", "etcd-main-0")
+	first := time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)
+
+	m = update(t, m, logsLoadedMsg{content: "first\n", source: source})
+	m = update(t, m, keyMsg("b"))
+	if !m.appendLogs {
+		t.Fatal("b did not turn on append mode")
+	}
+	m = update(t, m, logsLoadedMsg{content: "first\n", source: source, latest: first})
+	m = update(t, m, logsLoadedMsg{content: "second\n", source: source, appended: true, latest: first.Add(time.Second)})
+	if m.content != "first\nsecond\n" || !m.lastLogTime.Equal(first.Add(time.Second)) {
+		t.Errorf("content = %q, last log time %v, want the new lines appended", m.content, m.lastLogTime)
+	}
+
+	// A refresh only asks for what came after the last line
+	client.ClearActions()
+	msg := m.refreshLogs()().(logsLoadedMsg)
+	if !msg.appended {
+		t.Error("refresh in append mode replaced the logs")
+	}
+	var opts *corev1.PodLogOptions
+	for _, action := range client.Actions() {
+		if generic, ok := action.(k8stesting.GenericAction); ok && action.GetSubresource() == "log" {
+			opts, _ = generic.GetValue().(*corev1.PodLogOptions)
+		}
+	}
+	if opts == nil || opts.SinceTime == nil || !opts.SinceTime.Time.Equal(first.Add(time.Second)) || opts.TailLines != nil || !opts.Timestamps {
+		t.Errorf("log options = %+v, want timestamps since the last line without a tail", opts)
+	}
+
+	// Replace mode fetches everything again
+	m = update(t, m, keyMsg("b"))
+	if msg := m.refreshLogs()().(logsLoadedMsg); msg.appended {
+		t.Error("refresh in replace mode appended")
+	}
+}
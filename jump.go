@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// jumpTimeout is how long the quick-jump buffer keeps collecting keys after the last one
+const jumpTimeout = time.Second
+
+// clearJumpMsg empties the quick-jump buffer once typing has paused
+type clearJumpMsg struct{ id int }
+
+// isJumpKey reports whether a key feeds the quick-jump buffer
+// Only digits are used so they never clash with the single-letter actions of the pod list
+func isJumpKey(key string) bool {
+	return len(key) == 1 && key[0] >= '0' && key[0] <= '9'
+}
+
+// podOrdinal parses the ordinal the StatefulSet gave a pod, e.g. 2 for etcd-main-2
+func podOrdinal(podName, setName string) (int, bool) {
+	suffix, ok := strings.CutPrefix(podName, setName+"-")
+	if !ok {
+		return 0, false
+	}
+	ordinal, err := strconv.Atoi(suffix)
+	if err != nil || ordinal < 0 || strconv.Itoa(ordinal) != suffix {
+		return 0, false
+	}
+	return ordinal, true
+}
+
+// jumpTo appends key to the quick-jump buffer and selects the pod whose ordinal it spells
+// The selection stays put while no pod has that ordinal, so a typo doesn't lose the place
+func (m *Model) jumpTo(key string) tea.Cmd {
+	m.jumpBuffer += key
+	m.jumpID++
+
+	for i, item := range m.list.VisibleItems() {
+		pod, ok := item.(Pod)
+		if !ok {
+			continue
+		}
+		if ordinal, ok := podOrdinal(pod.Name, m.etcdName); ok && strconv.Itoa(ordinal) == m.jumpBuffer {
+			m.list.Select(i)
+			break
+		}
+	}
+
+	id := m.jumpID
+	return tea.Tick(jumpTimeout, func(time.Time) tea.Msg {
+		return clearJumpMsg{id}
+	})
+}
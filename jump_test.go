@@ -0,0 +1,80 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestQuickJump(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, tea.WindowSizeMsg{Width: 80, Height: 40})
+	var pods []Pod
+	for _, name := range []string{"etcd-main-0", "etcd-main-10", "etcd-main-1", "etcd-main-2"} {
+		pods = append(pods, Pod{Name: name})
+	}
+	m = update(t, m, podsLoadedMsg{pods: pods})
+
+	selected := func() string {
+		pod, _ := m.selectedListPod()
+		return pod.Name
+	}
+
+	m = update(t, m, keyMsg("2"))
+	if got := selected(); got != "etcd-main-2" {
+		t.Errorf("after 2: selected %q, want etcd-main-2", got)
+	}
+
+	// The ordinal must match exactly, not merely be contained in the name
+	m = update(t, m, clearJumpMsg{m.jumpID})
+	m = update(t, m, keyMsg("1"))
+	if got := selected(); got != "etcd-main-1" {
+		t.Errorf("after 1: selected %q, want etcd-main-1", got)
+	}
+
+	// Keys typed before the timeout extend the buffer
+	m = update(t, m, keyMsg("0"))
+	if got := selected(); got != "etcd-main-10" || m.jumpBuffer != "10" {
+		t.Errorf("after 10: selected %q with buffer %q, want etcd-main-10", got, m.jumpBuffer)
+	}
+
+	// No pod has ordinal 105, so the selection stays where it was
+	m = update(t, m, keyMsg("5"))
+	if got := selected(); got != "etcd-main-10" {
+		t.Errorf("after 105: selected %q, want etcd-main-10 unchanged", got)
+	}
+	m = update(t, m, clearJumpMsg{m.jumpID})
+	m = update(t, m, keyMsg("1"))
+	m = update(t, m, keyMsg("0"))
+
+	// A timer from an earlier key must not clear a newer buffer
+	m = update(t, m, clearJumpMsg{m.jumpID - 1})
+	if m.jumpBuffer != "10" {
+		t.Errorf("buffer cleared by a stale timer")
+	}
+	m = update(t, m, clearJumpMsg{m.jumpID})
+	if m.jumpBuffer != "" {
+		t.Errorf("buffer = %q after the timeout, want it cleared", m.jumpBuffer)
+	}
+}
+
+func TestPodOrdinal(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   int
+		wantOK bool
+	}{
+		{"etcd-main-0", 0, true},
+		{"etcd-main-12", 12, true},
+		{"etcd-main-01", 0, false},
+		{"etcd-main-client", 0, false},
+		{"etcd-events-1", 0, false},
+		{"etcd-main-", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := podOrdinal(tt.name, testEtcdName)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("podOrdinal(%q) = %d, %v, want %d, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,397 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyAction names what a key does; the names are what the keys section of the config file binds
+type keyAction string
+
+const (
+	// Every screen
+	actionRefresh    keyAction = "refresh"
+	actionRefreshAll keyAction = "refresh-all"
+	actionKeys       keyAction = "keys"
+	actionPalette    keyAction = "palette"
+
+	// Shared by several screens
+	actionQuit        keyAction = "quit"
+	actionBack        keyAction = "back"
+	actionSelect      keyAction = "select"
+	actionDescribe    keyAction = "describe"
+	actionDebug       keyAction = "debug"
+	actionMetadata    keyAction = "metadata"
+	actionNode        keyAction = "node"
+	actionCordon      keyAction = "cordon"
+	actionTimes       keyAction = "times"
+	actionSwitchView  keyAction = "switch-view"
+	actionCopyCommand keyAction = "copy-command"
+	actionMerged      keyAction = "merge-containers"
+	actionSplit       keyAction = "split-logs"
+
+	// The pod list
+	actionContainers   keyAction = "containers"
+	actionLogBundle    keyAction = "log-bundle"
+	actionDescribeEtcd keyAction = "describe-etcd"
+	actionYAML         keyAction = "yaml"
+	actionEtcdYAML     keyAction = "etcd-yaml"
+	actionStatusFilter keyAction = "status-filter"
+	actionDiagnostics  keyAction = "diagnostics"
+	actionQuorum       keyAction = "quorum"
+	actionDiskUsage    keyAction = "disk-usage"
+	actionCopyName     keyAction = "copy-name"
+	actionMark         keyAction = "mark"
+	actionRestart      keyAction = "restart"
+	actionDiff         keyAction = "diff"
+	actionTheme        keyAction = "theme"
+	actionTable        keyAction = "table"
+	actionMetrics      keyAction = "metrics"
+	actionEvents       keyAction = "events"
+	actionEdit         keyAction = "edit"
+	actionDashboard    keyAction = "dashboard"
+	actionClusterLogs  keyAction = "cluster-logs"
+	actionWatch        keyAction = "watch"
+	actionFinishedPods keyAction = "finished-pods"
+	actionCerts        keyAction = "certificates"
+	actionPin          keyAction = "pin"
+
+	// The log views
+	actionPretty        keyAction = "pretty"
+	actionSeverity      keyAction = "severity"
+	actionGrep          keyAction = "grep"
+	actionFullLogs      keyAction = "full-logs"
+	actionTimestamps    keyAction = "timestamps"
+	actionSince         keyAction = "since"
+	actionWindow        keyAction = "window"
+	actionFollow        keyAction = "follow"
+	actionAppend        keyAction = "append"
+	actionPrevContainer keyAction = "previous-container"
+	actionNextContainer keyAction = "next-container"
+	actionFocus         keyAction = "focus"
+	actionPaneContainer keyAction = "pane-container"
+
+	// The YAML and describe views
+	actionLineNumbers   keyAction = "line-numbers"
+	actionHighlight     keyAction = "highlight"
+	actionManagedFields keyAction = "managed-fields"
+	actionLastApplied   keyAction = "last-applied"
+	actionScale         keyAction = "scale"
+	actionReconcile     keyAction = "reconcile"
+
+	// The events view
+	actionSortEvents keyAction = "sort-events"
+)
+
+// keyDefaults are the built-in bindings with what each does, for the keys screen
+var keyDefaults = map[keyAction]struct {
+	keys []string
+	help string
+}{
+	actionRefresh:    {[]string{"r"}, "refresh"},
+	actionRefreshAll: {[]string{"ctrl+r"}, "refresh the pods, Etcd status and statefulset"},
+	actionKeys:       {[]string{"?"}, "show the key bindings"},
+	actionPalette:    {[]string{":"}, "command palette"},
+
+	actionQuit:        {[]string{"q"}, "quit"},
+	actionBack:        {[]string{"q", "esc"}, "back"},
+	actionSelect:      {[]string{"enter"}, "open the selection"},
+	actionDescribe:    {[]string{"d"}, "describe"},
+	actionDebug:       {[]string{"D"}, "add a debug container"},
+	actionMetadata:    {[]string{"M"}, "add or remove a label or annotation"},
+	actionNode:        {[]string{"N"}, "describe the node"},
+	actionCordon:      {[]string{"c"}, "cordon or uncordon the node"},
+	actionTimes:       {[]string{"t"}, "relative or absolute times"},
+	actionSwitchView:  {[]string{"tab"}, "switch between logs and describe"},
+	actionCopyCommand: {[]string{"C"}, "copy the kubectl logs command"},
+	actionMerged:      {[]string{"A"}, "logs of all containers interleaved"},
+	actionSplit:       {[]string{"V"}, "split logs of the marked pods"},
+
+	actionContainers:   {[]string{"l"}, "logs, picking the container"},
+	actionLogBundle:    {[]string{"B"}, "write a log bundle"},
+	actionDescribeEtcd: {[]string{"D"}, "describe the Etcd"},
+	actionYAML:         {[]string{"y"}, "pod YAML"},
+	actionEtcdYAML:     {[]string{"e"}, "Etcd YAML"},
+	actionStatusFilter: {[]string{"F"}, "toggle the --status filter"},
+	actionDiagnostics:  {[]string{"!"}, "run the diagnostics"},
+	actionQuorum:       {[]string{"Q"}, "cluster health from etcdctl"},
+	actionDiskUsage:    {[]string{"u"}, "data volume usage"},
+	actionCopyName:     {[]string{"c"}, "copy the pod name"},
+	actionMark:         {[]string{" "}, "mark for diff and split"},
+	actionRestart:      {[]string{"R"}, "rolling restart"},
+	actionDiff:         {[]string{"x"}, "diff the marked pods"},
+	actionTheme:        {[]string{"T"}, "next theme"},
+	actionTable:        {[]string{"t"}, "table layout"},
+	actionMetrics:      {[]string{"m"}, "metrics"},
+	actionEvents:       {[]string{"v"}, "events"},
+	actionEdit:         {[]string{"E"}, "edit the pod, or the Etcd in its YAML"},
+	actionDashboard:    {[]string{"H"}, "health dashboard"},
+	actionClusterLogs:  {[]string{"L"}, "etcd logs of every member"},
+	actionWatch:        {[]string{"W"}, "alert when a condition is met, or stop watching"},
+	actionFinishedPods: {[]string{"a"}, "show or hide Succeeded and Failed pods"},
+	actionCerts:        {[]string{"S"}, "certificates of the TLS secrets, after confirming"},
+	actionPin:          {[]string{"p"}, "pin or unpin the pod at the top"},
+
+	actionPretty:        {[]string{"p"}, "pretty or raw logs"},
+	actionSeverity:      {[]string{"s"}, "minimum level"},
+	actionGrep:          {[]string{"g"}, "grep"},
+	actionFullLogs:      {[]string{"a"}, "last lines or the full log"},
+	actionTimestamps:    {[]string{"t"}, "timestamps"},
+	actionSince:         {[]string{"S"}, "how far back"},
+	actionWindow:        {[]string{"w"}, "time window"},
+	actionFollow:        {[]string{"f"}, "follow"},
+	actionAppend:        {[]string{"b"}, "append or replace on refresh"},
+	actionPrevContainer: {[]string{"["}, "previous container"},
+	actionNextContainer: {[]string{"]"}, "next container"},
+	actionFocus:         {[]string{"tab"}, "focus the other pane"},
+	actionPaneContainer: {[]string{"c"}, "container of the pane"},
+
+	actionLineNumbers:   {[]string{"#"}, "line numbers"},
+	actionHighlight:     {[]string{"h"}, "syntax highlighting"},
+	actionManagedFields: {[]string{"m"}, "managedFields"},
+	actionLastApplied:   {[]string{"a"}, "live or last applied"},
+	actionScale:         {[]string{"s"}, "scale the Etcd"},
+	actionReconcile:     {[]string{"R"}, "annotate the Etcd to reconcile"},
+	actionSortEvents:    {[]string{"s"}, "sort by recency or count"},
+}
+
+// globalActions work on every screen, ahead of the screen's own
+var globalActions = []keyAction{actionRefresh, actionRefreshAll, actionKeys, actionPalette}
+
+// screenActions lists the actions of each screen in the order the keys screen shows them
+var screenActions = map[AppState][]keyAction{
+	ListState: {actionQuit, actionContainers, actionDescribe, actionYAML, actionNode, actionMark, actionDiff, actionSplit,
+		actionLogBundle, actionDescribeEtcd, actionEtcdYAML, actionStatusFilter, actionMetadata, actionDiagnostics, actionQuorum,
+		actionDiskUsage, actionCopyName, actionCopyCommand, actionRestart, actionTheme, actionTable, actionMetrics, actionEvents,
+		actionEdit, actionDashboard, actionClusterLogs, actionWatch, actionFinishedPods, actionPin, actionCerts},
+	LogState: {actionBack, actionSwitchView, actionPretty, actionSeverity, actionGrep, actionLineNumbers, actionTimestamps,
+		actionFullLogs, actionSince, actionWindow, actionFollow, actionAppend, actionPrevContainer, actionNextContainer,
+		actionMerged, actionCopyCommand, actionDebug},
+	DescribeState:        {actionBack, actionSwitchView, actionTimes, actionMetadata, actionCordon, actionNode, actionScale, actionReconcile},
+	NodeState:            {actionBack, actionTimes, actionCordon},
+	ContainerSelectState: {actionBack, actionSelect, actionDescribe, actionMerged, actionDebug},
+	YamlState:            {actionBack, actionHighlight, actionLineNumbers, actionManagedFields, actionLastApplied, actionEdit},
+	EtcdSelectState:      {actionBack, actionSelect},
+	DiagnosticsState:     {actionBack, actionSelect},
+	ClusterLogsState: {actionBack, actionPretty, actionSeverity, actionGrep, actionLineNumbers, actionTimestamps,
+		actionFullLogs, actionSince, actionWindow},
+	DashboardState:  {actionBack, actionSelect, actionTimes},
+	SplitLogsState:  {actionBack, actionFocus, actionSplit, actionSelect, actionPaneContainer, actionPretty, actionSeverity, actionGrep},
+	MetricsState:    {actionBack},
+	EventsState:     {actionBack, actionTimes, actionSortEvents},
+	DiffState:       {actionBack},
+	ChangeState:     {actionBack},
+	RolloutState:    {actionBack},
+	ConditionsState: {actionBack, actionTimes},
+	QuorumState:     {actionBack},
+	CertsState:      {actionBack, actionTimes},
+}
+
+// keyMap holds the bindings in use: the defaults with the overrides from the config file applied
+type keyMap struct {
+	keys map[keyAction][]string
+	// custom is the keys section the bindings were built from, written back when the config is saved
+	custom map[string]string
+}
+
+// defaultKeyMap returns the built-in bindings
+func defaultKeyMap() keyMap {
+	k := keyMap{keys: map[keyAction][]string{}}
+	for action, binding := range keyDefaults {
+		k.keys[action] = binding.keys
+	}
+	return k
+}
+
+// isReservedKey reports whether a key can't be bound: ctrl+c always quits and digits jump, to a pod or up the breadcrumb
+func isReservedKey(key string) bool {
+	return key == "ctrl+c" || isJumpKey(key)
+}
+
+// parseKeyMap applies the keys section of the config file, action → comma-separated keys, over the defaults
+// Unknown actions, reserved and navigation keys and two actions of one screen sharing a key are rejected, leaving the defaults in use
+func parseKeyMap(custom map[string]string) (keyMap, error) {
+	k, err := bindKeys(custom)
+	if err != nil {
+		// The keys section is still saved back as written, so fixing it by hand is all it takes
+		k = defaultKeyMap()
+		k.custom = custom
+	}
+	return k, err
+}
+
+// bindKeys applies the keys section of the config onto the default bindings
+func bindKeys(custom map[string]string) (keyMap, error) {
+	k := defaultKeyMap()
+	if len(custom) == 0 {
+		return k, nil
+	}
+	for name, value := range custom {
+		action := keyAction(name)
+		if _, ok := keyDefaults[action]; !ok {
+			return keyMap{}, fmt.Errorf("unknown key action %q", name)
+		}
+		var keys []string
+		for _, key := range strings.Split(value, ",") {
+			// A lone comma can't be split out, so space is written out like bubbletea names it
+			key = strings.TrimSpace(key)
+			if key == "space" {
+				key = " "
+			}
+			if key == "" {
+				continue
+			}
+			if isReservedKey(key) {
+				return keyMap{}, fmt.Errorf("key %q of %s is reserved", key, name)
+			}
+			keys = append(keys, key)
+		}
+		if len(keys) == 0 {
+			return keyMap{}, fmt.Errorf("no key given for %s", name)
+		}
+		k.keys[action] = keys
+	}
+	if err := k.validate(); err != nil {
+		return keyMap{}, err
+	}
+	k.custom = custom
+	return k, nil
+}
+
+// validate checks that no key does two things on the same screen, nor takes a key the screen moves with
+func (k keyMap) validate() error {
+	states := make([]AppState, 0, len(screenActions))
+	for state := range screenActions {
+		states = append(states, state)
+	}
+	// Sorted so the same config always reports the same conflict
+	sort.Slice(states, func(i, j int) bool { return states[i] < states[j] })
+	navigation := navigationKeys()
+	for _, state := range states {
+		bound := map[string]keyAction{}
+		for _, action := range slices.Concat(globalActions, screenActions[state]) {
+			for _, key := range k.keys[action] {
+				if other, ok := bound[key]; ok && other != action {
+					return fmt.Errorf("key %q is bound to both %s and %s", keyName(key), other, action)
+				}
+				// The defaults already take some of them, e.g. f to follow, and stay as they are
+				if slices.Contains(navigation, key) && !slices.Contains(keyDefaults[action].keys, key) {
+					return fmt.Errorf("key %q of %s is needed to move through lists and scroll", keyName(key), action)
+				}
+				bound[key] = action
+			}
+		}
+	}
+	return nil
+}
+
+// navigationKeys are the keys the lists and viewports move with, which a binding would take over
+func navigationKeys() []string {
+	l, v := list.DefaultKeyMap(), viewport.DefaultKeyMap()
+	var keys []string
+	for _, binding := range []key.Binding{l.CursorUp, l.CursorDown, l.PrevPage, l.NextPage, l.GoToStart, l.GoToEnd, l.Filter,
+		v.Up, v.Down, v.Left, v.Right, v.PageUp, v.PageDown, v.HalfPageUp, v.HalfPageDown} {
+		keys = append(keys, binding.Keys()...)
+	}
+	return keys
+}
+
+// action returns what key does on the screen of state, or "" when nothing is bound to it there
+func (k keyMap) action(state AppState, key string) keyAction {
+	for _, action := range slices.Concat(globalActions, screenActions[state]) {
+		if slices.Contains(k.keys[action], key) {
+			return action
+		}
+	}
+	return ""
+}
+
+// keyName renders a key for display, spelling out the invisible space
+func keyName(key string) string {
+	if key == " " {
+		return "space"
+	}
+	return key
+}
+
+// keyHelp renders the keys bound to an action for the help lines, e.g. "q/esc"
+func (k keyMap) keyHelp(action keyAction) string {
+	names := make([]string, len(k.keys[action]))
+	for i, key := range k.keys[action] {
+		names[i] = keyName(key)
+	}
+	return strings.Join(names, "/")
+}
+
+// hint renders an action for the help lines with the keys bound to it, e.g. "d: describe"
+func (k keyMap) hint(action keyAction, text string) string {
+	return k.keyHelp(action) + ": " + text
+}
+
+// helpStart begins a help line with entries, e.g. "• d: describe • y: yaml"
+func helpStart(entries ...string) string {
+	return "• " + strings.Join(entries, " • ")
+}
+
+// helpLine continues a help line with entries
+func helpLine(entries ...string) string {
+	return " " + helpStart(entries...)
+}
+
+// scrollHelp begins the help line of the viewport screens
+func (m Model) scrollHelp() string {
+	return helpStart(m.keys.hint(actionBack, "back"), "↑/↓: scroll")
+}
+
+// renderKeys lays out the bindings of a screen, one action per line
+func (k keyMap) renderKeys(state AppState) string {
+	actions := slices.Concat(screenActions[state], globalActions)
+	names := make([]string, len(actions))
+	width := 0
+	for i, action := range actions {
+		keys := make([]string, len(k.keys[action]))
+		for j, key := range k.keys[action] {
+			keys[j] = keyName(key)
+		}
+		names[i] = strings.Join(keys, "/")
+		width = max(width, len(names[i]))
+	}
+	var out strings.Builder
+	for i, action := range actions {
+		fmt.Fprintf(&out, "%-*s  %s (%s)\n", width, names[i], keyDefaults[action].help, action)
+	}
+	return out.String()
+}
+
+// openKeysOverlay shows the bindings of the current screen over it, until closed with back or the keys key again
+func (m *Model) openKeysOverlay() {
+	m.showKeys = true
+	m.keysView.SetContent(m.keys.renderKeys(m.state))
+	m.keysView.GotoTop()
+}
+
+// updateKeysOverlay closes the overlay or scrolls it; no other key reaches the screen underneath
+func (m *Model) updateKeysOverlay(msg tea.KeyMsg) tea.Cmd {
+	key := msg.String()
+	if slices.Contains(m.keys.keys[actionBack], key) || slices.Contains(m.keys.keys[actionKeys], key) {
+		m.showKeys = false
+		return nil
+	}
+	var cmd tea.Cmd
+	m.keysView, cmd = m.keysView.Update(msg)
+	return cmd
+}
+
+// keysOverlayView renders the overlay in place of the current screen's body
+func (m Model) keysOverlayView() string {
+	header := m.theme.header.Render("Key bindings")
+	help := m.theme.help.Render("• esc/?: close • ↑/↓: scroll • keys can be rebound in the keys section of the config file")
+	return fmt.Sprintf("%s\n%s\n%s", header, m.keysView.View(), help)
+}
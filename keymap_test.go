@@ -0,0 +1,137 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestDefaultKeyMapValid(t *testing.T) {
+	if err := defaultKeyMap().validate(); err != nil {
+		t.Fatalf("default bindings conflict: %v", err)
+	}
+	for state, actions := range screenActions {
+		for _, action := range actions {
+			if _, ok := keyDefaults[action]; !ok {
+				t.Errorf("screen %v lists %s, which has no default binding", state, action)
+			}
+		}
+	}
+}
+
+func TestParseKeyMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		custom  map[string]string
+		wantErr string
+	}{
+		{name: "rebinding", custom: map[string]string{"describe": "i", "back": "q, esc, backspace", "mark": "space"}},
+		{name: "unknown action", custom: map[string]string{"explode": "x"}, wantErr: `unknown key action "explode"`},
+		{name: "conflict", custom: map[string]string{"describe": "l"}, wantErr: `key "l" is bound to both containers and describe`},
+		{name: "conflict with every screen", custom: map[string]string{"pretty": "r"}, wantErr: `key "r" is bound to both refresh and pretty`},
+		{name: "reserved", custom: map[string]string{"quit": "ctrl+c"}, wantErr: "reserved"},
+		{name: "digit", custom: map[string]string{"describe": "3"}, wantErr: "reserved"},
+		{name: "scrolls", custom: map[string]string{"describe": "j"}, wantErr: `key "j" of describe is needed to move through lists and scroll`},
+		{name: "filters", custom: map[string]string{"yaml": "/"}, wantErr: "needed to move through lists"},
+		{name: "empty", custom: map[string]string{"describe": " , "}, wantErr: "no key given for describe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := parseKeyMap(tt.custom)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseKeyMap() error = %v, want %q", err, tt.wantErr)
+				}
+				if k.action(ListState, "d") != actionDescribe {
+					t.Errorf("rejected bindings didn't fall back to the defaults")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseKeyMap() error = %v", err)
+			}
+			if got := k.action(ListState, "i"); got != actionDescribe {
+				t.Errorf("i in the list = %q, want describe", got)
+			}
+			if got := k.action(ListState, "d"); got != "" {
+				t.Errorf("d in the list = %q after rebinding describe, want nothing", got)
+			}
+			if got := k.action(LogState, "backspace"); got != actionBack {
+				t.Errorf("backspace in the logs = %q, want back", got)
+			}
+			if got := k.action(ListState, " "); got != actionMark {
+				t.Errorf("space in the list = %q, want mark", got)
+			}
+		})
+	}
+
+	// A default that scrolls elsewhere, such as f to follow, can still be kept alongside another key
+	if _, err := parseKeyMap(map[string]string{"follow": "f, F"}); err != nil {
+		t.Errorf("parseKeyMap() of a default navigation key error = %v", err)
+	}
+}
+
+func TestReboundKeys(t *testing.T) {
+	m, _ := newTestModel([]runtime.Object{testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))})
+	m.applyConfig(Config{TailLines: defaultTailLines, Keys: map[string]string{"describe": "i", "back": "backspace"}})
+	m = update(t, m, m.loadPods()())
+
+	m = update(t, m, keyMsg("d"))
+	if m.state != ListState {
+		t.Fatalf("d went to %v after describe was rebound, want the pod list", m.state)
+	}
+	m.width = 400
+	if help := ansi.Strip(m.stateView()); !strings.Contains(help, "• i: describe •") {
+		t.Errorf("help of the pod list doesn't show the rebound describe:\n%s", help)
+	}
+	m = update(t, m, keyMsg("i"))
+	if m.state != DescribeState {
+		t.Fatalf("i went to %v, want the describe", m.state)
+	}
+	if help := ansi.Strip(m.stateView()); !strings.Contains(help, "• backspace: back •") {
+		t.Errorf("help of the describe doesn't show the rebound back:\n%s", help)
+	}
+	m = update(t, m, keyMsg("esc"))
+	if m.state != DescribeState {
+		t.Fatalf("esc went to %v after back was rebound, want to stay", m.state)
+	}
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyBackspace})
+	if m.state != ListState {
+		t.Errorf("backspace went to %v, want the pod list", m.state)
+	}
+	if got := m.currentConfig().Keys["describe"]; got != "i" {
+		t.Errorf("saved config binds describe to %q, want i", got)
+	}
+}
+
+func TestKeysOverlay(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("?"))
+	if !m.showKeys {
+		t.Fatal("? didn't open the keys overlay")
+	}
+	view := m.View()
+	for _, want := range []string{"Key bindings", "describe (describe)", "space"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("keys overlay missing %q:\n%s", want, view)
+		}
+	}
+	// The keys of every screen come last, below what fits the test terminal
+	if keys := m.keys.renderKeys(ListState); !strings.Contains(keys, "ctrl+r  refresh the pods") {
+		t.Errorf("keys of the pod list miss the hard refresh:\n%s", keys)
+	}
+	// Keys of the screen underneath don't act while the overlay is open
+	m = update(t, m, keyMsg("d"))
+	if m.state != ListState || !m.showKeys {
+		t.Errorf("d with the overlay open: state %v, overlay %v", m.state, m.showKeys)
+	}
+	m = update(t, m, keyMsg("esc"))
+	if m.showKeys || m.state != ListState {
+		t.Errorf("esc: overlay %v, state %v, want the pod list", m.showKeys, m.state)
+	}
+}
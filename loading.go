@@ -0,0 +1,42 @@
+package main
+
+import (
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startLoading shows a spinner with text in the viewport until stopLoading, so a screen entered before its data arrives doesn't look frozen
+func (m *Model) startLoading(text string) tea.Cmd {
+	m.loading = text
+	m.content = ""
+	m.refreshViewport()
+	return m.spinner.Tick
+}
+
+// stopLoading drops the spinner once the data arrived or failed
+func (m *Model) stopLoading() {
+	m.loading = ""
+}
+
+// updateSpinners advances whichever spinner a tick belongs to; each spinner ignores the ticks of the others
+// The viewport's spinner also turns in the footer during a hard refresh
+func (m *Model) updateSpinners(msg spinner.TickMsg) tea.Cmd {
+	var listCmd, loadingCmd tea.Cmd
+	m.list, listCmd = m.list.Update(msg)
+	if m.loading != "" || m.hardRefreshing {
+		m.spinner, loadingCmd = m.spinner.Update(msg)
+		m.refreshViewport()
+	}
+	return tea.Batch(listCmd, loadingCmd)
+}
+
+// describeLoadingText names what a describe is gathering while it runs
+func (m *Model) describeLoadingText() string {
+	switch {
+	case m.describeEtcd:
+		return "Gathering etcd details…"
+	case m.describeOne != "":
+		return "Gathering container details…"
+	}
+	return "Gathering pod details…"
+}
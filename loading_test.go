@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDescribeShowsSpinnerUntilLoaded(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, tea.WindowSizeMsg{Width: 80, Height: 20})
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+
+	// The describe screen opens right away, before the describe returns
+	m = update(t, m, keyMsg("d"))
+	if m.state != DescribeState {
+		t.Fatalf("state = %v, want DescribeState immediately", m.state)
+	}
+	if view := m.View(); !strings.Contains(view, "Gathering pod details…") {
+		t.Errorf("describe view while loading missing the spinner text:\n%s", view)
+	}
+
+	// Ticks keep the spinner moving while loading
+	if cmd := m.updateSpinners(m.spinner.Tick().(spinner.TickMsg)); cmd == nil {
+		t.Error("a spinner tick while loading scheduled no next tick")
+	}
+
+	m = update(t, m, describeLoadedMsg{content: "Name: etcd-main-0\n"})
+	if m.loading != "" || !strings.Contains(m.View(), "Name: etcd-main-0") {
+		t.Errorf("after loading: loading = %q, view:\n%s", m.loading, m.View())
+	}
+}
+
+func TestDescribeSpinnerStopsOnError(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("D"))
+	if m.loading != "Gathering etcd details…" {
+		t.Fatalf("loading = %q, want the etcd text", m.loading)
+	}
+	m = update(t, m, errMsg{errors.New("boom")})
+	if m.loading != "" {
+		t.Errorf("loading = %q after an error, want the spinner gone", m.loading)
+	}
+}
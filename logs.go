@@ -0,0 +1,392 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// logEntry is a single log line, decoded from structured JSON when possible
+// etcd (zap) and etcd-backup-restore (logrus) both emit one JSON object per line
+type logEntry struct {
+	raw    string
+	json   bool
+	time   string
+	level  string
+	msg    string
+	fields []string // remaining "key=value" pairs, sorted by key
+}
+
+// Well-known keys used by the JSON loggers we care about, in order of preference
+var (
+	logTimeKeys  = []string{"ts", "time", "timestamp"}
+	logLevelKeys = []string{"level", "severity", "lvl"}
+	logMsgKeys   = []string{"msg", "message"}
+)
+
+// parseLogLine decodes a JSON-per-line log entry
+// Lines that are not JSON objects are returned with json set to false
+func parseLogLine(line string) logEntry {
+	entry := logEntry{raw: line}
+
+	trimmed := strings.TrimSpace(line)
+	// With timestamps enabled the kubelet prefixes each line with an RFC3339 time
+	kubeletTime := ""
+	if ts, rest, ok := strings.Cut(trimmed, " "); ok && strings.HasPrefix(rest, "{") {
+		if _, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+			kubeletTime, trimmed = ts, rest
+		}
+	}
+	if !strings.HasPrefix(trimmed, "{") {
+		return entry
+	}
+
+	// UseNumber keeps large values such as raft revisions from losing precision
+	decoder := json.NewDecoder(strings.NewReader(trimmed))
+	decoder.UseNumber()
+	fields := map[string]interface{}{}
+	if err := decoder.Decode(&fields); err != nil {
+		return entry
+	}
+
+	entry.json = true
+	entry.time = formatLogTime(popLogField(fields, logTimeKeys))
+	if entry.time == "" {
+		entry.time = kubeletTime
+	}
+	entry.level = normalizeLogLevel(formatLogValue(popLogField(fields, logLevelKeys)))
+	entry.msg = formatLogValue(popLogField(fields, logMsgKeys))
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		entry.fields = append(entry.fields, fmt.Sprintf("%s=%s", k, formatLogValue(fields[k])))
+	}
+
+	return entry
+}
+
+// popLogField removes and returns the first present key from fields
+func popLogField(fields map[string]interface{}, keys []string) interface{} {
+	for _, k := range keys {
+		if v, ok := fields[k]; ok {
+			delete(fields, k)
+			return v
+		}
+	}
+	return nil
+}
+
+// formatLogValue renders a decoded JSON value as a compact string
+func formatLogValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	default:
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(v); err != nil {
+			return fmt.Sprint(v)
+		}
+		return strings.TrimSpace(buf.String())
+	}
+}
+
+// formatLogTime renders the timestamp of a log entry
+// zap can be configured to emit epoch seconds, so numeric values are converted to RFC3339
+func formatLogTime(v interface{}) string {
+	if n, ok := v.(json.Number); ok {
+		if secs, err := strconv.ParseFloat(n.String(), 64); err == nil {
+			return time.UnixMilli(int64(secs * 1000)).UTC().Format("2006-01-02T15:04:05.000Z07:00")
+		}
+	}
+	return formatLogValue(v)
+}
+
+// normalizeLogLevel maps the level spellings of different loggers onto one set
+func normalizeLogLevel(level string) string {
+	level = strings.ToLower(level)
+	switch level {
+	case "warning":
+		return "warn"
+	case "err":
+		return "error"
+	}
+	return level
+}
+
+// logLevelStyle picks the color used for a log level
+func (th theme) logLevelStyle(level string) lipgloss.Style {
+	switch level {
+	case "error", "fatal", "panic", "dpanic":
+		return th.logError
+	case "warn":
+		return th.logWarn
+	case "info":
+		return th.logInfo
+	}
+	return th.logDebug
+}
+
+// prettyPrintLogs renders JSON log lines as colorized, column-aligned text
+// Lines that don't parse as JSON are passed through unchanged
+func prettyPrintLogs(content string, th theme) string {
+	lines := strings.Split(content, "\n")
+	entries := make([]logEntry, len(lines))
+	timeWidth := 0
+	for i, line := range lines {
+		entries[i] = parseLogLine(line)
+		if entries[i].json && len(entries[i].time) > timeWidth {
+			timeWidth = len(entries[i].time)
+		}
+	}
+
+	var out strings.Builder
+	for i, entry := range entries {
+		if i > 0 {
+			out.WriteByte('\n')
+		}
+		if !entry.json {
+			out.WriteString(entry.raw)
+			continue
+		}
+
+		// Pad before styling so ANSI escape codes don't break the alignment
+		out.WriteString(th.logTime.Render(fmt.Sprintf("%-*s", timeWidth, entry.time)))
+		out.WriteString(" ")
+		out.WriteString(th.logLevelStyle(entry.level).Render(fmt.Sprintf("%-5s", strings.ToUpper(entry.level))))
+		out.WriteString(" ")
+		out.WriteString(entry.msg)
+		if len(entry.fields) > 0 {
+			out.WriteString("  ")
+			out.WriteString(th.logField.Render(strings.Join(entry.fields, " ")))
+		}
+	}
+
+	return out.String()
+}
+
+// logSeverity is the minimum level a log line needs to be shown
+type logSeverity int
+
+const (
+	severityAll logSeverity = iota
+	severityInfo
+	severityWarn
+	severityError
+)
+
+func (s logSeverity) String() string {
+	switch s {
+	case severityInfo:
+		return "info+"
+	case severityWarn:
+		return "warn+"
+	case severityError:
+		return "error"
+	}
+	return "all"
+}
+
+// next cycles through the severity filters, wrapping back to showing everything
+func (s logSeverity) next() logSeverity {
+	if s == severityError {
+		return severityAll
+	}
+	return s + 1
+}
+
+var (
+	// klog lines start with the level letter followed by MMDD, e.g. "E0101 12:00:00.000000"
+	klogLevelPattern = regexp.MustCompile(`^([IWEF])\d{4} `)
+	// logfmt style lines carry an explicit level=<level> pair
+	logfmtLevelPattern = regexp.MustCompile(`\blevel=("?)(\w+)`)
+)
+
+// severityFromLevel maps a normalized level name onto a severity
+func severityFromLevel(level string) (logSeverity, bool) {
+	switch normalizeLogLevel(level) {
+	case "debug", "trace":
+		return severityAll, true
+	case "info":
+		return severityInfo, true
+	case "warn":
+		return severityWarn, true
+	case "error", "fatal", "panic", "dpanic":
+		return severityError, true
+	}
+	return severityAll, false
+}
+
+// lineSeverity detects the level of a single log line
+// Structured JSON is preferred, with substring matching as a fallback for plain text
+func lineSeverity(line string) (logSeverity, bool) {
+	if entry := parseLogLine(line); entry.json {
+		return severityFromLevel(entry.level)
+	}
+	if match := klogLevelPattern.FindStringSubmatch(line); match != nil {
+		switch match[1] {
+		case "I":
+			return severityInfo, true
+		case "W":
+			return severityWarn, true
+		default:
+			return severityError, true
+		}
+	}
+	if match := logfmtLevelPattern.FindStringSubmatch(line); match != nil {
+		return severityFromLevel(match[2])
+	}
+	return severityAll, false
+}
+
+// filterLogs keeps only lines at or above the minimum severity that also match grep, when set
+// Lines without a detectable level (e.g. stack traces) inherit the level of the line before them
+func filterLogs(content string, min logSeverity, grep *regexp.Regexp) string {
+	if min == severityAll && grep == nil {
+		return content
+	}
+
+	lines := strings.Split(content, "\n")
+	var kept []string
+	for i, keep := range logMask(lines, min, grep) {
+		if keep {
+			kept = append(kept, lines[i])
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+// logMask reports for each line whether it passes both the minimum severity and grep, see filterLogs
+func logMask(lines []string, min logSeverity, grep *regexp.Regexp) []bool {
+	keep := severityMask(lines, min)
+	if grep == nil {
+		return keep
+	}
+	for i, line := range lines {
+		keep[i] = keep[i] && grep.MatchString(line)
+	}
+	return keep
+}
+
+// parseLogGrep compiles a log grep pattern once, so every refresh filters without recompiling
+// An empty pattern turns the grep off
+func parseLogGrep(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	grep, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log grep pattern %q: %w", pattern, err)
+	}
+	return grep, nil
+}
+
+// promptLogGrep asks for the pattern log lines must match; an invalid pattern keeps the current one
+func (m *Model) promptLogGrep() tea.Cmd {
+	current := ""
+	if m.logGrep != nil {
+		current = m.logGrep.String()
+	}
+	return m.openPrompt("grep", current, func(m *Model, value string) tea.Cmd {
+		grep, err := parseLogGrep(value)
+		if err != nil {
+			return m.setStatus(err.Error())
+		}
+		m.logGrep = grep
+		m.refreshViewport()
+		return nil
+	})
+}
+
+// severityMask reports for each line whether it passes the minimum severity, see filterLogs
+func severityMask(lines []string, min logSeverity) []bool {
+	keep := make([]bool, len(lines))
+	current := severityInfo
+	for i, line := range lines {
+		if severity, ok := lineSeverity(line); ok {
+			current = severity
+		}
+		keep[i] = min == severityAll || current >= min
+	}
+	return keep
+}
+
+// logSinceSteps are the windows the since toggle cycles through; zero means no limit
+var logSinceSteps = []time.Duration{0, 5 * time.Minute, 15 * time.Minute, time.Hour}
+
+// nextLogSince returns the window after current, wrapping back to no limit
+func nextLogSince(current time.Duration) time.Duration {
+	for i, step := range logSinceSteps {
+		if step == current {
+			return logSinceSteps[(i+1)%len(logSinceSteps)]
+		}
+	}
+	return logSinceSteps[0]
+}
+
+// formatLogSince renders a since window for the help line, e.g. "5m" or "all"
+func formatLogSince(since time.Duration) string {
+	switch {
+	case since <= 0:
+		return "all"
+	case since%time.Hour == 0:
+		return fmt.Sprintf("%dh", since/time.Hour)
+	}
+	return fmt.Sprintf("%dm", since/time.Minute)
+}
+
+// tailBuffer is an io.Writer that retains only the last max bytes written to it
+// It keeps memory bounded when streaming a full log dump from a long-running pod
+type tailBuffer struct {
+	buf       []byte
+	max       int
+	truncated bool
+}
+
+func newTailBuffer(max int) *tailBuffer {
+	return &tailBuffer{max: max}
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.buf = append(t.buf, p...)
+	// Compact lazily so we aren't copying on every small write
+	if len(t.buf) > 2*t.max {
+		t.buf = append(t.buf[:0], t.buf[len(t.buf)-t.max:]...)
+		t.truncated = true
+	}
+	return len(p), nil
+}
+
+// Truncated reports whether any content was dropped from the front
+func (t *tailBuffer) Truncated() bool {
+	return t.truncated || len(t.buf) > t.max
+}
+
+// String returns the retained content, starting at a line boundary when truncated
+func (t *tailBuffer) String() string {
+	data := t.buf
+	if len(data) > t.max {
+		data = data[len(data)-t.max:]
+	}
+	if t.Truncated() {
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			data = data[i+1:]
+		}
+	}
+	return string(data)
+}
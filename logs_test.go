@@ -0,0 +1,179 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTailBuffer(t *testing.T) {
+	tests := []struct {
+		name          string
+		max           int
+		writes        []string
+		want          string
+		wantTruncated bool
+	}{
+		{
+			name:   "fits",
+			max:    64,
+			writes: []string{"line 1\n", "line 2\n"},
+			want:   "line 1\nline 2\n",
+		},
+		{
+			name:          "drops the front at a line boundary",
+			max:           16,
+			writes:        []string{"line 1\n", "line 2\n", "line 3\n"},
+			want:          "line 2\nline 3\n",
+			wantTruncated: true,
+		},
+		{
+			name:          "compacts after many writes",
+			max:           8,
+			writes:        []string{strings.Repeat("x", 30) + "\n", "tail\n"},
+			want:          "tail\n",
+			wantTruncated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := newTailBuffer(tt.max)
+			for _, w := range tt.writes {
+				if _, err := buf.Write([]byte(w)); err != nil {
+					t.Fatalf("Write() error = %v", err)
+				}
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+			if got := buf.Truncated(); got != tt.wantTruncated {
+				t.Errorf("Truncated() = %v, want %v", got, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestFilterLogsBySeverity(t *testing.T) {
+	content := strings.Join([]string{
+		`{"level":"debug","msg":"d"}`,
+		`{"level":"info","msg":"i"}`,
+		`{"level":"warn","msg":"w"}`,
+		`E0101 12:00:00.000000       1 main.go:1] klog error`,
+		`  stack trace line`,
+		`time="now" level=info msg="logfmt info"`,
+	}, "\n")
+
+	tests := []struct {
+		min  logSeverity
+		want []string
+	}{
+		{min: severityAll, want: strings.Split(content, "\n")},
+		{min: severityWarn, want: []string{
+			`{"level":"warn","msg":"w"}`,
+			`E0101 12:00:00.000000       1 main.go:1] klog error`,
+			`  stack trace line`,
+		}},
+		{min: severityError, want: []string{
+			`E0101 12:00:00.000000       1 main.go:1] klog error`,
+			`  stack trace line`,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.min.String(), func(t *testing.T) {
+			got := filterLogs(content, tt.min, nil)
+			if want := strings.Join(tt.want, "\n"); got != want {
+				t.Errorf("filterLogs() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestNextLogSince(t *testing.T) {
+	since := time.Duration(0)
+	var got []string
+	for range logSinceSteps {
+		since = nextLogSince(since)
+		got = append(got, formatLogSince(since))
+	}
+	if want := "5m,15m,1h,all"; strings.Join(got, ",") != want {
+		t.Errorf("since cycle = %s, want %s", strings.Join(got, ","), want)
+	}
+	if next := nextLogSince(7 * time.Minute); next != 0 {
+		t.Errorf("nextLogSince(unknown) = %v, want a reset to no limit", next)
+	}
+}
+
+func TestEmptyLogsNotice(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("l"))
+	m = update(t, m, containersLoadedMsg{[]Container{{Name: "etcd"}, {Name: "backup-restore"}}})
+	m = update(t, m, logsLoadedMsg{content: "\n"})
+
+	want := "No logs available for etcd (it may not have started yet)"
+	if got := m.viewportContent(); got != want {
+		t.Errorf("viewportContent() = %q, want %q", got, want)
+	}
+
+	// Once the container logs something the notice goes away
+	m = update(t, m, logsLoadedMsg{content: "started\n"})
+	if got := m.viewportContent(); strings.Contains(got, "No logs available") {
+		t.Errorf("viewportContent() = %q, want the logs", got)
+	}
+}
+
+func TestFilterLogsWithGrep(t *testing.T) {
+	content := strings.Join([]string{
+		`{"level":"info","msg":"added member 8e9e05c52164694d"}`,
+		`{"level":"error","msg":"lost leader 8e9e05c52164694d"}`,
+		`{"level":"error","msg":"slow fdatasync"}`,
+	}, "\n")
+	grep, err := parseLogGrep(`8e9e05c5`)
+	if err != nil {
+		t.Fatalf("parseLogGrep() error = %v", err)
+	}
+
+	// Severity and grep combine, so only error lines about the member stay
+	got := filterLogs(content, severityError, grep)
+	if want := `{"level":"error","msg":"lost leader 8e9e05c52164694d"}`; got != want {
+		t.Errorf("filterLogs() = %q, want %q", got, want)
+	}
+
+	if grep, err := parseLogGrep(""); grep != nil || err != nil {
+		t.Errorf("parseLogGrep(\"\") = %v, %v, want the grep off", grep, err)
+	}
+	if _, err := parseLogGrep("member ("); err == nil || !strings.Contains(err.Error(), `invalid log grep pattern "member ("`) {
+		t.Errorf("parseLogGrep() error = %v, want the invalid pattern named", err)
+	}
+}
+
+func TestLogGrepPrompt(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("l"))
+	m = update(t, m, containersLoadedMsg{[]Container{{Name: "etcd"}, {Name: "backup-restore"}}})
+	m = update(t, m, logsLoadedMsg{content: "leader elected\nsnapshot saved\n"})
+
+	m = update(t, m, keyMsg("g"))
+	m = update(t, m, keyMsg("snap"))
+	m = update(t, m, keyMsg("enter"))
+	if got := m.viewportContent(); strings.Contains(got, "leader") || !strings.Contains(got, "snapshot saved") {
+		t.Errorf("viewportContent() = %q, want only the matching line", got)
+	}
+
+	// An invalid pattern is reported and the previous one stays
+	m = update(t, m, keyMsg("g"))
+	m = update(t, m, keyMsg("["))
+	m = update(t, m, keyMsg("enter"))
+	if m.logGrep == nil || m.logGrep.String() != "snap" || !strings.Contains(m.status, "invalid log grep pattern") {
+		t.Errorf("grep = %v with status %q, want snap kept and the error shown", m.logGrep, m.status)
+	}
+
+	m.logGrep, _ = parseLogGrep("nothing")
+	m.refreshViewport()
+	if got := m.viewportContent(); !strings.Contains(got, "No lines match nothing") {
+		t.Errorf("viewportContent() = %q, want a notice that nothing matches", got)
+	}
+}
@@ -1,14 +1,22 @@
 package main
 
 import (
-	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
+	"log/slog"
+	"net/http"
 	"os"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -16,28 +24,81 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
 // Pod represents a simplified view of a Kubernetes pod for our list
+// The JSON tags define the --output format, so renaming a field is a breaking change for scripts
 type Pod struct {
-	Name      string
-	Namespace string
-	Status    string
-	Ready     string
-	Age       string
-	Node      string
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Status    string `json:"status"`
+	Ready     string `json:"ready"`
+	Restarts  int32  `json:"restarts"`
+	Age       string `json:"age"`
+	Node      string `json:"node"`
+	PodIP     string `json:"podIP,omitempty"`     // empty until the pod got an address, e.g. while Pending
+	HostIP    string `json:"hostIP,omitempty"`    // address of the node, empty until the pod is scheduled
+	AllReady  bool   `json:"allReady"`            // true when every container in the pod reports ready
+	Role      string `json:"role,omitempty"`      // leader, follower or learner, from the Etcd status members
+	Member    string `json:"member,omitempty"`    // member status such as Ready; empty while the member hasn't registered yet
+	Backup    string `json:"backup,omitempty"`    // readiness of the backup-restore sidecar; empty without one
+	OOMKilled string `json:"oomKilled,omitempty"` // containers that were OOMKilled with their exit codes, see oomKilledContainers
+	Marked    bool   `json:"-"`                   // picked for the diff view, see toggleDiffMark
+	// ShowNamespace prefixes the title with the namespace, which --all-namespaces needs to tell pods apart
+	ShowNamespace bool `json:"-"`
+	// Terminating is set once the pod is being deleted, with Status saying so in place of the phase
+	Terminating bool `json:"terminating,omitempty"`
+	// Finished is set for pods in the Succeeded or Failed phase, which the list hides unless asked, see hidesFinished
+	Finished bool `json:"-"`
+	// Pinned pods float to the top of the list, see togglePin
+	Pinned bool `json:"-"`
 }
 
 // Implement the list.Item interface for bubbletea list component
-func (p Pod) FilterValue() string { return p.Name }
-func (p Pod) Title() string       { return p.Name }
+func (p Pod) FilterValue() string {
+	if p.ShowNamespace {
+		return p.Namespace + "/" + p.Name
+	}
+	return p.Name
+}
+func (p Pod) Title() string {
+	title := p.Name
+	if p.ShowNamespace {
+		title = p.Namespace + "/" + title
+	}
+	if p.Marked {
+		title = "* " + title
+	}
+	if p.Pinned {
+		title = pinMarker + title
+	}
+	if p.Role == "leader" {
+		title += " (leader)"
+	}
+	return title
+}
 func (p Pod) Description() string {
-	return fmt.Sprintf("Status: %s | Ready: %s | Node: %s | Age: %s",
-		p.Status, p.Ready, p.Node, p.Age)
+	member := "not registered"
+	if p.Member != "" {
+		member = fmt.Sprintf("%s, %s", p.Role, p.Member)
+	}
+	backup := p.Backup
+	if backup == "" {
+		backup = "none"
+	}
+	desc := fmt.Sprintf("Status: %s | Ready: %s | Restarts: %d | Member: %s | Backup: %s | Node: %s | Age: %s",
+		p.Status, p.Ready, p.Restarts, member, backup, p.Node, p.Age)
+	if p.OOMKilled != "" {
+		desc += " | OOMKilled: " + p.OOMKilled
+	}
+	return desc
 }
 
 // AppState represents the different screens our TUI can be in
@@ -49,135 +110,483 @@ const (
 	DescribeState
 	ContainerSelectState // New state for selecting a container
 	YamlState
+	MetricsState
+	EventsState
+	DiffState
+	RolloutState
+	ConditionsState
+	DashboardState
+	EtcdSelectState // Picking the Etcd when only the namespace was given
+	ClusterLogsState
+	QuorumState      // Cluster health from etcdctl run inside a member
+	DiagnosticsState // Checklist of what the viewer needs, on launch and with !
+	SplitLogsState   // The logs of the two marked pods side by side
+	NodeState        // Describe of the node the selected pod runs on
+	CertsState       // Certificate metadata of the TLS secrets the selected pod mounts
+	ChangeState      // The diff of what an edit changed, or a mutation would change with --dry-run
 )
 
 // Model holds our application state
 type Model struct {
 	state         AppState
+	navStack      []AppState // Screens to return to on esc/q, most recent last
 	list          list.Model
 	viewport      viewport.Model
 	pods          []Pod
 	selectedPod   Pod
 	kubeClient    kubernetes.Interface
 	dynamicClient dynamic.Interface
+	restConfig    *rest.Config // Needed to exec into pods; nil when exec is unavailable, e.g. in tests
+	contextName   string       // kube context the clients were built from, shown in the footer
 	namespace     string
 	etcdName      string
 	content       string
 	err           error
-	containerList list.Model // List for containers in a pod
-	containers    []string   // Names of containers in the selected pod
+	containerList list.Model  // List for containers in a pod
+	containers    []Container // Containers in the selected pod, init containers first
+	rawLogs       bool        // Show logs exactly as fetched instead of pretty-printing JSON lines
+	mergedLogs    bool        // LogState interleaves every container instead of showing the current one
+	minSeverity   logSeverity // Hide log lines below this level; m.content always keeps every line
+	plainYAML     bool        // Skip syntax highlighting, which can be slow for very large specs
+	yamlEtcd      bool        // YamlState shows the Etcd CR instead of the selected pod
+	describeEtcd  bool        // DescribeState shows the Etcd CR instead of the selected pod
+	describeOne   string      // DescribeState shows only this container of the selected pod when set
+	managedFields bool        // Include metadata.managedFields when showing the Etcd CR
+	lastApplied   bool        // YamlState shows the kubectl last-applied-configuration instead of the live object
+	absoluteTimes bool        // Show timestamps as RFC3339 instead of how long ago, see formatTimestamp
+	lineNumbers   bool        // Render a line number gutter in the log and YAML views
+	fullLogs      bool        // Fetch the whole log instead of only the last tailLines lines
+	logsTruncated bool        // The front of the full log was dropped to stay within maxLogBytes
+	refreshTick   int         // Generation of the live view refresh loop, so re-entering doesn't double it
+	statusFilter  []string    // Pod phases to show, from --status; empty shows every phase
+	labelSelector string      // Selects the etcd pods, from --selector; empty uses podLabelSelector's default
+	// pins are the pinned pods as namespace/name, saved in the config file; see togglePin
+	pins []string
+	// namespaceSelector picks the namespaces whose Etcds the selection screen offers, from --namespace-label-selector
+	namespaceSelector string
+	allPhases         bool   // The user widened the view past --status interactively
+	showFinished      bool   // List the pods in the Succeeded and Failed phases too, see hidesFinished
+	readOnly          bool   // Disable every action that mutates the cluster
+	dryRun            bool   // Send mutations as server-side dry runs and show their diff, from --dry-run
+	fieldManager      string // Recorded in managedFields for the changes made, from --field-manager
+	changeTitle       string // Header of the change on screen, e.g. "Dry run: scale etcd etcd-main to 5 replicas"
+	allNamespaces     bool   // List pods from every namespace, from --all-namespaces; m.namespace is empty then
+	insecure          bool   // TLS verification is off, which the footer keeps visible
+	status            string // Transient notice shown in the footer, e.g. a blocked action
+	statusID          int    // Incremented per notice so an old timer can't clear a newer one
+	podsPage          int    // Generation of the pod listing that further pages are appended to
+	loadingMore       bool   // Later pages of the pod listing are still being fetched
+	jumpBuffer        string // Digits typed for the quick jump, cleared after jumpTimeout
+	jumpID            int    // Incremented per jump key so only the latest timer clears the buffer
+	backupSummary     string // Backup health from the Etcd status, shown above the pod list
+	diffMarks         []Pod  // Pods marked for the diff view, in the order they were marked
+	// backupContainer names the backup sidecar, from --backup-container; empty uses a name heuristic
+	backupContainer string
+	// defaultContainer is opened by l without the selection screen, from --container; see defaultLogContainer
+	defaultContainer string
+	// skipToDefault is set while the container selection opened by l loads
+	skipToDefault bool
+	// appendLogs makes refreshing the log view append the lines since lastLogTime instead of replacing it
+	appendLogs  bool
+	lastLogTime time.Time
+	// logWindow shows the logs of a time range instead of the latest ones, in the log and cluster log views
+	logWindow logWindow
+	// tabbed is the log or describe view tab switched away from, dropped once the pair is left
+	tabbed *tabbedView
+
+	// prompt is an open text input, which takes all keys until submitted or cancelled
+	prompt *inputPrompt
+
+	// loading is shown next to spinner in the viewport while the screen's data is fetched, see startLoading
+	loading string
+	spinner spinner.Model
+
+	// operations are requests in flight that quitting would cut off, see beginOperation
+	operations []string
+	// quitAsked is set while the quit confirmation is open
+	quitAsked bool
+	// inline renders in the terminal's normal screen instead of the alt screen, from --no-alt-screen
+	inline bool
+	// startupChecks is set while the diagnostics shown on launch are open, which go on to diagnosticsNext
+	startupChecks   bool
+	diagnosticsNext AppState
+
+	// logSince limits logs to lines newer than this; zero fetches them regardless of age
+	logSince time.Duration
+	// logGrep hides log lines not matching it, from --log-grep or g; nil shows every line
+	logGrep *regexp.Regexp
+	// followLogs streams LogState or re-fetches it every refreshInterval, see toggleFollowLogs
+	followLogs bool
+	// logSource, logPodUID and previousLogs track the pod instance being followed, see setFollowedLogs
+	logSource    string
+	logPodUID    types.UID
+	previousLogs string
+	// logStream streams the followed container's logs, see startLogStream
+	logStream   *logStream
+	logStreamID int
+	// clusterLogs streams the etcd logs of every pod into ClusterLogsState, see startClusterLogs
+	clusterLogs   *clusterLogs
+	clusterLogsID int
+
+	// requestTimeout bounds every API call, see requestContext; zero disables the bound
+	requestTimeout time.Duration
+
+	// Preferences loaded from and saved to the config file at configPath
+	configPath      string        // Empty disables saving
+	tailLines       int64         // Log lines fetched unless fullLogs is set
+	timestamps      bool          // Ask the kubelet to prefix each log line with its timestamp
+	refreshInterval time.Duration // How often live views re-fetch their data
+	compactList     bool          // Show the pods as a one-row-per-pod table instead of two-line items
+	themeName       string        // Chosen theme preset; empty follows the terminal background
+
+	// theme holds every style; it only changes through setTheme
+	theme theme
+
+	// The split log view, see split.go
+	splitPanes [2]logPane
+	splitFocus int // Index of the pane that scrolls and takes pane commands
+
+	// keys are the bindings in use, see keymap.go; showKeys overlays them on the current screen
+	keys     keyMap
+	showKeys bool
+	keysView viewport.Model
+
+	// hardRefreshing is set while the fetches of ctrl+r run, see hardRefresh; the StatefulSet status is reported at the end
+	hardRefreshing       bool
+	hardRefreshID        int
+	refreshedStatefulSet string
+
+	// bundling names the pod whose logs are being bundled, see startLogBundle; bundleProgress is shown in the footer meanwhile
+	bundling       string
+	bundleProgress string
+
+	// The events view, see events.go: the events shown, what they may be about and the watch streaming more in
+	// freshEvents are those that streamed in lately, highlighted for eventHighlightDuration; eventsByCount sorts the most repeated first
+	events        []corev1.Event
+	eventsRelated map[string]bool
+	eventsByCount bool
+	freshEvents   map[types.UID]time.Time
+	eventsWatch   *eventsWatch
+	eventsWatchID int
+
+	// podWatch waits for a condition in the background, see watch.go; watchAlert reports it met until the next key
+	podWatch   *podWatch
+	podWatchID int
+	watchAlert string
+	// bell is where the terminal bell is rung, nil when there is no terminal
+	bell io.Writer
+
+	// Terminal size from the last WindowSizeMsg, see layout
+	width, height int
 }
 
+const (
+	// minWidth and minHeight are the smallest terminal the screens are laid out for, see tooSmall
+	minWidth  = 40
+	minHeight = 10
+	// defaultTailLines is how many log lines are fetched unless the full log is requested
+	defaultTailLines = 100
+	// maxLogBytes caps the log content held in memory; older output is dropped first
+	maxLogBytes = 8 << 20
+)
+
+// defaultRefreshInterval is how often live views such as metrics re-fetch their data
+const defaultRefreshInterval = 10 * time.Second
+
+// podPageSize is how many pods are listed per request
+// Etcd clusters are small, so a single page is the norm and later pages only appear with many leftover pods
+const podPageSize = 50
+
+// statusDuration is how long a transient footer notice stays visible
+const statusDuration = 3 * time.Second
+
 // Kubernetes client setup - this is where we establish connection to the cluster
-func setupKubeClient() (kubernetes.Interface, dynamic.Interface, error) {
+// The returned context name is the kubeconfig context the clients were built from
+// The rest config is returned as well because exec needs it to open a stream to the pod
+// configOverrides take precedence over the kubeconfig, see kubeConfigOverrides
+// API requests are logged to debugLog when it is set, see --debug
+func setupKubeClient(configOverrides *clientcmd.ConfigOverrides, debugLog *slog.Logger) (kubernetes.Interface, dynamic.Interface, *rest.Config, string, error) {
 	// Use kubeconfig from KUBECONFIG env var or default location (~/.kube/config)
 	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
-	configOverrides := &clientcmd.ConfigOverrides{}
 	kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
 	config, err := kubeConfig.ClientConfig()
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+		return nil, nil, nil, "", fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	if debugLog != nil {
+		config.Wrap(func(rt http.RoundTripper) http.RoundTripper { return debugTransport{next: rt, log: debugLog} })
+	}
+
+	// Resolve the active context name for display purposes only
+	// An explicit override wins over the kubeconfig's current-context
+	contextName := configOverrides.CurrentContext
+	if contextName == "" {
+		if rawConfig, err := kubeConfig.RawConfig(); err == nil {
+			contextName = rawConfig.CurrentContext
+		}
 	}
 
 	// Create the standard Kubernetes client for basic operations
 	kubeClient, err := kubernetes.NewForConfig(config)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+		return nil, nil, nil, "", fmt.Errorf("failed to create kubernetes client: %w", err)
 	}
 
 	// Create dynamic client for working with Custom Resources
 	// This is essential because Etcd is a CRD, not a built-in Kubernetes type
 	dynamicClient, err := dynamic.NewForConfig(config)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create dynamic client: %w", err)
+		return nil, nil, nil, "", fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	return kubeClient, dynamicClient, config, contextName, nil
+}
+
+// kubeConfigNamespace returns the namespace of the kubeconfig's current context, "default" when it names none
+func kubeConfigNamespace(configOverrides *clientcmd.ConfigOverrides) (string, error) {
+	kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(clientcmd.NewDefaultClientConfigLoadingRules(), configOverrides)
+	namespace, _, err := kubeConfig.Namespace()
+	if err != nil {
+		return "", fmt.Errorf("failed to read the namespace of the current context: %w", err)
 	}
+	return namespace, nil
+}
+
+// kubeConfigOverrides builds the kubeconfig overrides for the connection flags
+// With both a server and a token no kubeconfig entry is needed at all
+func kubeConfigOverrides(server, token string, insecureSkipTLSVerify bool) *clientcmd.ConfigOverrides {
+	overrides := &clientcmd.ConfigOverrides{}
+	overrides.ClusterInfo.Server = server
+	// clientcmd drops any CA from the kubeconfig when this is set, since the two can't be combined
+	overrides.ClusterInfo.InsecureSkipTLSVerify = insecureSkipTLSVerify
+	overrides.AuthInfo.Token = token
+	return overrides
+}
 
-	return kubeClient, dynamicClient, nil
+// Define the Group, Version, Resource for Etcd CRD
+// This is the schema identifier for the custom resource
+var etcdGVR = schema.GroupVersionResource{
+	Group:    "druid.gardener.cloud",
+	Version:  "v1alpha1",
+	Resource: "etcds",
 }
 
 // fetchEtcdResource retrieves the Etcd custom resource
 // This demonstrates how to work with CRDs using the dynamic client
 func (m *Model) fetchEtcdResource() (*unstructured.Unstructured, error) {
-	// Define the Group, Version, Resource for Etcd CRD
-	// This is the schema identifier for the custom resource
-	etcdGVR := schema.GroupVersionResource{
-		Group:    "druid.gardener.cloud",
-		Version:  "v1alpha1",
-		Resource: "etcds",
-	}
+	return m.fetchEtcdResourceIn(m.namespace)
+}
 
+// fetchEtcdResourceIn retrieves the Etcd custom resource of the given namespace
+// With --all-namespaces each pod's namespace has an Etcd of the same name
+func (m *Model) fetchEtcdResourceIn(namespace string) (*unstructured.Unstructured, error) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
 	// Fetch the Etcd resource from the specified namespace
 	etcdResource, err := m.dynamicClient.Resource(etcdGVR).
-		Namespace(m.namespace).
-		Get(context.Background(), m.etcdName, metav1.GetOptions{})
+		Namespace(namespace).
+		Get(ctx, m.etcdName, metav1.GetOptions{})
 
+	if isEtcdCRDMissing(err) {
+		return nil, fmt.Errorf("failed to get Etcd resource %s/%s: %w: %w",
+			namespace, m.etcdName, errEtcdCRDNotInstalled, err)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get Etcd resource %s/%s: %w",
-			m.namespace, m.etcdName, err)
+			namespace, m.etcdName, err)
 	}
 
 	return etcdResource, nil
 }
 
+// podLabelSelector returns the label selector matching the pods of our Etcd resource
+// --selector replaces it for deployments that label their pods differently
+func (m *Model) podLabelSelector() string {
+	if m.labelSelector != "" {
+		return m.labelSelector
+	}
+	return fmt.Sprintf("app.kubernetes.io/name=%s", m.etcdName)
+}
+
+// parseLabelSelector validates a --selector value and returns it in canonical form
+func parseLabelSelector(value string) (string, error) {
+	selector, err := labels.Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid --selector %q: %w", value, err)
+	}
+	return selector.String(), nil
+}
+
+// phaseAllowed reports whether a pod phase passes the --status filter
+func (m *Model) phaseAllowed(phase corev1.PodPhase) bool {
+	if len(m.statusFilter) == 0 || m.allPhases {
+		return true
+	}
+	for _, allowed := range m.statusFilter {
+		if strings.EqualFold(allowed, string(phase)) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseStatusFilter splits a comma-separated list of pod phases, dropping empty entries
+func parseStatusFilter(value string) []string {
+	var phases []string
+	for _, phase := range strings.Split(value, ",") {
+		if phase = strings.TrimSpace(phase); phase != "" {
+			phases = append(phases, phase)
+		}
+	}
+	return phases
+}
+
+// formatAge renders the time elapsed since t, e.g. "1h2m3s"
+func formatAge(t time.Time) string {
+	return time.Since(t).Truncate(time.Second).String()
+}
+
+// formatTimestamp renders a point in time as how long ago it was, or as RFC3339 when absolute times were chosen
+// Relative times are easier to reason about during an incident, absolute ones correlate with other systems
+func (m *Model) formatTimestamp(t time.Time) string {
+	if m.absoluteTimes {
+		return t.Format(time.RFC3339)
+	}
+	return formatAge(t) + " ago"
+}
+
+// toggleAbsoluteTimes flips between relative and absolute timestamps and re-renders the current view with them
+func (m *Model) toggleAbsoluteTimes() tea.Cmd {
+	m.absoluteTimes = !m.absoluteTimes
+	return tea.Batch(m.refreshCurrentView(), m.persistConfig())
+}
+
+// timesHelp describes the timestamp toggle for the help line
+func (m Model) timesHelp() string {
+	if m.absoluteTimes {
+		return m.keys.hint(actionTimes, "times absolute")
+	}
+	return m.keys.hint(actionTimes, "times relative")
+}
+
 // fetchEtcdPods retrieves pods managed by the StatefulSet that corresponds to our Etcd resource
+// It reads every page; the TUI uses fetchEtcdPodsPage directly so the first page renders early
 func (m *Model) fetchEtcdPods() ([]Pod, error) {
+	var pods []Pod
+	continueToken := ""
+	for {
+		page, next, err := m.fetchEtcdPodsPage(continueToken)
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, page...)
+		if next == "" {
+			return pods, nil
+		}
+		continueToken = next
+	}
+}
+
+// fetchEtcdPodsPage retrieves up to podPageSize pods, starting at continueToken
+// The returned token is empty once the last page has been read
+func (m *Model) fetchEtcdPodsPage(continueToken string) ([]Pod, string, error) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
 	// The key insight here is that etcd-druid creates a StatefulSet with the same name as the Etcd resource
 	// We use label selectors to find pods managed by this StatefulSet
-	labelSelector := fmt.Sprintf("app.kubernetes.io/name=%s", m.etcdName)
-
+	// See podLabelSelector for the selector itself
 	podList, err := m.kubeClient.CoreV1().Pods(m.namespace).List(
-		context.Background(),
-		metav1.ListOptions{LabelSelector: labelSelector})
+		ctx,
+		metav1.ListOptions{LabelSelector: m.podLabelSelector(), Limit: podPageSize, Continue: continueToken})
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to list etcd pods: %w", err)
+		return nil, "", fmt.Errorf("failed to list etcd pods: %w", err)
 	}
 
+	// Roles are best effort: the pods are still worth listing while the Etcd resource is unreadable,
+	// and during scale-up new pods show up before etcd-druid reports them as members
+	// Across namespaces every namespace has its own Etcd, read once per page
+	members := map[string]map[string]etcdMemberStatus{}
+
 	var pods []Pod
 	for _, pod := range podList.Items {
-		// Calculate pod age - this gives users context about pod lifecycle
-		age := time.Since(pod.CreationTimestamp.Time).Truncate(time.Second)
+		if !m.phaseAllowed(pod.Status.Phase) {
+			continue
+		}
 
 		// Determine ready status by checking container readiness
 		readyCount := 0
 		totalCount := len(pod.Status.ContainerStatuses)
+		var restarts int32
 		for _, status := range pod.Status.ContainerStatuses {
 			if status.Ready {
 				readyCount++
 			}
+			restarts += status.RestartCount
+		}
+		oomKilled := oomKilledContainers(pod.Status.ContainerStatuses)
+		status := podStatus(pod.Status.Phase, oomKilled)
+		if pod.DeletionTimestamp != nil {
+			status = terminatingPodStatus(&pod, time.Now())
 		}
 
 		pods = append(pods, Pod{
-			Name:      pod.Name,
-			Namespace: pod.Namespace,
-			Status:    string(pod.Status.Phase),
-			Ready:     fmt.Sprintf("%d/%d", readyCount, totalCount),
-			Age:       age.String(),
-			Node:      pod.Spec.NodeName,
+			Name:        pod.Name,
+			Namespace:   pod.Namespace,
+			Status:      status,
+			Ready:       fmt.Sprintf("%d/%d", readyCount, totalCount),
+			Restarts:    restarts,
+			Age:         formatAge(pod.CreationTimestamp.Time), // gives users context about pod lifecycle
+			Node:        pod.Spec.NodeName,
+			PodIP:       pod.Status.PodIP,
+			HostIP:      pod.Status.HostIP,
+			AllReady:    totalCount > 0 && readyCount == totalCount,
+			Backup:      m.backupSidecarState(pod.Status.ContainerStatuses),
+			OOMKilled:   oomKilled,
+			Terminating: pod.DeletionTimestamp != nil,
+			Finished:    isFinishedPhase(pod.Status.Phase),
 		})
+		if _, ok := members[pod.Namespace]; !ok {
+			members[pod.Namespace], _ = m.fetchEtcdMembers(pod.Namespace)
+		}
+		if member, ok := members[pod.Namespace][pod.Name]; ok {
+			pods[len(pods)-1].Role = memberRole(member)
+			pods[len(pods)-1].Member = member.Status
+		}
 	}
 
-	return pods, nil
+	return pods, podList.Continue, nil
 }
 
 // fetchPodContainers retrieves the list of containers for a given pod
-func (m *Model) fetchPodContainers(podName string) ([]string, error) {
-	pod, err := m.kubeClient.CoreV1().Pods(m.namespace).Get(
-		context.Background(), podName, metav1.GetOptions{})
+// Init containers come first, in the order they run, so their logs are reachable when stuck in Init:
+func (m *Model) fetchPodContainers(namespace, podName string) ([]Container, error) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	pod, err := m.kubeClient.CoreV1().Pods(namespace).Get(
+		ctx, podName, metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pod %s: %w", podName, err)
 	}
-	var containers []string
+	var containers []Container
+	for _, c := range pod.Spec.InitContainers {
+		containers = append(containers, Container{Name: c.Name, Init: true})
+	}
 	for _, c := range pod.Spec.Containers {
-		containers = append(containers, c.Name)
+		containers = append(containers, Container{Name: c.Name, Default: c.Name == pod.Annotations[defaultContainerAnnotation]})
+	}
+	for _, c := range pod.Spec.EphemeralContainers {
+		containers = append(containers, Container{Name: c.Name, Ephemeral: true})
 	}
 	return containers, nil
 }
 
 // fetchPodYAML retrieves the YAML configuration for a given pod
-func (m *Model) fetchPodYAML(podName string) (string, error) {
-	pod, err := m.kubeClient.CoreV1().Pods(m.namespace).Get(
-		context.Background(), podName, metav1.GetOptions{})
+func (m *Model) fetchPodYAML(namespace, podName string) (string, error) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	pod, err := m.kubeClient.CoreV1().Pods(namespace).Get(
+		ctx, podName, metav1.GetOptions{})
 	if err != nil {
 		return "", fmt.Errorf("failed to get pod %s: %w", podName, err)
 	}
@@ -188,44 +597,92 @@ func (m *Model) fetchPodYAML(podName string) (string, error) {
 	return string(b), nil
 }
 
+// fetchEtcdYAML retrieves the Etcd custom resource rendered as YAML
+// managedFields are stripped unless requested since they make the CR unreadable
+func (m *Model) fetchEtcdYAML(showManagedFields bool) (string, error) {
+	etcd, err := m.fetchEtcdResource()
+	if err != nil {
+		return "", err
+	}
+	obj := etcd.DeepCopy()
+	if !showManagedFields {
+		unstructured.RemoveNestedField(obj.Object, "metadata", "managedFields")
+	}
+	b, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal etcd %s/%s to yaml: %w", m.namespace, m.etcdName, err)
+	}
+	return string(b), nil
+}
+
 // getPodLogs retrieves logs for the selected pod and container
-func (m *Model) getPodLogs(podName, container string) (string, error) {
-	// Configure log retrieval options
-	// TailLines limits output to prevent overwhelming the terminal
-	tailLines := int64(100)
-	req := m.kubeClient.CoreV1().Pods(m.namespace).GetLogs(podName, &corev1.PodLogOptions{
-		TailLines: &tailLines,
-		Container: container,
-	})
+// The returned flag reports whether the front of the log was dropped to stay within maxLogBytes
+func (m *Model) getPodLogs(namespace, podName, container string) (string, bool, error) {
+	logs, truncated, err := m.streamPodLogs(namespace, podName, m.podLogOptions(container))
+	// A window reads timestamps to find its end, which are dropped again unless switched on
+	if err == nil && m.logWindow.active() && !m.timestamps {
+		logs, _ = newLogLines(logs, time.Time{}, false)
+	}
+	return logs, truncated, err
+}
+
+// podLogOptions configures log retrieval for a container from the log view settings
+// TailLines limits output to prevent overwhelming the terminal unless the full log was requested
+func (m *Model) podLogOptions(container string) *corev1.PodLogOptions {
+	opts := &corev1.PodLogOptions{Container: container, Timestamps: m.timestamps}
+	if !m.fullLogs {
+		tailLines := m.tailLines
+		opts.TailLines = &tailLines
+	}
+	if m.logSince > 0 {
+		sinceSeconds := int64(m.logSince.Seconds())
+		opts.SinceSeconds = &sinceSeconds
+	}
+	// A time window replaces the tail and since settings
+	if m.logWindow.active() {
+		sinceTime := metav1.NewTime(m.logWindow.start)
+		opts.SinceTime, opts.SinceSeconds, opts.TailLines = &sinceTime, nil, nil
+		opts.Timestamps = true
+	}
+	return opts
+}
+
+// streamPodLogs reads the logs selected by opts
+func (m *Model) streamPodLogs(namespace, podName string, opts *corev1.PodLogOptions) (string, bool, error) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	container := opts.Container
+	req := m.kubeClient.CoreV1().Pods(namespace).GetLogs(podName, opts)
 
 	// Execute the request and read the response
-	logs, err := req.Stream(context.Background())
+	logs, err := req.Stream(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to get logs for pod %s (container %s): %w", podName, container, err)
+		return "", false, fmt.Errorf("failed to get logs for pod %s (container %s): %w", podName, container, err)
 	}
 	defer logs.Close()
 
-	// Read all log content
-	buf := make([]byte, 2048)
-	var result strings.Builder
-	for {
-		n, err := logs.Read(buf)
-		if n > 0 {
-			result.Write(buf[:n])
-		}
-		if err != nil {
-			break
-		}
+	// Read the log content, retaining only the most recent maxLogBytes
+	// A window with an end stops reading there rather than at the latest line
+	result := newTailBuffer(maxLogBytes)
+	if end := m.logWindow.end; m.logWindow.active() && !end.IsZero() && opts.Timestamps {
+		err = copyLogsUntil(result, logs, end)
+	} else {
+		_, err = io.Copy(result, logs)
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read logs for pod %s (container %s): %w", podName, container, err)
 	}
 
-	return result.String(), nil
+	return result.String(), result.Truncated(), nil
 }
 
 // describePod gets detailed information about a pod
 // This mimics the 'kubectl describe pod' functionality
-func (m *Model) describePod(podName string) (string, error) {
-	pod, err := m.kubeClient.CoreV1().Pods(m.namespace).Get(
-		context.Background(), podName, metav1.GetOptions{})
+func (m *Model) describePod(namespace, podName string) (string, error) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	pod, err := m.kubeClient.CoreV1().Pods(namespace).Get(
+		ctx, podName, metav1.GetOptions{})
 	if err != nil {
 		return "", fmt.Errorf("failed to describe pod %s: %w", podName, err)
 	}
@@ -237,372 +694,2243 @@ func (m *Model) describePod(podName string) (string, error) {
 	desc.WriteString(fmt.Sprintf("Node: %s\n", pod.Spec.NodeName))
 	desc.WriteString(fmt.Sprintf("Status: %s\n", pod.Status.Phase))
 	desc.WriteString(fmt.Sprintf("IP: %s\n", pod.Status.PodIP))
-	desc.WriteString(fmt.Sprintf("Created: %s\n", pod.CreationTimestamp.Time.Format(time.RFC3339)))
+	desc.WriteString(fmt.Sprintf("Created: %s\n", m.formatTimestamp(pod.CreationTimestamp.Time)))
+	writeMetadata(&desc, "Labels", pod.Labels)
+	writeMetadata(&desc, "Annotations", pod.Annotations)
 
 	desc.WriteString("\nContainers:\n")
 	for _, container := range pod.Spec.Containers {
 		desc.WriteString(fmt.Sprintf("  %s: %s\n", container.Name, container.Image))
+		writeProbes(&desc, "    ", container, pod.Status.ContainerStatuses)
+		desc.WriteString(m.oomKilledLine("    ", pod.Status.ContainerStatuses, container.Name))
+	}
+	// Which identity pulls the images matters when a container is stuck in ImagePullBackOff
+	writeImagePull(&desc, pod.Spec)
+
+	// Tolerations and affinity explain why a member landed on, or was kept off, a node
+	writeScheduling(&desc, pod.Spec)
+	writeSchedulingGates(&desc, pod.Spec)
+
+	if len(pod.Status.InitContainerStatuses) > 0 {
+		desc.WriteString("\nInit Containers:\n")
+		for _, status := range pod.Status.InitContainerStatuses {
+			desc.WriteString(fmt.Sprintf("  %s: %s\n", status.Name, containerStateSummary(status)))
+		}
 	}
 
 	desc.WriteString("\nConditions:\n")
 	for _, condition := range pod.Status.Conditions {
 		desc.WriteString(fmt.Sprintf("  %s: %s\n", condition.Type, condition.Status))
 	}
+	writeReadinessGates(&desc, pod)
+
+	// A Pending member is often stuck on its PVC, which would otherwise need cross-referencing by hand
+	if pod.Status.Phase == corev1.PodPending {
+		m.writeStorage(&desc, pod)
+	}
+
+	// The node is often the root cause of evictions, so include its health when scheduled
+	if pod.Spec.NodeName != "" {
+		m.writeNodeSummary(&desc, pod.Spec.NodeName)
+	}
 
 	return desc.String(), nil
 }
 
 // Initialize sets up the initial state of our application
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(
+	// The checks run before anything else is loaded
+	if m.state == DiagnosticsState {
+		return m.loadDiagnostics()
+	}
+	// Nothing can load before the Etcd is known
+	if m.state == EtcdSelectState {
+		return m.loadEtcdNames()
+	}
+	cmds := []tea.Cmd{
 		m.list.StartSpinner(),
 		m.loadPods(),
-	)
+		m.loadBackupSummary(),
+	}
+	// --dashboard lands on the dashboard, which refreshes like the other live views
+	if m.state == DashboardState {
+		id := m.refreshTick
+		cmds = append(cmds, m.loadDashboard(), tea.Tick(m.refreshInterval, func(time.Time) tea.Msg {
+			return refreshTickMsg{id}
+		}))
+	}
+	// Startup notices such as a malformed config are cleared like any other
+	if m.status != "" {
+		id := m.statusID
+		cmds = append(cmds, tea.Tick(statusDuration, func(time.Time) tea.Msg {
+			return clearStatusMsg{id}
+		}))
+	}
+	return tea.Batch(cmds...)
 }
 
 // loadPods is a command that fetches pod data asynchronously
+// This and the other load commands retry transient API errors before reporting them
 func (m *Model) loadPods() tea.Cmd {
 	return func() tea.Msg {
-		pods, err := m.fetchEtcdPods()
+		var next string
+		pods, err := retryFetch(func() ([]Pod, error) {
+			var pods []Pod
+			var err error
+			pods, next, err = m.fetchEtcdPodsPage("")
+			return pods, err
+		})
 		if err != nil {
 			return errMsg{err}
 		}
-		return podsLoadedMsg{pods}
+		return podsLoadedMsg{pods: pods, next: next}
 	}
 }
 
-// Message types for the Elm architecture pattern used by bubbletea
-type podsLoadedMsg struct{ pods []Pod }
-type errMsg struct{ err error }
-type logsLoadedMsg struct{ content string }
-type describeLoadedMsg struct{ content string }
-type containersLoadedMsg struct{ containers []string }
-type containerSelectedMsg struct{ container string }
-type yamlLoadedMsg struct{ content string }
+// loadMorePods is a command that fetches the page of pods after continueToken
+// page identifies the listing it continues, so pages of a superseded listing can be dropped
+func (m *Model) loadMorePods(continueToken string, page int) tea.Cmd {
+	return func() tea.Msg {
+		var next string
+		pods, err := retryFetch(func() ([]Pod, error) {
+			var pods []Pod
+			var err error
+			pods, next, err = m.fetchEtcdPodsPage(continueToken)
+			return pods, err
+		})
+		return podsPageMsg{pods: pods, next: next, page: page, err: err}
+	}
+}
 
-// Update handles all state changes in response to messages
-// This is the heart of the Elm architecture - pure function that transforms state
-func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	var cmd tea.Cmd
-	var cmds []tea.Cmd
+// loadLogs is a command that fetches logs for a container of the selected pod asynchronously
+func (m *Model) loadLogs(container string) tea.Cmd {
+	return m.loadLogsSince(container, time.Time{})
+}
 
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		if msg.String() == "ctrl+c" {
-			return m, tea.Quit
-		}
-		if msg.String() == "r" {
-			switch m.state {
-			case ListState:
-				return m, m.loadPods()
-			case LogState:
-				if m.selectedPod.Name != "" && m.content != "" {
-					container := ""
-					if len(m.containers) > 0 && m.containerList.Index() >= 0 && m.containerList.Index() < len(m.containers) {
-						container = m.containers[m.containerList.Index()]
-					}
-					if container == "" {
-						container = m.selectedPod.Name // fallback, but should not happen
-					}
-					return m, func() tea.Msg {
-						content, err := m.getPodLogs(m.selectedPod.Name, container)
-						if err != nil {
-							return errMsg{err}
-						}
-						return logsLoadedMsg{content}
-					}
-				}
-			case DescribeState:
-				if m.selectedPod.Name != "" {
-					return m, func() tea.Msg {
-						content, err := m.describePod(m.selectedPod.Name)
-						if err != nil {
-							return errMsg{err}
-						}
-						return describeLoadedMsg{content}
-					}
-				}
-			case ContainerSelectState:
-				if m.selectedPod.Name != "" {
-					return m, func() tea.Msg {
-						containers, err := m.fetchPodContainers(m.selectedPod.Name)
-						if err != nil {
-							return errMsg{err}
-						}
-						return containersLoadedMsg{containers}
-					}
-				}
-			}
+// loadLogsSince is loadLogs reading only the lines after since, which are appended to the log view; see refreshLogs
+// In append mode even a full fetch reads timestamps, so the next refresh knows where to continue
+func (m *Model) loadLogsSince(container string, since time.Time) tea.Cmd {
+	namespace, podName, follow, history := m.podNamespace(m.selectedPod), m.selectedPod.Name, m.followLogs, m.appendLogs
+	return func() tea.Msg {
+		// The UID is read first, so logs of a pod recreated in between are attributed to the new instance
+		var uid types.UID
+		if follow {
+			uid = m.fetchPodUID(namespace, podName)
 		}
-		switch m.state {
-		case ListState:
-			switch msg.String() {
-			case "q":
-				return m, tea.Quit
-			case "l":
-				// Load containers for selected pod and show container selection
-				if len(m.pods) > 0 {
-					m.selectedPod = m.pods[m.list.Index()]
-					m.state = ContainerSelectState
-					return m, func() tea.Msg {
-						containers, err := m.fetchPodContainers(m.selectedPod.Name)
-						if err != nil {
-							return errMsg{err}
-						}
-						return containersLoadedMsg{containers}
-					}
-				}
-			case "d":
-				// Describe selected pod
-				if len(m.pods) > 0 {
-					m.selectedPod = m.pods[m.list.Index()]
-					m.state = DescribeState
-					return m, func() tea.Msg {
-						content, err := m.describePod(m.selectedPod.Name)
-						if err != nil {
-							return errMsg{err}
-						}
-						return describeLoadedMsg{content}
-					}
-				}
-			case "r":
-				// Refresh pod list
-				return m, m.loadPods()
-			case "y":
-				// Show YAML for selected pod
-				if len(m.pods) > 0 {
-					m.selectedPod = m.pods[m.list.Index()]
-					m.state = YamlState
-					return m, func() tea.Msg {
-						content, err := m.fetchPodYAML(m.selectedPod.Name)
-						if err != nil {
-							return errMsg{err}
-						}
-						return yamlLoadedMsg{content}
-					}
-				}
-			default:
-				m.list, cmd = m.list.Update(msg)
-				cmds = append(cmds, cmd)
-			}
-		case LogState:
-			switch msg.String() {
-			case "q":
-				m.state = ListState
-				m.content = ""
-			case "esc":
-				m.state = ContainerSelectState
-				m.content = ""
-			default:
-				m.viewport, cmd = m.viewport.Update(msg)
-				cmds = append(cmds, cmd)
-			}
-		case DescribeState:
-			switch msg.String() {
-			case "q", "esc":
-				m.state = ListState
-				m.content = ""
-			default:
-				m.viewport, cmd = m.viewport.Update(msg)
-				cmds = append(cmds, cmd)
-			}
-		case ContainerSelectState:
-			switch msg.String() {
-			case "q", "esc":
-				m.state = ListState
-				return m, nil
-			case "enter":
-				if len(m.containers) > 0 {
-					selected := m.containers[m.containerList.Index()]
-					return m, func() tea.Msg {
-						return containerSelectedMsg{container: selected}
-					}
-				}
-			default:
-				m.containerList, cmd = m.containerList.Update(msg)
-				cmds = append(cmds, cmd)
-			}
-		case YamlState:
-			switch msg.String() {
-			case "q", "esc":
-				m.state = ListState
-				m.content = ""
-			default:
-				m.viewport, cmd = m.viewport.Update(msg)
-				cmds = append(cmds, cmd)
+		var truncated bool
+		var latest time.Time
+		content, err := retryFetch(func() (string, error) {
+			var content string
+			var err error
+			if history {
+				content, truncated, latest, err = m.fetchLogHistory(namespace, podName, container, since)
+			} else {
+				content, truncated, err = m.getPodLogs(namespace, podName, container)
 			}
+			return content, err
+		})
+		if err != nil {
+			return errMsg{err}
 		}
+		return logsLoadedMsg{content: content, truncated: truncated, source: logSource(namespace, podName, container), uid: uid,
+			appended: !since.IsZero(), latest: latest}
+	}
+}
 
-	case podsLoadedMsg:
-		m.pods = msg.pods
-		// Convert pods to list items for the bubbletea list component
-		items := make([]list.Item, len(m.pods))
-		for i, pod := range m.pods {
-			items[i] = pod
+// currentContainer returns the container highlighted in the container list
+// It falls back to the pod name, which is also the default container name for etcd pods
+func (m Model) currentContainer() string {
+	// The list may be filtered, so the item is read rather than indexing m.containers
+	if len(m.containers) > 0 {
+		if c, ok := m.containerList.SelectedItem().(Container); ok {
+			return c.Name
 		}
-		m.list.SetItems(items)
-		m.list.StopSpinner()
-
-	case logsLoadedMsg:
-		m.content = msg.content
-		m.viewport.SetContent(m.content)
-		m.state = LogState
-		return m, nil
-
-	case describeLoadedMsg:
-		m.content = msg.content
-		m.viewport.SetContent(m.content)
+	}
+	return m.selectedPod.Name
+}
 
-	case containersLoadedMsg:
-		m.containers = msg.containers
-		items := make([]list.Item, len(msg.containers))
-		for i, c := range msg.containers {
-			items[i] = listItemString(c)
-		}
-		delegate := list.NewDefaultDelegate()
-		containerList := list.New(items, delegate, m.list.Width(), m.list.Height())
-		containerList.Title = "Containers"
-		containerList.SetShowStatusBar(false)
-		containerList.SetFilteringEnabled(false)
-		containerList.SetShowHelp(false)
-		m.containerList = containerList
-		m.state = ContainerSelectState
-		return m, nil
+// setContainers fills the container list of the selected pod
+func (m *Model) setContainers(containers []Container) {
+	m.containers = containers
+	items := make([]list.Item, len(containers))
+	for i, c := range containers {
+		items[i] = c
+	}
+	delegate := list.NewDefaultDelegate()
+	containerList := list.New(items, delegate, 0, 0)
+	containerList.Title = "Containers"
+	containerList.SetShowStatusBar(false)
+	containerList.SetShowHelp(false)
+	m.containerList = containerList
+	m.layout()
+}
 
-	case containerSelectedMsg:
-		if m.selectedPod.Name != "" && msg.container != "" {
-			return m, func() tea.Msg {
-				content, err := m.getPodLogs(m.selectedPod.Name, msg.container)
-				if err != nil {
-					return errMsg{err}
-				}
-				return logsLoadedMsg{content}
-			}
+// loadMetrics is a command that fetches pod metrics asynchronously
+func (m *Model) loadMetrics() tea.Cmd {
+	return func() tea.Msg {
+		content, err := retryFetch(m.fetchPodMetrics)
+		if err != nil {
+			return errMsg{err}
 		}
-		return m, nil
-
-	case yamlLoadedMsg:
-		m.content = msg.content
-		m.viewport.SetContent(m.content)
-		m.state = YamlState
-
-	case errMsg:
-		m.err = msg.err
-		m.list.StopSpinner()
-
-	case tea.WindowSizeMsg:
-		// Handle terminal resizing gracefully
-		m.list.SetWidth(msg.Width)
-		m.list.SetHeight(msg.Height - 4)
-		m.viewport.Width = msg.Width
-		m.viewport.Height = msg.Height - 4
+		return metricsLoadedMsg{content}
 	}
-
-	return m, tea.Batch(cmds...)
 }
 
-// View renders the current state of the application
-// This separates presentation logic from business logic
-func (m Model) View() string {
-	if m.err != nil {
-		return fmt.Sprintf("Error: %v\nPress 'q' to quit.", m.err)
+// loadEvents is a command that fetches events related to the etcd asynchronously
+func (m *Model) loadEvents() tea.Cmd {
+	return func() tea.Msg {
+		listed, err := retryFetch(m.listEtcdEvents)
+		if err != nil {
+			return errMsg{err}
+		}
+		return eventsLoadedMsg{listed}
 	}
+}
 
-	switch m.state {
-	case ListState:
-		header := headerStyle.Render(fmt.Sprintf("Etcd Pods (%s/%s)", m.namespace, m.etcdName))
-		help := helpStyle.Render("• l: logs • d: describe • r: refresh • q: quit")
-		return fmt.Sprintf("%s\n%s\n%s", header, m.list.View(), help)
-
-	case LogState:
-		header := headerStyle.Render(fmt.Sprintf("Logs: %s", m.selectedPod.Name))
-		help := helpStyle.Render("• esc: back • q: quit • ↑/↓: scroll")
-		return fmt.Sprintf("%s\n%s\n%s", header, m.viewport.View(), help)
-
-	case DescribeState:
-		header := headerStyle.Render(fmt.Sprintf("Describe: %s", m.selectedPod.Name))
-		help := helpStyle.Render("• esc: back • q: quit • ↑/↓: scroll")
-		return fmt.Sprintf("%s\n%s\n%s", header, m.viewport.View(), help)
+// isLiveState reports whether a screen re-fetches its data every refreshInterval
+func isLiveState(state AppState) bool {
+	return state == MetricsState || state == RolloutState || state == DashboardState
+}
 
-	case ContainerSelectState:
-		header := headerStyle.Render(fmt.Sprintf("Select Container: %s", m.selectedPod.Name))
-		help := helpStyle.Render("• enter: select • esc: back • q: quit")
-		return fmt.Sprintf("%s\n%s\n%s", header, m.containerList.View(), help)
+// scheduleRefresh starts a new live view refresh loop, superseding any running one
+func (m *Model) scheduleRefresh() tea.Cmd {
+	m.refreshTick++
+	id := m.refreshTick
+	return tea.Tick(m.refreshInterval, func(time.Time) tea.Msg {
+		return refreshTickMsg{id}
+	})
+}
 
-	case YamlState:
-		header := headerStyle.Render(fmt.Sprintf("YAML Config: %s", m.selectedPod.Name))
-		help := helpStyle.Render("• esc: back • q: quit • ↑/↓: scroll")
-		return fmt.Sprintf("%s\n%s\n%s", header, m.viewport.View(), help)
+// defaultLogContainer returns the index of the container whose logs l opens without asking
+// --container wins over the pod's kubectl.kubernetes.io/default-container annotation
+func (m *Model) defaultLogContainer(containers []Container) (int, bool) {
+	if m.defaultContainer != "" {
+		i := slices.IndexFunc(containers, func(c Container) bool { return c.Name == m.defaultContainer })
+		return i, i >= 0
 	}
-
-	return ""
+	i := slices.IndexFunc(containers, func(c Container) bool { return c.Default })
+	return i, i >= 0
 }
 
-// Styling using lipgloss - this makes our TUI visually appealing
-var (
-	headerStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("212")).
-			BorderStyle(lipgloss.NormalBorder()).
-			BorderBottom(true).
-			MarginBottom(1)
-
-	helpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("240")).
-			MarginTop(1)
-)
+// loadContainers is a command that fetches the containers of the selected pod asynchronously
+func (m *Model) loadContainers() tea.Cmd {
+	return func() tea.Msg {
+		containers, err := retryFetch(func() ([]Container, error) {
+			return m.fetchPodContainers(m.podNamespace(m.selectedPod), m.selectedPod.Name)
+		})
+		if err != nil {
+			return errMsg{err}
+		}
+		return containersLoadedMsg{containers}
+	}
+}
+
+// loadDescribe is a command that describes the selected pod asynchronously
+func (m *Model) loadDescribe() tea.Cmd {
+	container := m.describeOne
+	return func() tea.Msg {
+		content, err := retryFetch(func() (string, error) {
+			if container != "" {
+				return m.describeContainer(m.podNamespace(m.selectedPod), m.selectedPod.Name, container)
+			}
+			return m.describePod(m.podNamespace(m.selectedPod), m.selectedPod.Name)
+		})
+		if err != nil {
+			return errMsg{err}
+		}
+		return describeLoadedMsg{content}
+	}
+}
+
+// loadPodYAML is a command that fetches the selected pod as YAML asynchronously
+func (m *Model) loadPodYAML() tea.Cmd {
+	lastApplied := m.lastApplied
+	return func() tea.Msg {
+		content, err := retryFetch(func() (string, error) {
+			if lastApplied {
+				return m.fetchPodLastApplied(m.podNamespace(m.selectedPod), m.selectedPod.Name)
+			}
+			return m.fetchPodYAML(m.podNamespace(m.selectedPod), m.selectedPod.Name)
+		})
+		if err != nil {
+			return errMsg{err}
+		}
+		return yamlLoadedMsg{content}
+	}
+}
+
+// refreshCurrentView re-fetches the data shown on the current screen
+// It returns nil when there is nothing to refresh
+func (m *Model) refreshCurrentView() tea.Cmd {
+	return tea.Batch(m.currentViewFetches()...)
+}
+
+// currentViewFetches returns the fetches of the current screen, unbatched so a hard refresh can wait on each of them
+func (m *Model) currentViewFetches() []tea.Cmd {
+	switch m.state {
+	case ListState:
+		return []tea.Cmd{m.loadPods(), m.loadBackupSummary()}
+	case MetricsState:
+		return []tea.Cmd{m.loadMetrics()}
+	case EventsState:
+		return []tea.Cmd{m.loadEvents()}
+	case RolloutState:
+		return []tea.Cmd{m.loadRolloutStatus()}
+	case YamlState:
+		if m.yamlEtcd {
+			return []tea.Cmd{m.loadEtcdYAML()}
+		}
+	case DescribeState:
+		if m.describeEtcd {
+			return []tea.Cmd{m.loadEtcdDescribe()}
+		}
+	case ConditionsState:
+		return []tea.Cmd{m.loadEtcdConditions()}
+	case DashboardState:
+		return []tea.Cmd{m.loadDashboard()}
+	case ClusterLogsState:
+		return m.clusterLogsFetches()
+	case QuorumState:
+		return []tea.Cmd{m.startLoading("running etcdctl in pod " + m.selectedPod.Name), m.loadQuorum()}
+	case EtcdSelectState:
+		return []tea.Cmd{m.loadEtcdNames()}
+	case DiagnosticsState:
+		return []tea.Cmd{m.loadDiagnostics()}
+	case DiffState:
+		return []tea.Cmd{m.loadPodDiff()}
+	case SplitLogsState:
+		return []tea.Cmd{m.loadPaneLogs(0), m.loadPaneLogs(1)}
+	}
+
+	if m.selectedPod.Name == "" {
+		return nil
+	}
+	switch m.state {
+	case LogState:
+		return []tea.Cmd{m.refreshLogs()}
+	case DescribeState:
+		return []tea.Cmd{m.loadDescribe()}
+	case NodeState:
+		return []tea.Cmd{m.loadNodeDescribe()}
+	case CertsState:
+		return []tea.Cmd{m.startLoading("Reading certificates…"), m.loadPodCerts()}
+	case ContainerSelectState:
+		return []tea.Cmd{m.loadContainers()}
+	case YamlState:
+		return []tea.Cmd{m.loadPodYAML()}
+	}
+	return nil
+}
+
+// navigate moves to another screen, remembering the current one so back can return to it
+func (m *Model) navigate(state AppState) {
+	if state == m.state {
+		return
+	}
+	m.navStack = append(m.navStack, m.state)
+	m.state = state
+	// A spinner belongs to the screen that started it
+	m.stopLoading()
+	m.layout()
+}
+
+// back pops the navigation stack, returning to the screen we came from
+// Viewport screens drop their content when left, so it is re-fetched on return
+func (m *Model) back() tea.Cmd {
+	m.stopEventsWatch()
+	m.stopLogStream()
+	m.stopClusterLogs()
+	m.content = ""
+	m.tabbed = nil
+	m.stopLoading()
+	if len(m.navStack) == 0 {
+		m.state = ListState
+		m.layout()
+		return nil
+	}
+	m.state = m.navStack[len(m.navStack)-1]
+	m.navStack = m.navStack[:len(m.navStack)-1]
+	m.layout()
+
+	switch m.state {
+	case MetricsState, RolloutState, DashboardState:
+		return tea.Batch(m.refreshCurrentView(), m.scheduleRefresh())
+	case EventsState:
+		// The events stream in through a watch rather than a refresh loop
+		return m.refreshCurrentView()
+	case LogState, DescribeState, YamlState, DiffState, ConditionsState, SplitLogsState, NodeState, CertsState, ClusterLogsState:
+		if m.isLive() {
+			return tea.Batch(m.refreshCurrentView(), m.scheduleRefresh())
+		}
+		return m.refreshCurrentView()
+	}
+	return nil
+}
+
+// loadEtcdDescribe is a command that describes the Etcd CR asynchronously
+func (m *Model) loadEtcdDescribe() tea.Cmd {
+	return func() tea.Msg {
+		content, err := retryFetch(m.describeEtcdResource)
+		if err != nil {
+			return errMsg{err}
+		}
+		return describeLoadedMsg{content}
+	}
+}
+
+// loadEtcdYAML is a command that fetches the Etcd CR as YAML asynchronously
+func (m *Model) loadEtcdYAML() tea.Cmd {
+	showManagedFields, lastApplied := m.managedFields, m.lastApplied
+	return func() tea.Msg {
+		content, err := retryFetch(func() (string, error) {
+			if lastApplied {
+				return m.fetchEtcdLastApplied()
+			}
+			return m.fetchEtcdYAML(showManagedFields)
+		})
+		if err != nil {
+			return errMsg{err}
+		}
+		return yamlLoadedMsg{content}
+	}
+}
+
+// Message types for the Elm architecture pattern used by bubbletea
+type podsLoadedMsg struct {
+	pods []Pod
+	next string // continue token for the next page, empty when this was the only page
+}
+type podsPageMsg struct {
+	pods []Pod
+	next string
+	page int
+	err  error
+}
+type errMsg struct{ err error }
+type logsLoadedMsg struct {
+	content   string
+	truncated bool
+	merged    bool      // all containers interleaved, see loadMergedLogs
+	source    string    // what was read, see logSource
+	uid       types.UID // the pod instance read while following, empty otherwise
+	appended  bool      // only the lines since the previous load, see refreshLogs
+	latest    time.Time // timestamp of the last line in append mode, where the next refresh continues
+}
+type describeLoadedMsg struct{ content string }
+type containersLoadedMsg struct{ containers []Container }
+type containerSelectedMsg struct{ container string }
+type yamlLoadedMsg struct{ content string }
+type clearStatusMsg struct{ id int }
+type metricsLoadedMsg struct{ content string }
+type eventsLoadedMsg struct{ events etcdEvents }
+type refreshTickMsg struct{ id int }
+
+// setStatus shows a transient notice in the footer and schedules its removal
+func (m *Model) setStatus(text string) tea.Cmd {
+	m.statusID++
+	m.status = text
+	id := m.statusID
+	return tea.Tick(statusDuration, func(time.Time) tea.Msg {
+		return clearStatusMsg{id}
+	})
+}
+
+// guardMutation reports whether a mutating action may run
+// In read-only mode it returns false along with a command that flashes a notice
+// Every handler that changes the cluster must check this first, execs of read-only commands like df don't need to
+func (m *Model) guardMutation(action string) (bool, tea.Cmd) {
+	if !m.readOnly {
+		return true, nil
+	}
+	return false, m.setStatus(fmt.Sprintf("read-only mode: %s is disabled", action))
+}
+
+// setPods replaces the pods shown in the list
+func (m *Model) setPods(pods []Pod) {
+	m.pods = pods
+	for i, pod := range m.pods {
+		m.pods[i].Marked = slices.ContainsFunc(m.diffMarks, func(marked Pod) bool { return samePod(marked, pod) })
+		m.pods[i].ShowNamespace = m.allNamespaces
+		m.pods[i].Pinned = m.isPinned(pod)
+	}
+	// Convert pods to list items for the bubbletea list component
+	listed := pinnedFirst(m.listedPods())
+	items := make([]list.Item, len(listed))
+	for i, pod := range listed {
+		items[i] = pod
+	}
+	m.list.SetItems(items)
+	// Column widths depend on the pods, so resize them to the new set
+	m.updatePodDelegate()
+}
+
+// selectedListPod returns the pod under the cursor
+// The list may be filtered down to a subset, so m.pods can't be indexed with the cursor position
+func (m Model) selectedListPod() (Pod, bool) {
+	pod, ok := m.list.SelectedItem().(Pod)
+	return pod, ok
+}
+
+// selectListPod moves the cursor onto a pod, if the list still shows it
+func (m *Model) selectListPod(selected Pod) {
+	for i, item := range m.list.Items() {
+		if pod, isPod := item.(Pod); isPod && samePod(pod, selected) {
+			m.list.Select(i)
+			return
+		}
+	}
+}
+
+// Update handles all state changes in response to messages
+// This is the heart of the Elm architecture - pure function that transforms state
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		return m, m.updateMouse(msg)
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, m.quit()
+		}
+		// Any key acknowledges a met watch
+		m.watchAlert = ""
+		if m.prompt != nil {
+			return m, m.updatePrompt(msg)
+		}
+		// While typing a filter every key belongs to the filter input
+		if m.state == ListState && m.list.FilterState() == list.Filtering {
+			m.list, cmd = m.list.Update(msg)
+			return m, cmd
+		}
+		if m.state == ContainerSelectState && m.containerList.FilterState() == list.Filtering {
+			m.containerList, cmd = m.containerList.Update(msg)
+			return m, cmd
+		}
+		if m.showKeys {
+			return m, m.updateKeysOverlay(msg)
+		}
+		// Number keys jump back along the breadcrumb, except in the pod list where they jump to a pod
+		if level, ok := crumbKey(msg.String()); ok && m.state != ListState {
+			return m, m.jumpToCrumb(level)
+		}
+		switch m.keys.action(m.state, msg.String()) {
+		case actionRefreshAll:
+			// Everything at once, also dismissing the error screen
+			m.err = nil
+			return m, m.hardRefresh()
+		case actionRefresh:
+			// Retrying also dismisses the error screen
+			m.err = nil
+			if cmd := m.refreshCurrentView(); cmd != nil {
+				return m, cmd
+			}
+		case actionKeys:
+			m.openKeysOverlay()
+			return m, nil
+		case actionPalette:
+			return m, m.openPalette()
+		}
+		switch m.state {
+		case ListState:
+			switch m.keys.action(m.state, msg.String()) {
+			case actionQuit:
+				return m, m.quit()
+			case actionContainers:
+				// Load containers for selected pod and show container selection
+				if pod, ok := m.selectedListPod(); ok {
+					m.selectedPod = pod
+					m.navigate(ContainerSelectState)
+					m.skipToDefault = true
+					return m, m.loadContainers()
+				}
+			case actionDescribe:
+				// Describe selected pod
+				if pod, ok := m.selectedListPod(); ok {
+					m.selectedPod = pod
+					m.navigate(DescribeState)
+					m.describeEtcd = false
+					m.describeOne = ""
+					return m, tea.Batch(m.startLoading(m.describeLoadingText()), m.loadDescribe())
+				}
+			case actionLogBundle:
+				// Write the logs of every container into a tarball, e.g. for an incident ticket
+				if pod, ok := m.selectedListPod(); ok {
+					return m, m.startLogBundle(pod)
+				}
+			case actionNode:
+				// Describe the node of the selected pod
+				if pod, ok := m.selectedListPod(); ok {
+					return m, m.openNodeDescribe(pod)
+				}
+			case actionDescribeEtcd:
+				// Describe the Etcd custom resource itself
+				if ok, cmd := m.guardSingleNamespace("etcd describe"); !ok {
+					return m, cmd
+				}
+				m.navigate(DescribeState)
+				m.describeEtcd = true
+				m.describeOne = ""
+				return m, tea.Batch(m.startLoading(m.describeLoadingText()), m.loadEtcdDescribe())
+			case actionRefresh:
+				// Refresh pod list
+				return m, m.loadPods()
+			case actionYAML:
+				// Show YAML for selected pod
+				if pod, ok := m.selectedListPod(); ok {
+					m.selectedPod = pod
+					m.navigate(YamlState)
+					m.yamlEtcd = false
+					m.lastApplied = false
+					return m, m.loadPodYAML()
+				}
+			case actionStatusFilter:
+				// Toggle the --status phase filter so the initial view can be widened
+				if len(m.statusFilter) > 0 {
+					m.allPhases = !m.allPhases
+					return m, m.loadPods()
+				}
+			case actionMetadata:
+				// Add or remove a label or annotation of the selected pod
+				if pod, ok := m.selectedListPod(); ok {
+					if ok, cmd := m.guardMutation("patching metadata"); !ok {
+						return m, cmd
+					}
+					return m, m.promptPodMetadata(pod)
+				}
+			case actionDiagnostics:
+				// Run the startup checks again, e.g. after fixing RBAC
+				return m, m.openDiagnostics()
+			case actionQuorum:
+				// Check the health and quorum of the whole cluster with etcdctl inside the selected member
+				if pod, ok := m.selectedListPod(); ok {
+					m.selectedPod = pod
+					return m, m.openQuorum()
+				}
+			case actionDiskUsage:
+				// Show how full the data volume of the selected pod is, measured with df inside the pod
+				// df only reads, so it runs in read-only mode like the etcdctl health check
+				if pod, ok := m.selectedListPod(); ok {
+					m.selectedPod = pod
+					return m, tea.Batch(m.setStatus("measuring data volume of "+pod.Name), m.loadDiskUsage())
+				}
+			case actionCopyName:
+				// Copy the selected pod's name
+				if pod, ok := m.selectedListPod(); ok {
+					return m, m.copyToClipboard("pod name", pod.Name)
+				}
+			case actionCopyCommand:
+				// Copy a kubectl logs command for the selected pod
+				if pod, ok := m.selectedListPod(); ok {
+					return m, m.copyToClipboard("command", m.kubectlLogsCommand(m.podNamespace(pod), pod.Name, ""))
+				}
+			case actionMark:
+				// Mark the selected pod for the diff view
+				if pod, ok := m.selectedListPod(); ok {
+					m.toggleDiffMark(pod)
+				}
+			case actionRestart:
+				// Rolling restart of every member, like kubectl rollout restart
+				if ok, cmd := m.guardSingleNamespace("restart"); !ok {
+					return m, cmd
+				}
+				return m, m.promptRestartStatefulSet()
+			case actionDiff:
+				// Diff the YAML of the two marked pods
+				if len(m.diffMarks) != 2 {
+					return m, m.setStatus("mark two pods with space to diff them")
+				}
+				m.navigate(DiffState)
+				return m, m.loadPodDiff()
+			case actionSplit:
+				// Show the logs of the two marked pods side by side
+				if len(m.diffMarks) != 2 {
+					return m, m.setStatus("mark two pods with space to split their logs")
+				}
+				return m, m.openSplitLogs()
+			case actionTheme:
+				// Cycle through the theme presets
+				m.themeName = nextThemeName(m.theme.name)
+				if th, err := lookupTheme(m.themeName); err == nil {
+					m.setTheme(th)
+				}
+				return m, tea.Batch(m.setStatus("theme: "+m.themeName), m.persistConfig())
+			case actionTable:
+				// Toggle the compact table layout
+				m.compactList = !m.compactList
+				m.updatePodDelegate()
+				return m, m.persistConfig()
+			case actionMetrics:
+				// Show live resource usage for all etcd pods
+				if ok, cmd := m.guardSingleNamespace("metrics"); !ok {
+					return m, cmd
+				}
+				m.navigate(MetricsState)
+				return m, tea.Batch(m.loadMetrics(), m.scheduleRefresh())
+			case actionEvents:
+				// Show events for the pods, StatefulSet and PVCs behind this etcd
+				if ok, cmd := m.guardSingleNamespace("events"); !ok {
+					return m, cmd
+				}
+				m.navigate(EventsState)
+				return m, m.loadEvents()
+			case actionEdit:
+				// Edit the selected pod in $EDITOR and apply the result
+				if pod, ok := m.selectedListPod(); ok {
+					if ok, cmd := m.guardMutation("edit"); !ok {
+						return m, cmd
+					}
+					return m, m.prepareEdit(editTarget{namespace: m.podNamespace(pod), name: pod.Name})
+				}
+			case actionDashboard:
+				// Open the health dashboard
+				if ok, cmd := m.guardSingleNamespace("dashboard"); !ok {
+					return m, cmd
+				}
+				m.navigate(DashboardState)
+				return m, tea.Batch(m.loadDashboard(), m.scheduleRefresh())
+			case actionFinishedPods:
+				// Show or hide the pods of finished jobs, which the list leaves out by default
+				m.toggleFinishedPods()
+				if m.showFinished {
+					return m, m.setStatus("showing finished pods")
+				}
+				return m, m.setStatus("hiding finished pods")
+			case actionPin:
+				// Keep an eye on a member by floating it to the top, also after restarting
+				if pod, ok := m.selectedListPod(); ok {
+					return m, m.togglePin(pod)
+				}
+			case actionCerts:
+				// Show the certificates of the TLS secrets the selected pod mounts, after asking
+				if pod, ok := m.selectedListPod(); ok {
+					return m, m.promptPodCerts(pod)
+				}
+			case actionWatch:
+				// Alert when the selected pod or all members reach a state, e.g. during a rollout
+				if ok, cmd := m.guardSingleNamespace("watch"); !ok {
+					return m, cmd
+				}
+				if pod, ok := m.selectedListPod(); ok {
+					return m, m.promptPodWatch(pod)
+				}
+			case actionClusterLogs:
+				// Tail the etcd logs of every member at once
+				if len(m.listedPods()) == 0 {
+					return m, m.setStatus("no pods to read logs from")
+				}
+				m.navigate(ClusterLogsState)
+				if m.isLive() {
+					return m, tea.Batch(m.loadClusterLogs(), m.scheduleRefresh())
+				}
+				return m, m.loadClusterLogs()
+			case actionEtcdYAML:
+				// Show YAML for the Etcd custom resource itself
+				if ok, cmd := m.guardSingleNamespace("etcd yaml"); !ok {
+					return m, cmd
+				}
+				m.navigate(YamlState)
+				m.yamlEtcd = true
+				m.lastApplied = false
+				return m, m.loadEtcdYAML()
+			default:
+				if isJumpKey(msg.String()) {
+					return m, m.jumpTo(msg.String())
+				}
+				m.list, cmd = m.list.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+		case LogState:
+			switch m.keys.action(m.state, msg.String()) {
+			case actionBack:
+				return m, m.back()
+			case actionPretty:
+				// Toggle between pretty-printed and raw JSON logs
+				m.rawLogs = !m.rawLogs
+				m.refreshViewport()
+				return m, m.persistConfig()
+			case actionSeverity:
+				// Cycle the minimum severity shown
+				m.minSeverity = m.minSeverity.next()
+				m.refreshViewport()
+			case actionGrep:
+				// Only show lines matching a pattern, an empty one shows them all again
+				return m, m.promptLogGrep()
+			case actionLineNumbers:
+				// Toggle the line number gutter
+				m.lineNumbers = !m.lineNumbers
+				m.refreshViewport()
+				return m, m.persistConfig()
+			case actionTimestamps:
+				// Toggle kubelet timestamps on each log line
+				m.timestamps = !m.timestamps
+				return m, tea.Batch(m.reloadLogs(), m.persistConfig())
+			case actionFullLogs:
+				// Toggle between the last tailLines lines and the full log
+				m.fullLogs = !m.fullLogs
+				return m, m.reloadLogs()
+			case actionPrevContainer, actionNextContainer:
+				// Switch to the previous/next container, keeping the tail, since and timestamp settings
+				if len(m.containers) > 1 {
+					step := 1
+					if m.keys.action(m.state, msg.String()) == actionPrevContainer {
+						step = len(m.containers) - 1
+					}
+					current := slices.IndexFunc(m.containers, func(c Container) bool { return c.Name == m.currentContainer() })
+					// Cycle through every container, not only those matching a filter left on the selection screen
+					m.containerList.ResetFilter()
+					m.containerList.Select((current + step) % len(m.containers))
+					m.setMergedLogs(false)
+					return m, m.loadLogs(m.currentContainer())
+				}
+			case actionCopyCommand:
+				// Copy the kubectl logs command for the container being viewed
+				if m.mergedLogs {
+					return m, m.copyToClipboard("command", m.kubectlLogsCommand(m.podNamespace(m.selectedPod), m.selectedPod.Name, "")+" --all-containers --prefix")
+				}
+				return m, m.copyToClipboard("command", m.kubectlLogsCommand(m.podNamespace(m.selectedPod), m.selectedPod.Name, m.currentContainer()))
+			case actionMerged:
+				// Toggle between the current container and all containers interleaved
+				if len(m.containers) > 1 {
+					m.setMergedLogs(!m.mergedLogs)
+					return m, m.reloadLogs()
+				}
+			case actionSince:
+				// Cycle how far back logs are fetched
+				m.logSince = nextLogSince(m.logSince)
+				return m, m.reloadLogs()
+			case actionDebug:
+				// Single-container pods skip the selection screen, so debugging is offered here too
+				return m, m.promptDebugContainer()
+			case actionFollow:
+				// Keep re-fetching, following the pod through recreations during a rollout
+				return m, m.toggleFollowLogs()
+			case actionWindow:
+				// Show the logs of a time range, e.g. around an incident
+				return m, m.promptLogWindow()
+			case actionAppend:
+				// Toggle between refreshes replacing the logs and appending the new lines, like a scrollback buffer
+				return m, m.toggleAppendLogs()
+			case actionSwitchView:
+				// Switch to the describe of the pod without going back to the list
+				return m, m.toggleLogDescribe()
+			default:
+				m.viewport, cmd = m.viewport.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+		case DescribeState:
+			switch m.keys.action(m.state, msg.String()) {
+			case actionBack:
+				return m, m.back()
+			case actionMetadata:
+				// Add or remove a label or annotation of the described pod
+				if !m.describeEtcd {
+					if ok, cmd := m.guardMutation("patching metadata"); !ok {
+						return m, cmd
+					}
+					return m, m.promptPodMetadata(m.selectedPod)
+				}
+			case actionCordon:
+				// Cordon or uncordon the node hosting the described pod
+				if !m.describeEtcd && m.selectedPod.Node != "" {
+					if ok, cmd := m.guardMutation("cordon"); !ok {
+						return m, cmd
+					}
+					return m, m.toggleCordon(m.selectedPod.Node)
+				}
+				// The Etcd resource has no node, so c shows its conditions instead
+				if m.describeEtcd {
+					m.navigate(ConditionsState)
+					return m, m.loadEtcdConditions()
+				}
+			case actionScale:
+				// Change the replica count of the Etcd resource
+				if m.describeEtcd {
+					return m, m.promptScaleEtcd()
+				}
+			case actionReconcile:
+				// Ask etcd-druid to reconcile the Etcd resource now
+				if m.describeEtcd {
+					return m, m.promptReconcileEtcd()
+				}
+			case actionNode:
+				// Describe the node hosting the described pod
+				if !m.describeEtcd {
+					return m, m.openNodeDescribe(m.selectedPod)
+				}
+			case actionTimes:
+				return m, m.toggleAbsoluteTimes()
+			case actionSwitchView:
+				// Switch back to the logs of the described pod
+				return m, m.toggleLogDescribe()
+			default:
+				m.viewport, cmd = m.viewport.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+		case NodeState:
+			switch m.keys.action(m.state, msg.String()) {
+			case actionBack:
+				return m, m.back()
+			case actionCordon:
+				// Cordon or uncordon the described node
+				if ok, cmd := m.guardMutation("cordon"); !ok {
+					return m, cmd
+				}
+				return m, m.toggleCordon(m.selectedPod.Node)
+			case actionTimes:
+				return m, m.toggleAbsoluteTimes()
+			default:
+				m.viewport, cmd = m.viewport.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+		case ContainerSelectState:
+			switch m.keys.action(m.state, msg.String()) {
+			case actionBack:
+				// The first esc clears an applied filter, like in the pod list
+				if msg.String() == "esc" && m.containerList.FilterState() == list.FilterApplied {
+					m.containerList.ResetFilter()
+					return m, nil
+				}
+				return m, m.back()
+			case actionSelect:
+				if c, ok := m.containerList.SelectedItem().(Container); ok {
+					return m, func() tea.Msg {
+						return containerSelectedMsg{container: c.Name}
+					}
+				}
+			case actionDescribe:
+				// Describe just the highlighted container
+				if c, ok := m.containerList.SelectedItem().(Container); ok {
+					m.navigate(DescribeState)
+					m.describeEtcd = false
+					m.describeOne = c.Name
+					return m, tea.Batch(m.startLoading(m.describeLoadingText()), m.loadDescribe())
+				}
+			case actionDebug:
+				// Attach an ephemeral debug container targeting the highlighted container
+				if len(m.containers) > 0 {
+					return m, m.promptDebugContainer()
+				}
+			case actionMerged:
+				// Show the logs of every container interleaved by time
+				if len(m.containers) > 1 {
+					m.setMergedLogs(true)
+					return m, m.loadMergedLogs()
+				}
+			default:
+				m.containerList, cmd = m.containerList.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+		case YamlState:
+			switch m.keys.action(m.state, msg.String()) {
+			case actionBack:
+				return m, m.back()
+			case actionHighlight:
+				// Toggle syntax highlighting
+				m.plainYAML = !m.plainYAML
+				m.refreshViewport()
+				return m, m.persistConfig()
+			case actionLineNumbers:
+				// Toggle the line number gutter
+				m.lineNumbers = !m.lineNumbers
+				m.refreshViewport()
+				return m, m.persistConfig()
+			case actionManagedFields:
+				// Toggle managedFields on the Etcd CR
+				if m.yamlEtcd && !m.lastApplied {
+					m.managedFields = !m.managedFields
+					return m, m.loadEtcdYAML()
+				}
+			case actionLastApplied:
+				// Toggle between the live object and what kubectl apply last recorded
+				return m, m.toggleLastApplied()
+			case actionEdit:
+				// Edit the object on screen in $EDITOR and apply the result
+				if ok, cmd := m.guardMutation("edit"); !ok {
+					return m, cmd
+				}
+				if m.yamlEtcd {
+					return m, m.prepareEdit(editTarget{namespace: m.namespace, name: m.etcdName, etcd: true})
+				}
+				return m, m.prepareEdit(editTarget{namespace: m.podNamespace(m.selectedPod), name: m.selectedPod.Name})
+			default:
+				m.viewport, cmd = m.viewport.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+		case EtcdSelectState:
+			switch m.keys.action(m.state, msg.String()) {
+			case actionBack:
+				return m, m.quit()
+			case actionSelect:
+				return m, m.promptEtcdName("")
+			default:
+				m.viewport, cmd = m.viewport.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+		case DiagnosticsState:
+			switch m.keys.action(m.state, msg.String()) {
+			case actionBack:
+				// On launch there is nothing to go back to
+				if m.startupChecks {
+					return m, m.quit()
+				}
+				return m, m.back()
+			case actionSelect:
+				// Carry on despite a failed check, the pod views may not need what's missing
+				return m, m.leaveDiagnostics()
+			default:
+				m.viewport, cmd = m.viewport.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+		case ClusterLogsState:
+			switch m.keys.action(m.state, msg.String()) {
+			case actionBack:
+				return m, m.back()
+			case actionPretty:
+				// Toggle between pretty-printed and raw JSON logs
+				m.rawLogs = !m.rawLogs
+				m.refreshViewport()
+				return m, m.persistConfig()
+			case actionSeverity:
+				// Cycle the minimum severity shown
+				m.minSeverity = m.minSeverity.next()
+				m.refreshViewport()
+			case actionGrep:
+				// Only show lines matching a pattern, an empty one shows them all again
+				return m, m.promptLogGrep()
+			case actionLineNumbers:
+				// Toggle the line number gutter
+				m.lineNumbers = !m.lineNumbers
+				m.refreshViewport()
+				return m, m.persistConfig()
+			case actionTimestamps:
+				// Toggle kubelet timestamps on each log line
+				m.timestamps = !m.timestamps
+				return m, tea.Batch(m.loadClusterLogs(), m.persistConfig())
+			case actionFullLogs:
+				// Toggle between the last tailLines lines of each pod and the full logs
+				m.fullLogs = !m.fullLogs
+				return m, m.loadClusterLogs()
+			case actionSince:
+				// Cycle how far back logs are fetched
+				m.logSince = nextLogSince(m.logSince)
+				return m, m.loadClusterLogs()
+			case actionWindow:
+				// Show the logs of a time range, e.g. around an incident
+				return m, m.promptLogWindow()
+			default:
+				m.viewport, cmd = m.viewport.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+		case DashboardState:
+			switch m.keys.action(m.state, msg.String()) {
+			case actionBack, actionSelect:
+				// Landing on the dashboard leaves nothing to go back to, so this drills into the pod list
+				return m, m.back()
+			case actionTimes:
+				return m, m.toggleAbsoluteTimes()
+			default:
+				m.viewport, cmd = m.viewport.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+		case SplitLogsState:
+			switch m.keys.action(m.state, msg.String()) {
+			case actionBack:
+				return m, m.back()
+			case actionFocus:
+				// Move the focus to the other pane
+				m.splitFocus = 1 - m.splitFocus
+			case actionSplit, actionSelect:
+				// Back to a single viewport, showing the focused pane
+				return m, m.singleLogs()
+			case actionPaneContainer:
+				// Switch the container of the focused pane
+				return m, m.promptPaneContainer()
+			case actionPretty:
+				// Toggle between pretty-printed and raw JSON logs
+				m.rawLogs = !m.rawLogs
+				m.refreshViewport()
+				return m, m.persistConfig()
+			case actionSeverity:
+				// Cycle the minimum severity shown
+				m.minSeverity = m.minSeverity.next()
+				m.refreshViewport()
+			case actionGrep:
+				// Only show lines matching a pattern, an empty one shows them all again
+				return m, m.promptLogGrep()
+			default:
+				pane := &m.splitPanes[m.splitFocus]
+				pane.viewport, cmd = pane.viewport.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+		case MetricsState, EventsState, DiffState, RolloutState, ConditionsState, QuorumState, CertsState, ChangeState:
+			switch m.keys.action(m.state, msg.String()) {
+			case actionBack:
+				return m, m.back()
+			case actionTimes:
+				if m.state == EventsState || m.state == ConditionsState || m.state == CertsState {
+					return m, m.toggleAbsoluteTimes()
+				}
+			case actionSortEvents:
+				// Sort the events by how often they fired, or back by when they were last seen
+				m.eventsByCount = !m.eventsByCount
+				m.renderEventsView()
+				if m.eventsByCount {
+					m.viewport.GotoTop()
+				} else {
+					m.viewport.GotoBottom()
+				}
+			default:
+				m.viewport, cmd = m.viewport.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+		}
+
+	case podsLoadedMsg:
+		m.setPods(msg.pods)
+		m.list.StopSpinner()
+		// Drop pages still arriving for an earlier listing
+		m.podsPage++
+		m.loadingMore = msg.next != ""
+		if m.loadingMore {
+			return m, m.loadMorePods(msg.next, m.podsPage)
+		}
+
+	case podsPageMsg:
+		if msg.page != m.podsPage {
+			break
+		}
+		if msg.err != nil {
+			m.loadingMore = false
+			return m, m.setStatus(msg.err.Error())
+		}
+		m.setPods(append(append([]Pod{}, m.pods...), msg.pods...))
+		m.loadingMore = msg.next != ""
+		if m.loadingMore {
+			return m, m.loadMorePods(msg.next, m.podsPage)
+		}
+
+	case logsLoadedMsg:
+		// Drop a load for the mode that was just switched away from
+		if msg.merged != m.mergedLogs {
+			break
+		}
+		m.logsTruncated = msg.truncated || (msg.appended && m.logsTruncated)
+		// Refreshes arrive while already in LogState and must not grow the stack
+		// Following is per visit, so newly opened logs start out as a snapshot
+		if m.state != LogState {
+			m.navigate(LogState)
+			m.followLogs = false
+			m.stopLogStream()
+		}
+		atBottom := m.viewport.AtBottom()
+		var status tea.Cmd
+		if m.setFollowedLogs(msg) {
+			status = m.setStatus(fmt.Sprintf("pod %s was recreated, following the new instance", m.selectedPod.Name))
+		}
+		m.refreshViewport()
+		if m.followLogs && atBottom {
+			m.viewport.GotoBottom()
+		}
+		return m, status
+
+	case describeLoadedMsg:
+		// Drop a describe that arrives after tab already switched to the logs
+		if m.state != DescribeState {
+			break
+		}
+		m.stopLoading()
+		m.content = msg.content
+		m.refreshViewport()
+
+	case spinner.TickMsg:
+		cmds = append(cmds, m.updateSpinners(msg))
+
+	case containersLoadedMsg:
+		m.setContainers(msg.containers)
+		// Nothing to choose between, or a default chosen beforehand, so go straight to the logs
+		// The selection screen is dropped from history so esc returns to the pod list
+		// Only opening the screen skips it; a reload, e.g. after adding a debug container, stays
+		skipToDefault := m.skipToDefault
+		m.skipToDefault = false
+		if m.state != ContainerSelectState {
+			return m, nil
+		}
+		pick, ok := 0, len(msg.containers) == 1
+		if !ok && skipToDefault {
+			pick, ok = m.defaultLogContainer(msg.containers)
+		}
+		if ok {
+			m.containerList.Select(pick)
+			// The describe tab switched away from stays, dropping the selection screen doesn't leave the pair
+			tabbed := m.tabbed
+			m.back()
+			m.tabbed = tabbed
+			m.setMergedLogs(false)
+			return m, m.loadLogs(msg.containers[pick].Name)
+		}
+		if skipToDefault && m.defaultContainer != "" {
+			return m, m.setStatus(fmt.Sprintf("container %s not found in pod %s", m.defaultContainer, m.selectedPod.Name))
+		}
+		return m, nil
+
+	case containerSelectedMsg:
+		if m.selectedPod.Name != "" && msg.container != "" {
+			m.setMergedLogs(false)
+			return m, m.loadLogs(msg.container)
+		}
+		return m, nil
+
+	case yamlLoadedMsg:
+		m.content = msg.content
+		m.refreshViewport()
+
+	case splitLogsLoadedMsg:
+		m.setPaneLogs(msg)
+
+	case bundleStepMsg:
+		return m, m.bundleNext(msg)
+
+	case bundleWrittenMsg:
+		return m, m.finishLogBundle(msg)
+
+	case podWatchStartedMsg:
+		return m, m.setPodWatch(msg)
+
+	case podWatchEventMsg:
+		return m, m.applyWatchEvent(msg)
+
+	case certsLoadedMsg:
+		if m.state == CertsState {
+			m.stopLoading()
+			m.content = msg.content
+			m.refreshViewport()
+		}
+
+	case nodeLoadedMsg:
+		if m.state == NodeState {
+			m.stopLoading()
+			m.content = msg.content
+			m.refreshViewport()
+		}
+
+	case diffLoadedMsg:
+		if m.state == DiffState {
+			m.content = msg.content
+			m.refreshViewport()
+		}
+
+	case list.FilterMatchesMsg:
+		// Only the list on screen can be filtering
+		if m.state == ContainerSelectState {
+			m.containerList, cmd = m.containerList.Update(msg)
+		} else {
+			m.list, cmd = m.list.Update(msg)
+		}
+		cmds = append(cmds, cmd)
+
+	case metricsLoadedMsg:
+		if m.state == MetricsState {
+			m.content = msg.content
+			m.refreshViewport()
+		}
+
+	case quorumLoadedMsg:
+		m.setQuorum(msg)
+
+	case rolloutLoadedMsg:
+		if m.state == RolloutState {
+			m.content = msg.content
+			m.refreshViewport()
+		}
+
+	case etcdNamesMsg:
+		if m.state == EtcdSelectState {
+			return m, m.showEtcdNames(msg)
+		}
+
+	case diagnosticsLoadedMsg:
+		return m, m.setDiagnostics(msg)
+
+	case dashboardLoadedMsg:
+		if m.state == DashboardState {
+			m.content = msg.content
+			m.refreshViewport()
+		}
+
+	case conditionsLoadedMsg:
+		if m.state == ConditionsState {
+			m.content = msg.content
+			m.refreshViewport()
+		}
+
+	case etcdScaledMsg:
+		m.endOperation(scaleOperation(m.etcdName))
+		if msg.err != nil {
+			return m, m.setStatus(msg.err.Error())
+		}
+		status := m.setStatus(fmt.Sprintf("scaled etcd %s to %d replicas", m.etcdName, msg.replicas))
+		// Show the Etcd status again so the replicas can be followed as etcd-druid catches up
+		if m.state == DescribeState && m.describeEtcd {
+			return m, tea.Batch(status, m.loadEtcdDescribe())
+		}
+		return m, status
+
+	case statefulSetRestartedMsg:
+		m.endOperation(restartOperation(m.etcdName))
+		if msg.err != nil {
+			return m, m.setStatus(msg.err.Error())
+		}
+		// Watch the members being replaced one by one
+		m.navigate(RolloutState)
+		return m, tea.Batch(m.setStatus("restarting statefulset "+m.etcdName), m.loadRolloutStatus(), m.scheduleRefresh())
+
+	case eventsLoadedMsg:
+		if m.state == EventsState {
+			return m, m.setEvents(msg.events)
+		}
+
+	case eventsWatchStartedMsg:
+		return m, m.setEventsWatch(msg)
+
+	case logStreamStartedMsg:
+		return m, m.setLogStream(msg)
+
+	case logStreamLinesMsg:
+		return m, m.applyLogLines(msg)
+
+	case logStreamRetryMsg:
+		return m, m.reopenLogStream(msg)
+
+	case clusterLogStreamStartedMsg:
+		return m, m.setClusterLogStream(msg)
+
+	case clusterLogLinesMsg:
+		return m, m.applyClusterLogLines(msg)
+
+	case clusterLogStreamEndedMsg:
+		return m, m.clusterLogStreamEnded(msg)
+
+	case clusterLogRetryMsg:
+		return m, m.reopenClusterLogStream(msg)
+
+	case eventsWatchEventMsg:
+		return m, m.applyEventsEvent(msg)
+
+	case eventsHighlightMsg:
+		m.expireEventHighlights(msg)
+
+	case eventsRelatedMsg:
+		return m, m.applyEventsRelated(msg)
+
+	case clusterLogsLoadedMsg:
+		// Streamed logs aren't replaced by a window fetch that finished late
+		if m.state == ClusterLogsState && m.clusterLogs == nil {
+			// Follow new lines like a tail, unless the user scrolled up to read
+			follow := m.content == "" || m.viewport.AtBottom()
+			m.content = msg.content
+			m.logsTruncated = msg.truncated
+			m.refreshViewport()
+			if follow {
+				m.viewport.GotoBottom()
+			}
+		}
+
+	case refreshTickMsg:
+		// Stop refreshing once the user has left the live views
+		if msg.id == m.refreshTick && m.isLive() {
+			return m, tea.Batch(m.refreshCurrentView(), m.scheduleRefresh())
+		}
+
+	case editReadyMsg:
+		return m, openEditor(msg)
+
+	case editorFinishedMsg:
+		return m, m.applyEdit(msg)
+
+	case editedMsg:
+		m.endOperation(editOperation)
+		return m, tea.Batch(m.setStatus(msg.summary), m.loadPods())
+
+	case debugContainerMsg:
+		m.endOperation(debugOperation(msg.podName))
+		if msg.err != nil {
+			return m, m.setStatus(msg.err.Error())
+		}
+		// The logs are offered while the pod is still the one on screen
+		if m.selectedPod.Name != msg.podName {
+			return m, m.setStatus(debugAttachHint(msg))
+		}
+		prompt := m.promptDebugLogs(msg)
+		if m.state == ContainerSelectState {
+			return m, tea.Batch(prompt, m.loadContainers())
+		}
+		return m, prompt
+
+	case debugLogsMsg:
+		return m, m.showDebugLogs(msg)
 
-// listItemString wraps a string to implement the list.Item interface for Bubbletea lists
-// Used for displaying container names in the container selection list
+	case diskUsageMsg:
+		m.endOperation(execOperation(msg.podName))
+		if msg.err != nil {
+			return m, m.setStatus(msg.err.Error())
+		}
+		return m, m.setStatus(fmt.Sprintf("%s data: %s", msg.podName, msg.usage))
 
-type listItemString string
+	case podMetadataPatchedMsg:
+		return m, m.showPatchedMetadata(msg)
 
-func (s listItemString) Title() string       { return string(s) }
-func (s listItemString) Description() string { return "" }
-func (s listItemString) FilterValue() string { return string(s) }
+	case changeMsg:
+		return m, m.showChange(msg)
 
-func main() {
-	// Parse command line arguments - k9s passes context information this way
-	if len(os.Args) < 3 {
-		log.Fatal("Usage: etcd-pod-viewer <namespace> <etcd-name>")
+	case etcdReconcileMsg:
+		return m, m.showReconcile(msg)
+
+	case nodeCordonedMsg:
+		m.endOperation(cordonOperation(msg.node))
+		if msg.err != nil {
+			return m, m.setStatus(msg.err.Error())
+		}
+		action := "uncordoned"
+		if msg.unschedulable {
+			action = "cordoned"
+		}
+		status := m.setStatus(fmt.Sprintf("%s node %s", action, msg.node))
+		// Re-describe so the node's schedulable state is current
+		if m.state == DescribeState && !m.describeEtcd {
+			return m, tea.Batch(status, m.loadDescribe())
+		}
+		if m.state == NodeState {
+			return m, tea.Batch(status, m.loadNodeDescribe())
+		}
+		return m, status
+
+	case configSaveFailedMsg:
+		return m, m.setStatus(msg.err.Error())
+
+	case statefulSetStatusMsg:
+		m.setStatefulSetStatus(msg)
+
+	case hardRefreshDoneMsg:
+		return m, m.finishHardRefresh(msg)
+
+	case backupSummaryMsg:
+		m.backupSummary = msg.summary
+		m.layout()
+
+	case clearJumpMsg:
+		if msg.id == m.jumpID {
+			m.jumpBuffer = ""
+		}
+
+	case clearStatusMsg:
+		if msg.id == m.statusID {
+			m.status = ""
+		}
+
+	case errMsg:
+		m.list.StopSpinner()
+		m.stopLoading()
+		// Without the CRD only the Etcd views are lost, so explain that in place of the view
+		if errors.Is(msg.err, errEtcdCRDNotInstalled) && m.state != ListState {
+			m.content = etcdCRDMissingNotice()
+			m.refreshViewport()
+			return m, nil
+		}
+		m.err = classifyError(msg.err)
+
+	case tea.WindowSizeMsg:
+		// Handle terminal resizing gracefully
+		m.width, m.height = msg.Width, msg.Height
+		if m.tooSmall() {
+			// The layout is kept as it was until the window grows again, View shows a notice meanwhile
+			return m, tea.Batch(cmds...)
+		}
+		m.layout()
+		m.refreshViewport()
 	}
 
-	namespace := os.Args[1]
-	etcdName := os.Args[2]
+	return m, tea.Batch(cmds...)
+}
 
-	// Initialize Kubernetes clients
-	kubeClient, dynamicClient, err := setupKubeClient()
-	if err != nil {
-		log.Fatalf("Failed to setup kubernetes client: %v", err)
+// footerView renders the status bar shown at the bottom of every screen
+// It gives constant context about which cluster and etcd we are looking at
+func (m Model) footerView() string {
+	readyPods := 0
+	for _, pod := range m.pods {
+		if pod.AllReady {
+			readyPods++
+		}
 	}
 
-	// Create the list component with custom styling
-	delegate := list.NewDefaultDelegate()
-	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
-		Foreground(lipgloss.Color("212")).Bold(true)
+	contextName := m.contextName
+	if contextName == "" {
+		contextName = "<none>"
+	}
+
+	footer := fmt.Sprintf("ctx: %s | ns: %s | etcd: %s | pods: %d/%d ready",
+		contextName, m.namespaceLabel(), m.etcdName, readyPods, len(m.pods))
+	if m.readOnly {
+		footer += " | read-only"
+	}
+	if m.dryRun {
+		footer += " | dry run"
+	}
+	footer = m.theme.footer.Render(footer)
+	if m.insecure {
+		footer += " " + m.theme.insecure.Render("TLS verification disabled")
+	}
+	if m.hardRefreshing {
+		footer += " " + m.spinner.View() + " refreshing everything"
+	}
+	if m.bundling != "" {
+		footer += fmt.Sprintf(" bundling the logs of %s: %s", m.bundling, m.bundleProgress)
+	}
+	if m.podWatch != nil {
+		footer += " watching for " + m.podWatch.condition.description
+	}
+	if m.watchAlert != "" {
+		footer += " " + m.theme.eventWarning.Render("met: "+m.watchAlert)
+	}
+	if m.status != "" {
+		footer += " " + m.theme.status.Render(m.status)
+	}
+	return footer
+}
+
+// viewportContent renders m.content for the current state, including any line number gutter
+func (m Model) viewportContent() string {
+	if m.loading != "" {
+		return m.spinner.View() + " " + m.loading
+	}
+	var content string
+	switch m.state {
+	case LogState:
+		// A blank viewport looks broken, so say why it's empty; r fetches again
+		if strings.TrimSpace(m.content) == "" {
+			return m.emptyLogsNotice()
+		}
+		content = m.renderedLogs()
+		if m.logGrep != nil && strings.TrimSpace(content) == "" {
+			return fmt.Sprintf("No lines match %s, press g to change the pattern", m.logGrep)
+		}
+	case ClusterLogsState:
+		if strings.TrimSpace(m.content) == "" && m.clusterLogs != nil {
+			return fmt.Sprintf("Waiting for the %s logs of %d pods…", etcdContainer, len(m.clusterLogs.streams))
+		}
+		if strings.TrimSpace(m.content) == "" {
+			return fmt.Sprintf("No logs available for the %s container of any pod", etcdContainer)
+		}
+		content = m.renderTaggedLogs(m.clusterLogTags())
+		if m.logGrep != nil && strings.TrimSpace(content) == "" {
+			return fmt.Sprintf("No lines match %s, press g to change the pattern", m.logGrep)
+		}
+	case YamlState:
+		content = m.renderedYAML()
+	case DiffState, ChangeState:
+		return colorDiff(m.content, m.theme)
+	default:
+		return m.content
+	}
+	if m.lineNumbers {
+		content = withLineNumbers(content, m.theme.lineNumber)
+	}
+	return content
+}
+
+// layout sizes the lists and the viewport to the space left between the header, help line and footer
+// It runs again whenever that chrome changes, e.g. when a prompt opens or the backup summary appears
+func (m *Model) layout() {
+	if m.height == 0 {
+		// No WindowSizeMsg yet
+		return
+	}
+	// The breadcrumb, header, help and footer
+	chrome := 1 + lipgloss.Height(m.theme.header.Render("")) + lipgloss.Height(m.theme.help.Render("")) + 1
+	if m.prompt != nil {
+		chrome++
+	}
+	if (m.state == LogState && m.mergedLogs) || m.state == ClusterLogsState {
+		// The container or pod legend
+		chrome++
+	}
+	if m.inline {
+		// The blank line the renderer erases on exit
+		chrome++
+	}
+	body := max(m.height-chrome, 1)
+
+	m.viewport.Width = m.width
+	m.viewport.Height = body
+	// The container list only exists once a pod's containers were loaded
+	if len(m.containers) > 0 {
+		m.containerList.SetSize(m.width, body)
+	}
+
+	// The pod list shares its space with the backup summary and the table headings
+	listHeight := body
+	if m.backupSummary != "" {
+		listHeight--
+	}
+	if m.compactList {
+		listHeight--
+	}
+	m.list.SetSize(m.width, max(listHeight, 1))
+	m.layoutPanes(body)
+	m.keysView.Width = m.width
+	m.keysView.Height = body
+}
+
+// refreshViewport re-renders the viewport after its content or a display setting changed
+func (m *Model) refreshViewport() {
+	if m.state == SplitLogsState {
+		m.refreshPanes()
+		return
+	}
+	m.viewport.SetContent(m.viewportContent())
+}
+
+// withLineNumbers prefixes each line with its number, right-aligned to the widest number
+func withLineNumbers(content string, style lipgloss.Style) string {
+	trailingNewline := strings.HasSuffix(content, "\n")
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	width := len(strconv.Itoa(len(lines)))
+
+	var out strings.Builder
+	for i, line := range lines {
+		if i > 0 {
+			out.WriteByte('\n')
+		}
+		out.WriteString(style.Render(fmt.Sprintf("%*d", width, i+1)))
+		out.WriteString(" ")
+		out.WriteString(line)
+	}
+	if trailingNewline {
+		out.WriteByte('\n')
+	}
+	return out.String()
+}
+
+// emptyLogsNotice explains a log view without any lines
+func (m Model) emptyLogsNotice() string {
+	container := m.currentContainer()
+	if m.mergedLogs {
+		container = "any container"
+	}
+	return fmt.Sprintf("No logs available for %s (it may not have started yet)", container)
+}
+
+// renderedLogs returns the log content as it should appear in the viewport
+func (m Model) renderedLogs() string {
+	if m.mergedLogs {
+		return m.renderedMergedLogs()
+	}
+	content := filterLogs(m.content, m.minSeverity, m.logGrep)
+	if m.rawLogs {
+		return content
+	}
+	return prettyPrintLogs(content, m.theme)
+}
+
+// renderedYAML returns the YAML content as it should appear in the viewport
+func (m Model) renderedYAML() string {
+	if m.plainYAML {
+		return m.content
+	}
+	return highlightYAML(m.content, m.theme.yamlStyle)
+}
+
+// View renders the current state of the application
+// This separates presentation logic from business logic
+func (m Model) View() string {
+	if m.tooSmall() {
+		return m.tooSmallView()
+	}
+	view := fmt.Sprintf("%s\n%s\n%s", m.breadcrumbView(), m.stateView(), m.footerView())
+	if m.prompt != nil {
+		view = fmt.Sprintf("%s\n%s\n%s\n%s", m.breadcrumbView(), m.stateView(), m.promptView(), m.footerView())
+	}
+	// Without the alt screen the renderer erases the line the cursor is on when quitting, which keeps the footer in the scrollback
+	if m.inline {
+		view += "\n"
+	}
+	return view
+}
+
+// tooSmall reports whether the terminal can't fit the breadcrumb, header, help and footer with a few lines left between them
+func (m Model) tooSmall() bool {
+	return m.height > 0 && (m.width < minWidth || m.height < minHeight)
+}
+
+// tooSmallView replaces the whole screen while the terminal is too small, keeping tmux panes from showing a garbled layout
+func (m Model) tooSmallView() string {
+	notice := fmt.Sprintf("terminal too small — please resize\n(%dx%d, need %dx%d)", m.width, m.height, minWidth, minHeight)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(notice))
+}
+
+// stateView renders the body of the current screen, without the footer
+func (m Model) stateView() string {
+	if m.err != nil {
+		return m.errorView()
+	}
+	if m.showKeys {
+		return m.keysOverlayView()
+	}
+
+	switch m.state {
+	case ListState:
+		title := fmt.Sprintf("Etcd Pods (%s/%s)", m.namespaceLabel(), m.etcdName)
+		if len(m.statusFilter) > 0 && !m.allPhases {
+			title += fmt.Sprintf(" [status: %s]", strings.Join(m.statusFilter, ","))
+		}
+		title += m.finishedTitle()
+		if m.loadingMore {
+			title += " (loading more…)"
+		}
+		if m.jumpBuffer != "" {
+			title += " jump: " + m.jumpBuffer
+		}
+		header := m.theme.header.Render(title)
+		k := m.keys
+		helpText := helpStart(k.hint(actionContainers, "logs"), k.hint(actionDescribe, "describe"), k.hint(actionDescribeEtcd, "describe etcd"),
+			k.hint(actionYAML, "yaml"), k.hint(actionEtcdYAML, "etcd yaml"), k.hint(actionMetrics, "metrics"), k.hint(actionEvents, "events"),
+			k.hint(actionClusterLogs, "cluster logs"), k.hint(actionDashboard, "dashboard"), k.hint(actionDiskUsage, "disk usage"),
+			k.hint(actionQuorum, "etcdctl health"))
+		if !m.readOnly {
+			helpText += helpLine(k.hint(actionEdit, "edit"), k.hint(actionMetadata, "label/annotate"), k.hint(actionRestart, "restart members"))
+		}
+		if len(m.statusFilter) > 0 {
+			helpText += helpLine(k.hint(actionStatusFilter, "toggle status filter"))
+		}
+		help := m.theme.help.Render(helpText + helpLine(k.hint(actionMark, "mark"), k.hint(actionDiff, "diff marked"), k.hint(actionSplit, "split logs"),
+			k.hint(actionNode, "node"), k.hint(actionLogBundle, "log bundle"), k.hint(actionWatch, "watch"), k.hint(actionFinishedPods, "finished pods"),
+			k.hint(actionPin, "pin"), k.hint(actionCerts, "certificates"), k.hint(actionCopyName, "copy name"), k.hint(actionCopyCommand, "copy logs cmd"),
+			k.hint(actionDiagnostics, "diagnostics"), k.hint(actionTable, "table"), k.hint(actionTheme, "theme"), "0-9: jump", "/: filter",
+			k.hint(actionRefresh, "refresh"), k.hint(actionRefreshAll, "refresh all"), k.hint(actionPalette, "commands"), k.hint(actionKeys, "keys"),
+			k.hint(actionQuit, "quit")))
+		body := m.list.View()
+		if m.backupSummary != "" {
+			body = m.theme.help.UnsetMarginTop().Render(m.backupSummary) + "\n" + body
+		}
+		if delegate, ok := m.podTableDelegate(); ok {
+			body = delegate.header(m.list.Width()) + "\n" + body
+		}
+		return fmt.Sprintf("%s\n%s\n%s", header, body, help)
+
+	case LogState:
+		title := fmt.Sprintf("Logs: %s", m.selectedPod.Name)
+		if m.mergedLogs {
+			title += " [all containers]"
+		} else if len(m.containers) > 0 {
+			title += fmt.Sprintf(" [%s]", m.currentContainer())
+		}
+		if m.logsTruncated {
+			title += fmt.Sprintf(" (truncated, showing last %d MiB)", maxLogBytes>>20)
+		}
+		if m.streamsLogs() {
+			title += " (following)"
+		} else if m.followLogs {
+			title += fmt.Sprintf(" (following, every %s)", m.refreshInterval)
+		}
+		if m.appendLogs {
+			title += " (appending)"
+		}
+		title += m.logWindowTitle()
+		header := m.theme.header.Render(title)
+		tail := fmt.Sprintf("last %d", m.tailLines)
+		if m.fullLogs {
+			tail = "all"
+		}
+		logMode := "pretty"
+		if m.rawLogs {
+			logMode = "raw"
+		}
+		timestamps := "off"
+		if m.timestamps {
+			timestamps = "on"
+		}
+		k := m.keys
+		helpText := m.scrollHelp() + helpLine(k.keyHelp(actionPrevContainer)+"/"+k.keyHelp(actionNextContainer)+": container",
+			k.hint(actionCopyCommand, "copy cmd"), k.hint(actionPretty, logMode), k.hint(actionSeverity, "level "+m.minSeverity.String()),
+			k.hint(actionFullLogs, "lines "+tail), k.hint(actionSince, "since "+formatLogSince(m.logSince)), k.hint(actionTimestamps, "timestamps "+timestamps),
+			k.hint(actionLineNumbers, "line numbers"))
+		grep := "off"
+		if m.logGrep != nil {
+			grep = m.logGrep.String()
+		}
+		helpText += helpLine(k.hint(actionGrep, "grep "+grep))
+		follow := "off"
+		if m.followLogs {
+			follow = "on"
+		}
+		helpText += helpLine(k.hint(actionFollow, "follow "+follow), k.hint(actionAppend, "refresh "+m.logRefreshMode()), k.hint(actionWindow, "time window"),
+			k.hint(actionSwitchView, "describe"), fmt.Sprintf("1-%d: breadcrumb", m.currentCrumb()))
+		if len(m.containers) > 1 {
+			helpText += helpLine(k.hint(actionMerged, "all containers"))
+		}
+		if !m.readOnly {
+			helpText += helpLine(k.hint(actionDebug, "debug container"))
+		}
+		help := m.theme.help.Render(helpText)
+		if m.mergedLogs {
+			return fmt.Sprintf("%s\n%s\n%s\n%s", header, m.mergedLegend(), m.viewport.View(), help)
+		}
+		return fmt.Sprintf("%s\n%s\n%s", header, m.viewport.View(), help)
+
+	case DescribeState:
+		name := m.selectedPod.Name
+		if m.describeEtcd {
+			name = "etcd/" + m.etcdName
+		} else if m.describeOne != "" {
+			name += fmt.Sprintf(" [%s]", m.describeOne)
+		}
+		header := m.theme.header.Render(fmt.Sprintf("Describe: %s", name))
+		k := m.keys
+		helpText := m.scrollHelp() + helpLine(m.timesHelp())
+		if m.describeEtcd {
+			helpText += helpLine(k.hint(actionCordon, "conditions"))
+			if !m.readOnly {
+				helpText += helpLine(k.hint(actionScale, "scale"), k.hint(actionReconcile, "reconcile"))
+			}
+		}
+		if !m.describeEtcd && !m.readOnly && m.selectedPod.Node != "" {
+			helpText += helpLine(k.hint(actionCordon, "cordon/uncordon node"))
+		}
+		if !m.describeEtcd && !m.readOnly {
+			helpText += helpLine(k.hint(actionMetadata, "label/annotate"))
+		}
+		if !m.describeEtcd && m.selectedPod.Node != "" {
+			helpText += helpLine(k.hint(actionNode, "node"))
+		}
+		if m.canToggleLogDescribe() {
+			helpText += helpLine(k.hint(actionSwitchView, "logs"))
+		}
+		if level := m.currentCrumb(); level > crumbCluster {
+			helpText += helpLine(fmt.Sprintf("1-%d: breadcrumb", level))
+		}
+		help := m.theme.help.Render(helpText)
+		return fmt.Sprintf("%s\n%s\n%s", header, m.viewport.View(), help)
+
+	case ContainerSelectState:
+		header := m.theme.header.Render(fmt.Sprintf("Select Container: %s", m.selectedPod.Name))
+		k := m.keys
+		helpText := helpStart(k.hint(actionSelect, "select"), k.hint(actionDescribe, "describe"), k.hint(actionMerged, "all containers"), "/: filter",
+			k.hint(actionBack, "back"))
+		if !m.readOnly {
+			helpText += helpLine(k.hint(actionDebug, "debug container"))
+		}
+		help := m.theme.help.Render(helpText)
+		return fmt.Sprintf("%s\n%s\n%s", header, m.containerList.View(), help)
+
+	case YamlState:
+		title := fmt.Sprintf("YAML Config: %s", m.selectedPod.Name)
+		if m.yamlEtcd {
+			title = fmt.Sprintf("YAML Config: Etcd %s", m.etcdName)
+		}
+		if m.lastApplied {
+			title += " [last applied]"
+		}
+		header := m.theme.header.Render(title)
+		highlight := "on"
+		if m.plainYAML {
+			highlight = "off"
+		}
+		shown := "live"
+		if m.lastApplied {
+			shown = "last applied"
+		}
+		k := m.keys
+		helpText := m.scrollHelp() + helpLine(k.hint(actionHighlight, "highlight "+highlight), k.hint(actionLineNumbers, "line numbers"),
+			k.hint(actionLastApplied, "showing "+shown))
+		if m.yamlEtcd && !m.lastApplied {
+			managedFields := "hidden"
+			if m.managedFields {
+				managedFields = "shown"
+			}
+			helpText += helpLine(k.hint(actionManagedFields, "managedFields "+managedFields))
+		}
+		if !m.readOnly {
+			helpText += helpLine(k.hint(actionEdit, "edit"))
+		}
+		help := m.theme.help.Render(helpText)
+		return fmt.Sprintf("%s\n%s\n%s", header, m.viewport.View(), help)
+
+	case MetricsState:
+		header := m.theme.header.Render(fmt.Sprintf("Metrics: %s", m.etcdName))
+		help := m.theme.help.Render(helpStart(m.keys.hint(actionBack, "back"), m.keys.hint(actionRefresh, fmt.Sprintf("refresh (auto every %s)", m.refreshInterval))))
+		return fmt.Sprintf("%s\n%s\n%s", header, m.viewport.View(), help)
+
+	case CertsState:
+		header := m.theme.header.Render(fmt.Sprintf("Certificates: %s", m.podDisplayName(m.selectedPod)))
+		help := m.theme.help.Render(m.scrollHelp() + helpLine(m.timesHelp(), m.keys.hint(actionRefresh, "refresh"),
+			fmt.Sprintf("expiring within %d days is highlighted, private keys are never shown", int(certExpiryWarning/(24*time.Hour)))))
+		return fmt.Sprintf("%s\n%s\n%s", header, m.viewport.View(), help)
+
+	case NodeState:
+		header := m.theme.header.Render(fmt.Sprintf("Node: %s (of %s)", m.selectedPod.Node, m.podDisplayName(m.selectedPod)))
+		helpText := m.scrollHelp() + helpLine(m.timesHelp(), m.keys.hint(actionRefresh, "refresh"))
+		if !m.readOnly {
+			helpText += helpLine(m.keys.hint(actionCordon, "cordon/uncordon"))
+		}
+		return fmt.Sprintf("%s\n%s\n%s", header, m.viewport.View(), m.theme.help.Render(helpText))
+
+	case SplitLogsState:
+		header := m.theme.header.Render(fmt.Sprintf("Split logs: %s%s", m.etcdName, m.logWindowTitle()))
+		k := m.keys
+		help := m.theme.help.Render(m.scrollHelp() + helpLine(k.hint(actionFocus, "focus"), k.keyHelp(actionSplit)+"/"+k.keyHelp(actionSelect)+": single view",
+			k.hint(actionPaneContainer, "container"), k.hint(actionPretty, "pretty/raw"), k.hint(actionSeverity, "level "+m.minSeverity.String()),
+			k.hint(actionGrep, "grep"), k.hint(actionRefresh, "refresh")))
+		return fmt.Sprintf("%s\n%s\n%s", header, m.splitView(), help)
+
+	case DiffState:
+		title := "Diff"
+		if len(m.diffMarks) == 2 {
+			title = fmt.Sprintf("Diff: %s → %s", m.podDisplayName(m.diffMarks[0]), m.podDisplayName(m.diffMarks[1]))
+		}
+		header := m.theme.header.Render(title)
+		help := m.theme.help.Render(m.scrollHelp() + helpLine(m.keys.hint(actionRefresh, "refresh")))
+		return fmt.Sprintf("%s\n%s\n%s", header, m.viewport.View(), help)
+
+	case ChangeState:
+		header := m.theme.header.Render(m.changeTitle)
+		help := m.theme.help.Render(m.scrollHelp())
+		return fmt.Sprintf("%s\n%s\n%s", header, m.viewport.View(), help)
+
+	case RolloutState:
+		header := m.theme.header.Render(fmt.Sprintf("Rollout: statefulset %s", m.etcdName))
+		help := m.theme.help.Render(m.scrollHelp() + helpLine(m.keys.hint(actionRefresh, fmt.Sprintf("refresh (auto every %s)", m.refreshInterval))))
+		return fmt.Sprintf("%s\n%s\n%s", header, m.viewport.View(), help)
+
+	case QuorumState:
+		header := m.theme.header.Render(fmt.Sprintf("Cluster health: %s (etcdctl in %s)", m.etcdName, m.podDisplayName(m.selectedPod)))
+		help := m.theme.help.Render(m.scrollHelp() + helpLine(m.keys.hint(actionRefresh, "run again")))
+		return fmt.Sprintf("%s\n%s\n%s", header, m.viewport.View(), help)
+
+	case DiagnosticsState:
+		header := m.theme.header.Render("Diagnostics")
+		body := m.viewport.View()
+		if m.content == "" {
+			body = "Checking the cluster…"
+		}
+		k := m.keys
+		help := helpStart(k.hint(actionSelect, "continue"), k.hint(actionRefresh, "check again"), k.hint(actionBack, "quit"))
+		if !m.startupChecks {
+			help = helpStart(k.keyHelp(actionSelect)+"/"+k.keyHelp(actionBack)+": back", k.hint(actionRefresh, "check again"))
+		}
+		return fmt.Sprintf("%s\n%s\n%s", header, body, m.theme.help.Render(help))
+
+	case EtcdSelectState:
+		header := m.theme.header.Render(fmt.Sprintf("Select Etcd: %s", m.etcdScope()))
+		help := m.theme.help.Render(helpStart(m.keys.hint(actionSelect, "type a name"), m.keys.hint(actionRefresh, "list again"), m.keys.hint(actionBack, "quit")))
+		return fmt.Sprintf("%s\n%s\n%s", header, m.viewport.View(), help)
+
+	case ClusterLogsState:
+		title := fmt.Sprintf("Cluster logs: %s [%s, %d pods]", m.etcdName, etcdContainer, len(m.listedPods()))
+		switch {
+		case m.logsTruncated && m.clusterLogs != nil:
+			title += fmt.Sprintf(" (truncated, showing the last %d MiB)", maxLogBytes>>20)
+		case m.logsTruncated:
+			title += fmt.Sprintf(" (truncated, showing last %d MiB per pod)", maxLogBytes>>20)
+		}
+		if m.clusterLogs != nil {
+			title += " (following)"
+		}
+		title += m.logWindowTitle()
+		header := m.theme.header.Render(title)
+		tail := fmt.Sprintf("last %d", m.tailLines)
+		if m.fullLogs {
+			tail = "all"
+		}
+		logMode := "pretty"
+		if m.rawLogs {
+			logMode = "raw"
+		}
+		timestamps := "off"
+		if m.timestamps {
+			timestamps = "on"
+		}
+		grep := "off"
+		if m.logGrep != nil {
+			grep = m.logGrep.String()
+		}
+		refresh := fmt.Sprintf("refresh (auto every %s)", m.refreshInterval)
+		if m.streamsClusterLogs() {
+			refresh = "reopen the streams"
+		}
+		k := m.keys
+		help := m.theme.help.Render(m.scrollHelp() + helpLine(k.hint(actionPretty, logMode), k.hint(actionSeverity, "level "+m.minSeverity.String()),
+			k.hint(actionGrep, "grep "+grep), k.hint(actionFullLogs, "lines "+tail), k.hint(actionSince, "since "+formatLogSince(m.logSince)),
+			k.hint(actionWindow, "time window"), k.hint(actionTimestamps, "timestamps "+timestamps), k.hint(actionLineNumbers, "line numbers"),
+			k.hint(actionRefresh, refresh)))
+		return fmt.Sprintf("%s\n%s\n%s\n%s", header, m.tagLegend(m.clusterLogTags()), m.viewport.View(), help)
+
+	case DashboardState:
+		header := m.theme.header.Render(fmt.Sprintf("Dashboard: %s/%s", m.namespace, m.etcdName))
+		help := m.theme.help.Render(helpStart(m.keys.hint(actionSelect, "pod list"), m.keys.hint(actionBack, "back"), "↑/↓: scroll", m.timesHelp(),
+			m.keys.hint(actionRefresh, fmt.Sprintf("refresh (auto every %s)", m.refreshInterval))))
+		return fmt.Sprintf("%s\n%s\n%s", header, m.viewport.View(), help)
+
+	case ConditionsState:
+		header := m.theme.header.Render(fmt.Sprintf("Conditions: etcd/%s", m.etcdName))
+		help := m.theme.help.Render(m.scrollHelp() + helpLine(m.timesHelp(), m.keys.hint(actionRefresh, "refresh")))
+		return fmt.Sprintf("%s\n%s\n%s", header, m.viewport.View(), help)
+
+	case EventsState:
+		title := fmt.Sprintf("Events: %s", m.etcdName)
+		if m.eventsWatch != nil {
+			title += " (live)"
+		}
+		header := m.theme.header.Render(title)
+		sorted := "recency"
+		if m.eventsByCount {
+			sorted = "count"
+		}
+		help := m.theme.help.Render(m.scrollHelp() + helpLine(m.timesHelp(), m.keys.hint(actionSortEvents, "sorted by "+sorted), m.keys.hint(actionRefresh, "list again")))
+		return fmt.Sprintf("%s\n%s\n%s", header, m.viewport.View(), help)
+	}
+
+	return ""
+}
+
+// Container is an entry in the container selection list
+type Container struct {
+	Name      string
+	Init      bool // init containers are marked so they can be told apart from long-running ones
+	Ephemeral bool // debug containers added through the ephemeralcontainers subresource
+	Default   bool // named by the pod's kubectl.kubernetes.io/default-container annotation
+}
+
+// defaultContainerAnnotation names the container kubectl picks when none is given
+const defaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
+// Implement the list.Item interface so containers can be shown in the selection list
+func (c Container) FilterValue() string { return c.Name }
+func (c Container) Description() string { return "" }
+func (c Container) Title() string {
+	switch {
+	case c.Init:
+		return c.Name + " (init)"
+	case c.Ephemeral:
+		return c.Name + " (debug)"
+	case c.Default:
+		return c.Name + " (default)"
+	}
+	return c.Name
+}
 
-	podList := list.New([]list.Item{}, delegate, 0, 0)
+// newModel builds the initial application state around the given clients
+// Tests construct it with the fake clientsets from client-go
+func newModel(kubeClient kubernetes.Interface, dynamicClient dynamic.Interface, namespace, etcdName string) Model {
+	// Start out dark until the config or --theme says otherwise
+	th := newTheme("dark", palettes["dark"])
+
+	// Create the list component with custom styling
+	podList := list.New([]list.Item{}, newPodDelegate(th), 0, 0)
 	podList.Title = "Etcd Pods"
 	podList.SetShowStatusBar(false)
-	podList.SetFilteringEnabled(false)
 	podList.SetShowHelp(false)
 
 	// Create viewport for displaying logs and descriptions
 	vp := viewport.New(80, 20)
 
-	// Initialize our model
-	model := Model{
+	m := Model{
 		state:         ListState,
 		list:          podList,
 		viewport:      vp,
+		keysView:      viewport.New(80, 20),
 		kubeClient:    kubeClient,
 		dynamicClient: dynamicClient,
 		namespace:     namespace,
 		etcdName:      etcdName,
+		theme:         th,
+		spinner:       spinner.New(),
+
+		requestTimeout: defaultRequestTimeout,
+		fieldManager:   defaultFieldManager,
+	}
+	m.applyConfig(defaultConfig())
+	return m
+}
+
+func main() {
+	readOnly := flag.Bool("read-only", false, "disable actions that change the cluster, such as edits, patches and debug containers")
+	dryRun := flag.Bool("dry-run", false, "send scale, restart, patch, edit and debug as server-side dry runs and show what they would change")
+	fieldManager := flag.String("field-manager", defaultFieldManager, "field manager recorded in managedFields for the changes made")
+	status := flag.String("status", "", "only show pods in these comma-separated phases, e.g. Running,Pending")
+	output := flag.String("output", "", "print the pods as json or yaml and exit instead of starting the TUI")
+	withEtcd := flag.Bool("with-etcd", false, "include the Etcd resource status in --output")
+	server := flag.String("server", "", "address of the Kubernetes API server, overriding the kubeconfig")
+	token := flag.String("token", "", "bearer token for the API server, overriding the kubeconfig")
+	insecure := flag.Bool("insecure-skip-tls-verify", false, "do not verify the API server certificate; this makes the connection insecure")
+	backupContainer := flag.String("backup-container", "", "name of the backup sidecar container (default: any container named *backup*)")
+	container := flag.String("container", "", "container whose logs l opens directly when the pod has it (default: the pod's kubectl.kubernetes.io/default-container)")
+	themeFlag := flag.String("theme", "", "color theme: dark, light, high-contrast or auto (default from the config file, else auto)")
+	logGrep := flag.String("log-grep", "", "only show log lines matching this regular expression; g changes it in the log view")
+	dashboard := flag.Bool("dashboard", false, "start on the health dashboard instead of the pod list; not with --all-namespaces")
+	skipDiagnostics := flag.Bool("skip-diagnostics", false, "start without checking the kubeconfig, API server, namespace and Etcd first")
+	noAltScreen := flag.Bool("no-alt-screen", false, "render in the normal screen, so the last view stays in the terminal scrollback after quitting")
+	noMouse := flag.Bool("no-mouse", false, "leave the mouse to the terminal, e.g. to select text, instead of clicking and scrolling in the TUI")
+	debugPath := flag.String("debug", "", "write a JSON log of every message, command and API call of the TUI to this file")
+	requestTimeout := flag.Duration("request-timeout", defaultRequestTimeout, "how long to wait for each API request before giving up; 0 waits indefinitely")
+	var allNamespaces bool
+	flag.BoolVar(&allNamespaces, "all-namespaces", false, "list the etcd pods of every namespace; only <etcd-name> is given then")
+	flag.BoolVar(&allNamespaces, "A", false, "shorthand for --all-namespaces")
+	selector := flag.String("selector", "", "label selector for the etcd pods, e.g. app=etcd,role=main (default app.kubernetes.io/name=<etcd-name>)")
+	namespaceSelector := flag.String("namespace-label-selector", "", "offer the Etcds of the namespaces with these labels to pick from, e.g. tenant=true; no arguments are given then")
+	flag.Usage = printUsage
+	flag.Parse()
+
+	var flagTheme theme
+	if *themeFlag != "" {
+		th, err := lookupTheme(*themeFlag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		flagTheme = th
+	}
+
+	if *insecure {
+		fmt.Fprintln(os.Stderr, "WARNING: --insecure-skip-tls-verify is set, the API server certificate will not be checked")
+	}
+
+	if err := validateOutputFormat(*output); err != nil {
+		log.Fatal(err)
+	}
+
+	grep, err := parseLogGrep(*logGrep)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var labelSelector string
+	if *selector != "" {
+		parsed, err := parseLabelSelector(*selector)
+		if err != nil {
+			log.Fatal(err)
+		}
+		labelSelector = parsed
+	}
+
+	var tenantSelector string
+	if *namespaceSelector != "" {
+		if allNamespaces || *output != "" || *dashboard {
+			log.Fatal("--namespace-label-selector picks the etcd in the TUI, it can't be combined with --all-namespaces, --output or --dashboard")
+		}
+		parsed, err := parseNamespaceSelector(*namespaceSelector)
+		if err != nil {
+			log.Fatal(err)
+		}
+		tenantSelector = parsed
+	}
+
+	// Parse command line arguments - k9s passes context information this way
+	launch, err := parseLaunchArgs(flag.Args(), allNamespaces, tenantSelector != "", *output != "" || *dashboard)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		printUsage()
+		os.Exit(2)
+	}
+	namespace, etcdName := launch.namespace, launch.etcdName
+	if *dashboard && allNamespaces {
+		log.Fatal("--dashboard shows a single etcd, it can't be combined with --all-namespaces")
+	}
+
+	// stdout belongs to the TUI, so the debug log goes to a file
+	var debugLog *slog.Logger
+	if *debugPath != "" {
+		logger, closer, err := openDebugLog(*debugPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer closer.Close()
+		debugLog = logger
+	}
+
+	// Initialize Kubernetes clients
+	overrides := kubeConfigOverrides(*server, *token, *insecure)
+	kubeClient, dynamicClient, restConfig, contextName, err := setupKubeClient(overrides, debugLog)
+	if err != nil {
+		log.Fatalf("Failed to setup kubernetes client: %v", err)
+	}
+	if launch.currentNamespace {
+		if namespace, err = kubeConfigNamespace(overrides); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Initialize our model
+	model := newModel(kubeClient, dynamicClient, namespace, etcdName)
+	model.contextName = contextName
+	model.restConfig = restConfig
+	model.readOnly = *readOnly
+	model.dryRun = *dryRun
+	model.fieldManager = *fieldManager
+	model.insecure = *insecure
+	model.backupContainer = *backupContainer
+	model.defaultContainer = *container
+	model.requestTimeout = *requestTimeout
+	model.inline = *noAltScreen
+	model.statusFilter = parseStatusFilter(*status)
+	model.labelSelector = labelSelector
+	model.namespaceSelector = tenantSelector
+	model.logGrep = grep
+	model.allNamespaces = allNamespaces
+	if *dashboard {
+		model.state = DashboardState
+	}
+	if etcdName == "" {
+		model.state = EtcdSelectState
+	}
+
+	// Scripting mode: print once and exit without starting the TUI
+	if *output != "" {
+		if err := model.writeOutput(os.Stdout, *output, *withEtcd); err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		return
+	}
+
+	// Load saved preferences, falling back to the defaults on any problem
+	if path, err := defaultConfigPath(); err != nil {
+		model.status = err.Error()
+	} else {
+		model.loadPreferences(path)
+	}
+
+	// --theme wins for this run without being saved; with no theme anywhere follow the terminal
+	if *themeFlag != "" {
+		model.setTheme(flagTheme)
+	} else if _, ok := palettes[model.themeName]; !ok {
+		if th, err := lookupTheme(themeAuto); err == nil {
+			model.setTheme(th)
+		}
+	}
+
+	// The bell of a met watch goes to stderr, writing to stdout could land in the middle of a frame
+	model.bell = os.Stderr
+
+	// The checks come first and continue on to the screen chosen above
+	if !*skipDiagnostics {
+		model.diagnosticsNext = model.state
+		model.state = DiagnosticsState
+		model.startupChecks = true
 	}
 
 	// Start the bubbletea program
-	p := tea.NewProgram(model, tea.WithAltScreen())
+	var options []tea.ProgramOption
+	if !*noAltScreen {
+		options = append(options, tea.WithAltScreen())
+	}
+	if !*noMouse {
+		options = append(options, tea.WithMouseCellMotion())
+	}
+	var program tea.Model = model
+	if debugLog != nil {
+		program = debugModel{model: model, log: debugLog}
+	}
+	p := tea.NewProgram(program, options...)
 	if _, err := p.Run(); err != nil {
 		log.Fatalf("Error running program: %v", err)
 	}
@@ -0,0 +1,932 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	testNamespace = "shoot--foo"
+	testEtcdName  = "etcd-main"
+)
+
+// newTestModel builds a Model backed by fake clients seeded with the given objects
+func newTestModel(kubeObjects []runtime.Object, etcdObjects ...runtime.Object) (Model, *fake.Clientset) {
+	kubeClient := fake.NewClientset(kubeObjects...)
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{etcdGVR: "EtcdList", podMetricsGVR: "PodMetricsList"}, etcdObjects...)
+	return newModel(kubeClient, dynamicClient, testNamespace, testEtcdName), kubeClient
+}
+
+// testPod builds an etcd member pod with one container per status
+func testPod(name string, phase corev1.PodPhase, statuses ...corev1.ContainerStatus) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         testNamespace,
+			Labels:            map[string]string{"app.kubernetes.io/name": testEtcdName},
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+		},
+		Spec: corev1.PodSpec{NodeName: "node-a"},
+		Status: corev1.PodStatus{
+			Phase:             phase,
+			PodIP:             "10.0.0.1",
+			ContainerStatuses: statuses,
+		},
+	}
+	for _, status := range statuses {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: status.Name, Image: "etcd:v3.5"})
+	}
+	return pod
+}
+
+func runningContainer(name string) corev1.ContainerStatus {
+	return corev1.ContainerStatus{
+		Name:  name,
+		Ready: true,
+		State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+	}
+}
+
+func crashLoopingContainer(name string) corev1.ContainerStatus {
+	return corev1.ContainerStatus{
+		Name:         name,
+		RestartCount: 7,
+		State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+			Reason: "CrashLoopBackOff",
+		}},
+	}
+}
+
+// podWithInitContainer builds a pod stuck in its init phase
+func podWithInitContainer() *corev1.Pod {
+	pod := testPod("etcd-main-0", corev1.PodPending, crashLoopingContainer("etcd"))
+	pod.Spec.InitContainers = []corev1.Container{{Name: "change-permissions", Image: "alpine"}}
+	pod.Status.InitContainerStatuses = []corev1.ContainerStatus{{
+		Name: "change-permissions",
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+			Reason:   "Completed",
+			ExitCode: 0,
+		}},
+	}}
+	return pod
+}
+
+// failingReactor simulates the API server being unreachable
+func failingReactor() k8stesting.ReactionFunc {
+	return func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	}
+}
+
+func TestFetchEtcdPods(t *testing.T) {
+	otherPod := testPod("unrelated", corev1.PodRunning, runningContainer("app"))
+	otherPod.Labels = map[string]string{"app.kubernetes.io/name": "something-else"}
+
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		fail    bool
+		want    []Pod
+		wantErr bool
+	}{
+		{
+			name: "healthy members",
+			objects: []runtime.Object{
+				testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"), runningContainer("backup-restore")),
+				otherPod,
+			},
+			want: []Pod{
+				{Name: "etcd-main-0", Namespace: testNamespace, Status: "Running", Ready: "2/2", Node: "node-a", PodIP: "10.0.0.1", AllReady: true, Backup: "ready"},
+			},
+		},
+		{
+			name: "crash-looping member",
+			objects: []runtime.Object{
+				testPod("etcd-main-1", corev1.PodRunning, crashLoopingContainer("etcd"), runningContainer("backup-restore")),
+			},
+			want: []Pod{
+				{Name: "etcd-main-1", Namespace: testNamespace, Status: "Running", Ready: "1/2", Restarts: 7, Node: "node-a", PodIP: "10.0.0.1", Backup: "ready"},
+			},
+		},
+		{
+			name:    "no matching pods",
+			objects: []runtime.Object{otherPod},
+		},
+		{
+			name:    "api error",
+			fail:    true,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, client := newTestModel(tt.objects)
+			if tt.fail {
+				client.PrependReactor("list", "pods", failingReactor())
+			}
+
+			pods, err := m.fetchEtcdPods()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fetchEtcdPods() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(pods) != len(tt.want) {
+				t.Fatalf("fetchEtcdPods() returned %d pods, want %d", len(pods), len(tt.want))
+			}
+			for i, got := range pods {
+				if got.Age == "" {
+					t.Errorf("pod %s has no age", got.Name)
+				}
+				got.Age = ""
+				if got != tt.want[i] {
+					t.Errorf("pod %d = %+v, want %+v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// podWithDefaultContainer returns a pod with an etcd and a backup-restore container, annotated with a default container
+func podWithDefaultContainer(container string) *corev1.Pod {
+	pod := testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"), runningContainer("backup-restore"))
+	pod.Annotations = map[string]string{defaultContainerAnnotation: container}
+	return pod
+}
+
+func TestFetchPodContainers(t *testing.T) {
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		fail    bool
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "multiple containers",
+			objects: []runtime.Object{testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"), runningContainer("backup-restore"))},
+			want:    []string{"etcd", "backup-restore"},
+		},
+		{
+			name:    "crash-looping container",
+			objects: []runtime.Object{testPod("etcd-main-0", corev1.PodRunning, crashLoopingContainer("etcd"))},
+			want:    []string{"etcd"},
+		},
+		{
+			name:    "init containers first",
+			objects: []runtime.Object{podWithInitContainer()},
+			want:    []string{"change-permissions (init)", "etcd"},
+		},
+		{
+			name:    "default container",
+			objects: []runtime.Object{podWithDefaultContainer("backup-restore")},
+			want:    []string{"etcd", "backup-restore (default)"},
+		},
+		{
+			name:    "pod not found",
+			wantErr: true,
+		},
+		{
+			name:    "api error",
+			objects: []runtime.Object{testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))},
+			fail:    true,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, client := newTestModel(tt.objects)
+			if tt.fail {
+				client.PrependReactor("get", "pods", failingReactor())
+			}
+
+			containers, err := m.fetchPodContainers(testNamespace, "etcd-main-0")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fetchPodContainers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var titles []string
+			for _, c := range containers {
+				titles = append(titles, c.Title())
+			}
+			if strings.Join(titles, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("fetchPodContainers() = %v, want %v", titles, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescribePod(t *testing.T) {
+	tests := []struct {
+		name         string
+		objects      []runtime.Object
+		fail         bool
+		wantContains []string
+		wantErr      bool
+	}{
+		{
+			name:    "running pod",
+			objects: []runtime.Object{testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))},
+			wantContains: []string{
+				"Name: etcd-main-0",
+				"Namespace: " + testNamespace,
+				"Node: node-a",
+				"Status: Running",
+				"IP: 10.0.0.1",
+				"  etcd: etcd:v3.5",
+			},
+		},
+		{
+			name:         "init container statuses",
+			objects:      []runtime.Object{podWithInitContainer()},
+			wantContains: []string{"Init Containers:", "  change-permissions: Terminated (Completed, exit code 0)"},
+		},
+		{
+			name:         "crash-looping pod",
+			objects:      []runtime.Object{testPod("etcd-main-0", corev1.PodRunning, crashLoopingContainer("etcd"))},
+			wantContains: []string{"Name: etcd-main-0", "Containers:", "Conditions:"},
+		},
+		{
+			name:    "pod not found",
+			wantErr: true,
+		},
+		{
+			name:    "api error",
+			objects: []runtime.Object{testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))},
+			fail:    true,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, client := newTestModel(tt.objects)
+			if tt.fail {
+				client.PrependReactor("get", "pods", failingReactor())
+			}
+
+			desc, err := m.describePod(testNamespace, "etcd-main-0")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("describePod() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(desc, want) {
+					t.Errorf("describePod() output missing %q:\n%s", want, desc)
+				}
+			}
+		})
+	}
+}
+
+func TestGetPodLogs(t *testing.T) {
+	m, client := newTestModel([]runtime.Object{
+		testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd")),
+	})
+
+	logs, truncated, err := m.getPodLogs(testNamespace, "etcd-main-0", "etcd")
+	if err != nil {
+		t.Fatalf("getPodLogs() error = %v", err)
+	}
+	if truncated {
+		t.Error("getPodLogs() reported a short log as truncated")
+	}
+	// The fake clientset always streams a fixed body
+	if logs != "fake logs" {
+		t.Errorf("getPodLogs() = %q, want %q", logs, "fake logs")
+	}
+
+	var opts *corev1.PodLogOptions
+	for _, action := range client.Actions() {
+		if action.GetSubresource() == "log" {
+			opts, _ = action.(k8stesting.GenericAction).GetValue().(*corev1.PodLogOptions)
+		}
+	}
+	if opts == nil {
+		t.Fatal("getPodLogs() did not request the log subresource")
+	}
+	if opts.Container != "etcd" {
+		t.Errorf("requested container = %q, want %q", opts.Container, "etcd")
+	}
+	if opts.TailLines == nil || *opts.TailLines != 100 {
+		t.Errorf("requested tail lines = %v, want 100", opts.TailLines)
+	}
+	if opts.SinceSeconds != nil {
+		t.Errorf("requested since = %v, want no limit", *opts.SinceSeconds)
+	}
+
+	// A since window is passed through alongside the tail
+	m.logSince = 15 * time.Minute
+	if _, _, err := m.getPodLogs(testNamespace, "etcd-main-0", "etcd"); err != nil {
+		t.Fatalf("getPodLogs() error = %v", err)
+	}
+	actions := client.Actions()
+	opts, _ = actions[len(actions)-1].(k8stesting.GenericAction).GetValue().(*corev1.PodLogOptions)
+	if opts == nil || opts.SinceSeconds == nil || *opts.SinceSeconds != 900 {
+		t.Errorf("requested options = %+v, want SinceSeconds 900", opts)
+	}
+}
+
+func TestFetchEtcdResource(t *testing.T) {
+	etcd := &unstructured.Unstructured{}
+	etcd.SetAPIVersion("druid.gardener.cloud/v1alpha1")
+	etcd.SetKind("Etcd")
+	etcd.SetNamespace(testNamespace)
+	etcd.SetName(testEtcdName)
+
+	t.Run("found", func(t *testing.T) {
+		m, _ := newTestModel(nil, etcd)
+		got, err := m.fetchEtcdResource()
+		if err != nil {
+			t.Fatalf("fetchEtcdResource() error = %v", err)
+		}
+		if got.GetName() != testEtcdName {
+			t.Errorf("fetchEtcdResource() name = %q, want %q", got.GetName(), testEtcdName)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		m, _ := newTestModel(nil)
+		if _, err := m.fetchEtcdResource(); err == nil {
+			t.Fatal("fetchEtcdResource() expected an error for a missing resource")
+		}
+	})
+}
+
+func TestGuardMutation(t *testing.T) {
+	m, _ := newTestModel(nil)
+	if ok, cmd := m.guardMutation("delete"); !ok || cmd != nil {
+		t.Fatalf("guardMutation() = %v, %v; want allowed without a notice", ok, cmd)
+	}
+
+	m.readOnly = true
+	ok, cmd := m.guardMutation("delete")
+	if ok {
+		t.Fatal("guardMutation() allowed a mutation in read-only mode")
+	}
+	if cmd == nil || !strings.Contains(m.status, "read-only mode") {
+		t.Errorf("guardMutation() status = %q, want a read-only notice", m.status)
+	}
+}
+
+func TestFetchEtcdYAML(t *testing.T) {
+	etcd := &unstructured.Unstructured{}
+	etcd.SetAPIVersion("druid.gardener.cloud/v1alpha1")
+	etcd.SetKind("Etcd")
+	etcd.SetNamespace(testNamespace)
+	etcd.SetName(testEtcdName)
+	etcd.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "etcd-druid", Operation: metav1.ManagedFieldsOperationUpdate}})
+	m, _ := newTestModel(nil, etcd)
+
+	tests := []struct {
+		name              string
+		showManagedFields bool
+	}{
+		{name: "managedFields stripped"},
+		{name: "managedFields shown", showManagedFields: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := m.fetchEtcdYAML(tt.showManagedFields)
+			if err != nil {
+				t.Fatalf("fetchEtcdYAML() error = %v", err)
+			}
+			if !strings.Contains(content, "name: "+testEtcdName) {
+				t.Errorf("fetchEtcdYAML() missing the resource name:\n%s", content)
+			}
+			if got := strings.Contains(content, "managedFields"); got != tt.showManagedFields {
+				t.Errorf("fetchEtcdYAML() contains managedFields = %v, want %v:\n%s", got, tt.showManagedFields, content)
+			}
+		})
+	}
+}
+
+func TestFetchEtcdPodsCustomSelector(t *testing.T) {
+	relabeled := testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))
+	relabeled.Labels = map[string]string{"app": "etcd", "role": "main"}
+	other := testPod("etcd-events-0", corev1.PodRunning, runningContainer("etcd"))
+	other.Labels = map[string]string{"app": "etcd", "role": "events"}
+	m, _ := newTestModel([]runtime.Object{relabeled, other, testPod("etcd-main-1", corev1.PodRunning)})
+
+	m.labelSelector = "app=etcd,role=main"
+	pods, err := m.fetchEtcdPods()
+	if err != nil {
+		t.Fatalf("fetchEtcdPods() error = %v", err)
+	}
+	if len(pods) != 1 || pods[0].Name != "etcd-main-0" {
+		t.Errorf("fetchEtcdPods() = %v, want only etcd-main-0", pods)
+	}
+}
+
+func TestParseLabelSelector(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "app=etcd", want: "app=etcd"},
+		{value: "role=main, app=etcd", want: "app=etcd,role=main"},
+		{value: "tier in (a,b),!canary", want: "!canary,tier in (a,b)"},
+		{value: "app in (a", wantErr: true},
+		{value: "app=etcd,", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseLabelSelector(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseLabelSelector(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseLabelSelector(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFetchEtcdPodsStatusFilter(t *testing.T) {
+	objects := []runtime.Object{
+		testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd")),
+		testPod("etcd-main-1", corev1.PodPending),
+		testPod("etcd-main-2", corev1.PodFailed),
+	}
+
+	tests := []struct {
+		name      string
+		filter    string
+		allPhases bool
+		want      []string
+	}{
+		{name: "no filter", want: []string{"etcd-main-0", "etcd-main-1", "etcd-main-2"}},
+		{name: "single phase", filter: "Pending", want: []string{"etcd-main-1"}},
+		{name: "case-insensitive list", filter: "running, failed,", want: []string{"etcd-main-0", "etcd-main-2"}},
+		{name: "widened interactively", filter: "Pending", allPhases: true, want: []string{"etcd-main-0", "etcd-main-1", "etcd-main-2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _ := newTestModel(objects)
+			m.statusFilter = parseStatusFilter(tt.filter)
+			m.allPhases = tt.allPhases
+
+			pods, err := m.fetchEtcdPods()
+			if err != nil {
+				t.Fatalf("fetchEtcdPods() error = %v", err)
+			}
+			var names []string
+			for _, pod := range pods {
+				names = append(names, pod.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("fetchEtcdPods() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+// keyMsg builds the tea.KeyMsg for a key name as reported by msg.String()
+func keyMsg(key string) tea.KeyMsg {
+	switch key {
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "tab":
+		return tea.KeyMsg{Type: tea.KeyTab}
+	case "ctrl+r":
+		return tea.KeyMsg{Type: tea.KeyCtrlR}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+// update feeds a message through Update and returns the resulting Model
+func update(t *testing.T, m Model, msg tea.Msg) Model {
+	t.Helper()
+	next, _ := m.Update(msg)
+	return next.(Model)
+}
+
+func TestNavigationStack(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+
+	steps := []struct {
+		msg  tea.Msg
+		want AppState
+	}{
+		{keyMsg("l"), ContainerSelectState},
+		{containersLoadedMsg{[]Container{{Name: "etcd"}, {Name: "backup-restore"}}}, ContainerSelectState},
+		{logsLoadedMsg{content: "line"}, LogState},
+		// A refresh while viewing logs must not push another entry
+		{logsLoadedMsg{content: "line 2"}, LogState},
+		{keyMsg("esc"), ContainerSelectState},
+		{keyMsg("q"), ListState},
+		{keyMsg("d"), DescribeState},
+		{keyMsg("esc"), ListState},
+		{keyMsg("m"), MetricsState},
+		{keyMsg("q"), ListState},
+	}
+
+	for i, step := range steps {
+		m = update(t, m, step.msg)
+		if m.state != step.want {
+			t.Fatalf("step %d (%T): state = %v, want %v", i, step.msg, m.state, step.want)
+		}
+	}
+	if len(m.navStack) != 0 {
+		t.Errorf("navigation stack not empty after returning to the list: %v", m.navStack)
+	}
+}
+
+func TestSelectedPodAfterFiltering(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, tea.WindowSizeMsg{Width: 80, Height: 40})
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}, {Name: "etcd-main-1"}, {Name: "etcd-main-2"}}})
+
+	m.list.SetFilterText("main-2")
+	m = update(t, m, keyMsg("d"))
+	if m.selectedPod.Name != "etcd-main-2" {
+		t.Errorf("selectedPod = %q after filtering, want etcd-main-2", m.selectedPod.Name)
+	}
+}
+
+func TestFilterInputCapturesKeys(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, tea.WindowSizeMsg{Width: 80, Height: 40})
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+
+	m = update(t, m, keyMsg("/"))
+	m = update(t, m, keyMsg("d"))
+	if m.state != ListState {
+		t.Errorf("state = %v while typing a filter, want ListState", m.state)
+	}
+	if got := m.list.FilterValue(); got != "d" {
+		t.Errorf("filter value = %q, want %q", got, "d")
+	}
+}
+
+func TestSingleContainerSkipsSelection(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+
+	m = update(t, m, keyMsg("l"))
+	next, cmd := m.Update(containersLoadedMsg{[]Container{{Name: "etcd"}}})
+	m = next.(Model)
+	if cmd == nil {
+		t.Fatal("expected a command loading logs for the only container")
+	}
+	m = update(t, m, logsLoadedMsg{content: "line"})
+	if m.state != LogState {
+		t.Fatalf("state = %v, want LogState", m.state)
+	}
+	if got := m.currentContainer(); got != "etcd" {
+		t.Errorf("currentContainer() = %q, want etcd", got)
+	}
+
+	// The selection screen was never shown, so esc goes straight back to the list
+	m = update(t, m, keyMsg("esc"))
+	if m.state != ListState {
+		t.Errorf("state after esc = %v, want ListState", m.state)
+	}
+}
+
+func TestDefaultContainerSkipsSelection(t *testing.T) {
+	containers := []Container{{Name: "etcd"}, {Name: "backup-restore", Default: true}}
+	tests := []struct {
+		name       string
+		flag       string
+		wantState  AppState
+		wantPick   string
+		wantStatus string
+	}{
+		{name: "annotation", wantState: LogState, wantPick: "backup-restore"},
+		{name: "flag wins over the annotation", flag: "etcd", wantState: LogState, wantPick: "etcd"},
+		{name: "flag container missing", flag: "compactor", wantState: ContainerSelectState,
+			wantStatus: "container compactor not found in pod etcd-main-0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _ := newTestModel(nil)
+			m.defaultContainer = tt.flag
+			m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+			m = update(t, m, keyMsg("l"))
+			next, cmd := m.Update(containersLoadedMsg{containers})
+			m = next.(Model)
+			if tt.wantPick != "" {
+				if cmd == nil {
+					t.Fatal("expected a command loading logs for the default container")
+				}
+				m = update(t, m, logsLoadedMsg{content: "line"})
+			}
+			if m.state != tt.wantState {
+				t.Fatalf("state = %v, want %v", m.state, tt.wantState)
+			}
+			if tt.wantPick != "" {
+				if got := m.currentContainer(); got != tt.wantPick {
+					t.Errorf("currentContainer() = %q, want %q", got, tt.wantPick)
+				}
+				m = update(t, m, keyMsg("esc"))
+				if m.state != ListState {
+					t.Errorf("esc from the logs went to %v, want the pod list", m.state)
+				}
+			}
+			if m.status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", m.status, tt.wantStatus)
+			}
+		})
+	}
+
+	// A reload of the selection screen, e.g. after adding a debug container, keeps it open
+	m, _ := newTestModel(nil)
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("l"))
+	m = update(t, m, containersLoadedMsg{[]Container{{Name: "etcd"}, {Name: "backup-restore"}}})
+	m = update(t, m, containersLoadedMsg{containers})
+	if m.state != ContainerSelectState {
+		t.Errorf("state = %v after a reload, want the selection screen to stay", m.state)
+	}
+}
+
+func TestSwitchContainerInLogView(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("l"))
+	m = update(t, m, containersLoadedMsg{[]Container{{Name: "etcd"}, {Name: "backup-restore"}, {Name: "debugger", Ephemeral: true}}})
+	m = update(t, m, logsLoadedMsg{content: "line"})
+
+	for _, step := range []struct {
+		key  string
+		want string
+	}{
+		{"]", "backup-restore"},
+		{"]", "debugger"},
+		{"]", "etcd"},
+		{"[", "debugger"},
+	} {
+		m = update(t, m, keyMsg(step.key))
+		if got := m.currentContainer(); got != step.want {
+			t.Fatalf("after %s: currentContainer() = %q, want %q", step.key, got, step.want)
+		}
+	}
+	if m.state != LogState {
+		t.Errorf("state = %v, want LogState", m.state)
+	}
+	if !strings.Contains(m.View(), "[debugger]") {
+		t.Error("log header does not name the current container")
+	}
+}
+
+func TestSelectedContainerAfterFiltering(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, tea.WindowSizeMsg{Width: 80, Height: 40})
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("l"))
+	m = update(t, m, containersLoadedMsg{[]Container{{Name: "change-permissions", Init: true}, {Name: "etcd"}, {Name: "backup-restore"}}})
+
+	m.containerList.SetFilterText("backup")
+	_, cmd := m.Update(keyMsg("enter"))
+	if cmd == nil {
+		t.Fatal("enter on the filtered list selected nothing")
+	}
+	if msg, ok := cmd().(containerSelectedMsg); !ok || msg.container != "backup-restore" {
+		t.Errorf("enter selected %v, want backup-restore", cmd())
+	}
+
+	// The first esc only clears the filter
+	m = update(t, m, keyMsg("esc"))
+	if m.state != ContainerSelectState || m.containerList.FilterState() != list.Unfiltered {
+		t.Errorf("after esc: state = %v, filter = %v, want the unfiltered selection screen", m.state, m.containerList.FilterState())
+	}
+
+	// Switching containers in the log view walks the whole list even when it was left filtered
+	m.containerList.SetFilterText("backup")
+	m = update(t, m, logsLoadedMsg{content: "line"})
+	m = update(t, m, keyMsg("]"))
+	if got := m.currentContainer(); got != "change-permissions" {
+		t.Errorf("currentContainer() = %q after ], want change-permissions", got)
+	}
+}
+
+func TestKubeConfigOverrides(t *testing.T) {
+	kubeconfig := clientcmdapi.NewConfig()
+	kubeconfig.Clusters["garden"] = &clientcmdapi.Cluster{Server: "https://kubeconfig:6443", CertificateAuthorityData: []byte("ca")}
+	kubeconfig.AuthInfos["user"] = &clientcmdapi.AuthInfo{Token: "from-kubeconfig"}
+	kubeconfig.Contexts["garden"] = &clientcmdapi.Context{Cluster: "garden", AuthInfo: "user"}
+	kubeconfig.CurrentContext = "garden"
+
+	t.Run("no flags keep the kubeconfig", func(t *testing.T) {
+		config, err := clientcmd.NewDefaultClientConfig(*kubeconfig, kubeConfigOverrides("", "", false)).ClientConfig()
+		if err != nil {
+			t.Fatalf("ClientConfig() error = %v", err)
+		}
+		if config.Host != "https://kubeconfig:6443" || config.BearerToken != "from-kubeconfig" || config.Insecure {
+			t.Errorf("config = host %q token %q insecure %v", config.Host, config.BearerToken, config.Insecure)
+		}
+	})
+
+	t.Run("flags win", func(t *testing.T) {
+		overrides := kubeConfigOverrides("https://jump-host:443", "from-flag", true)
+		config, err := clientcmd.NewDefaultClientConfig(*kubeconfig, overrides).ClientConfig()
+		if err != nil {
+			t.Fatalf("ClientConfig() error = %v", err)
+		}
+		if config.Host != "https://jump-host:443" || config.BearerToken != "from-flag" || !config.Insecure {
+			t.Errorf("config = host %q token %q insecure %v", config.Host, config.BearerToken, config.Insecure)
+		}
+		if len(config.CAData) != 0 {
+			t.Error("kubeconfig CA kept alongside --insecure-skip-tls-verify")
+		}
+	})
+}
+
+// pagedPodsReactor serves the pods in pages of pageSize, using the page offset as the continue token
+func pagedPodsReactor(pods []corev1.Pod, pageSize int) k8stesting.ReactionFunc {
+	return func(action k8stesting.Action) (bool, runtime.Object, error) {
+		opts := action.(k8stesting.ListActionImpl).ListOptions
+		start := 0
+		if opts.Continue != "" {
+			start, _ = strconv.Atoi(opts.Continue)
+		}
+		end := min(start+pageSize, len(pods))
+		list := &corev1.PodList{Items: pods[start:end]}
+		if end < len(pods) {
+			list.Continue = strconv.Itoa(end)
+		}
+		return true, list, nil
+	}
+}
+
+func TestFetchEtcdPodsPaging(t *testing.T) {
+	var pods []corev1.Pod
+	for i := range 5 {
+		pods = append(pods, *testPod(fmt.Sprintf("etcd-main-%d", i), corev1.PodRunning, runningContainer("etcd")))
+	}
+	m, client := newTestModel(nil)
+	client.PrependReactor("list", "pods", pagedPodsReactor(pods, 2))
+
+	page, next, err := m.fetchEtcdPodsPage("")
+	if err != nil {
+		t.Fatalf("fetchEtcdPodsPage() error = %v", err)
+	}
+	if len(page) != 2 || next != "2" {
+		t.Errorf("first page = %d pods, next %q; want 2 pods, next \"2\"", len(page), next)
+	}
+
+	all, err := m.fetchEtcdPods()
+	if err != nil {
+		t.Fatalf("fetchEtcdPods() error = %v", err)
+	}
+	if len(all) != 5 || all[4].Name != "etcd-main-4" {
+		t.Errorf("fetchEtcdPods() = %d pods, want all 5 across pages", len(all))
+	}
+	for _, action := range client.Actions() {
+		if list, ok := action.(k8stesting.ListActionImpl); ok && list.ListOptions.Limit != podPageSize {
+			t.Errorf("list limit = %d, want %d", list.ListOptions.Limit, podPageSize)
+		}
+	}
+}
+
+func TestPodsLoadIncrementally(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}, next: "1"})
+	if !m.loadingMore || !strings.Contains(m.View(), "loading more") {
+		t.Fatal("expected a loading indicator while further pages are fetched")
+	}
+
+	// A page from a superseded listing is dropped
+	m = update(t, m, podsPageMsg{pods: []Pod{{Name: "stale"}}, page: m.podsPage - 1})
+	if len(m.pods) != 1 {
+		t.Fatalf("stale page appended: %v", m.pods)
+	}
+
+	m = update(t, m, podsPageMsg{pods: []Pod{{Name: "etcd-main-1"}}, page: m.podsPage})
+	if len(m.pods) != 2 || m.loadingMore {
+		t.Errorf("pods = %v, loadingMore = %v; want both pods and loading finished", m.pods, m.loadingMore)
+	}
+	if len(m.list.Items()) != 2 {
+		t.Errorf("list has %d items, want 2", len(m.list.Items()))
+	}
+}
+
+func TestLayoutFitsTerminal(t *testing.T) {
+	const width, height = 100, 30
+	m, _ := newTestModel(nil)
+	m = update(t, m, tea.WindowSizeMsg{Width: width, Height: height})
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}, {Name: "etcd-main-1"}}})
+	m = update(t, m, backupSummaryMsg{"Backup: True"})
+
+	check := func(screen string) {
+		t.Helper()
+		if got := lipgloss.Height(m.View()); got > m.height {
+			t.Errorf("%s renders %d lines, want at most %d", screen, got, m.height)
+		}
+	}
+	check("pod list")
+	m = update(t, m, keyMsg("t"))
+	check("compact pod list")
+
+	m = update(t, m, keyMsg("l"))
+	m = update(t, m, containersLoadedMsg{[]Container{{Name: "etcd"}, {Name: "backup-restore"}}})
+	check("container list")
+	if m.containerList.Height() != m.viewport.Height {
+		t.Errorf("container list height = %d, want the body height %d", m.containerList.Height(), m.viewport.Height)
+	}
+
+	m = update(t, m, tea.WindowSizeMsg{Width: width, Height: height - 10})
+	if m.containerList.Width() != width || m.containerList.Height() != m.viewport.Height {
+		t.Errorf("container list not resized: %dx%d", m.containerList.Width(), m.containerList.Height())
+	}
+
+	m = update(t, m, logsLoadedMsg{content: strings.Repeat("line\n", 100)})
+	check("logs")
+}
+
+func TestInlineLayoutKeepsFooter(t *testing.T) {
+	const height = 30
+	m, _ := newTestModel(nil)
+	m.inline = true
+	m = update(t, m, tea.WindowSizeMsg{Width: 100, Height: height})
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("d"))
+	m = update(t, m, describeLoadedMsg{content: strings.Repeat("line\n", 100)})
+
+	// The renderer erases the last line when quitting, so it must be the blank one below the footer
+	view := m.View()
+	lines := strings.Split(view, "\n")
+	if got := lipgloss.Height(view); got > height {
+		t.Errorf("inline view renders %d lines, want at most %d", got, height)
+	}
+	if lines[len(lines)-1] != "" || !strings.Contains(lines[len(lines)-2], "ctx:") {
+		t.Errorf("inline view ends with %q, want the footer followed by a blank line", lines[len(lines)-2:])
+	}
+}
+
+func TestWindowTooSmall(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, tea.WindowSizeMsg{Width: 80, Height: 40})
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	listHeight := m.list.Height()
+
+	m = update(t, m, tea.WindowSizeMsg{Width: 30, Height: 5})
+	view := m.View()
+	if !strings.Contains(view, "terminal too small") || strings.Contains(view, "Etcd Pods") {
+		t.Errorf("view in a 30x5 terminal:\n%s", view)
+	}
+	if got := lipgloss.Height(view); got > 5 {
+		t.Errorf("notice is %d lines high in a 5 line terminal", got)
+	}
+	if m.list.Height() != listHeight {
+		t.Errorf("list resized to %d while too small", m.list.Height())
+	}
+
+	m = update(t, m, tea.WindowSizeMsg{Width: 80, Height: 20})
+	if view := m.View(); strings.Contains(view, "terminal too small") || !strings.Contains(view, "Etcd Pods") {
+		t.Errorf("view after growing:\n%s", view)
+	}
+}
+
+func TestWithLineNumbers(t *testing.T) {
+	numbered := func(n int) string {
+		lines := make([]string, n)
+		for i := range lines {
+			lines[i] = "line"
+		}
+		return strings.Join(lines, "\n")
+	}
+	tests := []struct {
+		name      string
+		content   string
+		wantFirst string
+		wantLast  string
+	}{
+		{"single digit", numbered(9), "1 line", "9 line"},
+		{"ten lines", numbered(10), " 1 line", "10 line"},
+		{"hundred lines", numbered(100), "  1 line", "100 line"},
+		// The trailing newline ends the last line, it doesn't start a numbered empty one
+		{"trailing newline", numbered(10) + "\n", " 1 line", "10 line\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := withLineNumbers(tt.content, lipgloss.NewStyle())
+			if !strings.HasPrefix(got, tt.wantFirst+"\n") || !strings.HasSuffix(got, "\n"+tt.wantLast) {
+				t.Errorf("withLineNumbers() = %q, want it from %q to %q", got, tt.wantFirst, tt.wantLast)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// etcdMemberStatus mirrors an entry of the Etcd resource's .status.members
+// etcd-druid fills these in from the member lease and the etcd cluster itself
+type etcdMemberStatus struct {
+	Name   string `json:"name"`
+	ID     string `json:"id,omitempty"`
+	Role   string `json:"role,omitempty"`
+	Status string `json:"status"`
+}
+
+// etcdStatusMembers is the part of the Etcd resource status holding the members
+type etcdStatusMembers struct {
+	Status struct {
+		Members []etcdMemberStatus `json:"members"`
+	} `json:"status"`
+}
+
+// fetchEtcdMembers returns the members reported on the Etcd resource, keyed by name
+// Member names match the names of the pods they run in
+func (m *Model) fetchEtcdMembers(namespace string) (map[string]etcdMemberStatus, error) {
+	etcd, err := m.fetchEtcdResourceIn(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded etcdStatusMembers
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(etcd.Object, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode members of Etcd %s/%s: %w", namespace, m.etcdName, err)
+	}
+
+	members := make(map[string]etcdMemberStatus, len(decoded.Status.Members))
+	for _, member := range decoded.Status.Members {
+		members[member.Name] = member
+	}
+	return members, nil
+}
+
+// memberRole renders the role of a member the way etcd itself talks about it
+// etcd-druid calls a non-leader voting member "Member", which reads better as follower
+func memberRole(member etcdMemberStatus) string {
+	switch strings.ToLower(member.Role) {
+	case "leader":
+		return "leader"
+	case "learner":
+		return "learner"
+	case "":
+		return "unknown"
+	}
+	return "follower"
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func testEtcdWithMembers(members ...map[string]interface{}) *unstructured.Unstructured {
+	list := make([]interface{}, len(members))
+	for i, member := range members {
+		list[i] = member
+	}
+	etcd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{"members": list},
+	}}
+	etcd.SetAPIVersion("druid.gardener.cloud/v1alpha1")
+	etcd.SetKind("Etcd")
+	etcd.SetNamespace(testNamespace)
+	etcd.SetName(testEtcdName)
+	return etcd
+}
+
+func TestFetchEtcdPodsMemberRoles(t *testing.T) {
+	etcd := testEtcdWithMembers(
+		map[string]interface{}{"name": "etcd-main-0", "id": "1", "role": "Leader", "status": "Ready"},
+		map[string]interface{}{"name": "etcd-main-1", "id": "2", "role": "Member", "status": "NotReady"},
+	)
+	m, _ := newTestModel([]runtime.Object{
+		testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd")),
+		testPod("etcd-main-1", corev1.PodRunning, runningContainer("etcd")),
+		// Scaled up, but not reported as a member yet
+		testPod("etcd-main-2", corev1.PodPending),
+	}, etcd)
+
+	pods, err := m.fetchEtcdPods()
+	if err != nil {
+		t.Fatalf("fetchEtcdPods() error = %v", err)
+	}
+
+	want := map[string][2]string{
+		"etcd-main-0": {"leader", "Ready"},
+		"etcd-main-1": {"follower", "NotReady"},
+		"etcd-main-2": {"", ""},
+	}
+	for _, pod := range pods {
+		if got := [2]string{pod.Role, pod.Member}; got != want[pod.Name] {
+			t.Errorf("%s role/member = %v, want %v", pod.Name, got, want[pod.Name])
+		}
+	}
+	if got := pods[0].Title(); got != "etcd-main-0 (leader)" {
+		t.Errorf("leader Title() = %q", got)
+	}
+}
+
+func TestFetchEtcdPodsWithoutEtcdResource(t *testing.T) {
+	m, _ := newTestModel([]runtime.Object{testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))})
+	pods, err := m.fetchEtcdPods()
+	if err != nil {
+		t.Fatalf("fetchEtcdPods() error = %v, want roles to be best effort", err)
+	}
+	if len(pods) != 1 || pods[0].Role != "" {
+		t.Errorf("fetchEtcdPods() = %+v, want one pod without a role", pods)
+	}
+}
+
+func TestMemberRole(t *testing.T) {
+	tests := map[string]string{"Leader": "leader", "Member": "follower", "Learner": "learner", "": "unknown"}
+	for role, want := range tests {
+		if got := memberRole(etcdMemberStatus{Role: role}); got != want {
+			t.Errorf("memberRole(%q) = %q, want %q", role, got, want)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// mergedLogPrefix tags a merged log line with the container it came from, e.g. "[etcd] "
+func mergedLogPrefix(container string) string {
+	return "[" + container + "] "
+}
+
+// splitMergedLogLine separates the container tag of a merged log line from the line itself
+func splitMergedLogLine(line string) (container, rest string, ok bool) {
+	if !strings.HasPrefix(line, "[") {
+		return "", line, false
+	}
+	container, rest, ok = strings.Cut(line[1:], "] ")
+	if !ok {
+		return "", line, false
+	}
+	return container, rest, true
+}
+
+// containerLogs is the log of one container, or the error reading it
+type containerLogs struct {
+	container string
+	content   string
+	err       error
+}
+
+// getMergedPodLogs fetches the logs of every container and interleaves them by kubelet timestamp
+// A container whose logs can't be read gets a note instead of failing the whole view
+func (m *Model) getMergedPodLogs(namespace, podName string, containers []Container) (string, bool, error) {
+	logs := make([]containerLogs, len(containers))
+	var truncated bool
+	failures := 0
+	for i, c := range containers {
+		// Timestamps are always requested for sorting; interleaveLogs drops them again unless switched on
+		opts := m.podLogOptions(c.Name)
+		opts.Timestamps = true
+		content, cut, err := m.streamPodLogs(namespace, podName, opts)
+		if err != nil {
+			failures++
+		}
+		logs[i] = containerLogs{container: c.Name, content: content, err: err}
+		truncated = truncated || cut
+	}
+	if failures > 0 && failures == len(containers) {
+		return "", false, fmt.Errorf("failed to get logs for any container of pod %s: %w", podName, logs[0].err)
+	}
+	return interleaveLogs(logs, m.timestamps), truncated, nil
+}
+
+// interleaveLogs merges timestamped container logs into one, oldest line first, each tagged with its container
+// Notes about unreadable logs and lines without a timestamp keep their place at the front
+func interleaveLogs(logs []containerLogs, keepTimestamps bool) string {
+	type timestampedLine struct {
+		time time.Time
+		line string
+	}
+	var lines []timestampedLine
+	for _, l := range logs {
+		prefix := mergedLogPrefix(l.container)
+		if l.err != nil {
+			lines = append(lines, timestampedLine{line: prefix + fmt.Sprintf("(logs unavailable: %v)", l.err)})
+			continue
+		}
+		for _, line := range splitLines(l.content) {
+			ts, rest, _ := strings.Cut(line, " ")
+			parsed, err := time.Parse(time.RFC3339Nano, ts)
+			if err != nil || keepTimestamps {
+				rest = line
+			}
+			lines = append(lines, timestampedLine{time: parsed, line: prefix + rest})
+		}
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].time.Before(lines[j].time) })
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		out[i] = l.line
+	}
+	return strings.Join(out, "\n")
+}
+
+// loadMergedLogs is a command that fetches the interleaved logs of all containers asynchronously
+func (m *Model) loadMergedLogs() tea.Cmd {
+	namespace, podName, containers, follow := m.podNamespace(m.selectedPod), m.selectedPod.Name, m.containers, m.followLogs
+	return func() tea.Msg {
+		var uid types.UID
+		if follow {
+			uid = m.fetchPodUID(namespace, podName)
+		}
+		var truncated bool
+		content, err := retryFetch(func() (string, error) {
+			var content string
+			var err error
+			content, truncated, err = m.getMergedPodLogs(namespace, podName, containers)
+			return content, err
+		})
+		if err != nil {
+			return errMsg{err}
+		}
+		return logsLoadedMsg{content: content, truncated: truncated, merged: true, source: logSource(namespace, podName, "*"), uid: uid}
+	}
+}
+
+// setMergedLogs switches the log view between one container and all of them
+// The legend above merged logs takes a line, so the viewport is resized
+func (m *Model) setMergedLogs(merged bool) {
+	m.mergedLogs = merged
+	m.layout()
+}
+
+// reloadLogs re-fetches the log view, merged or for the current container; a followed container is streamed again
+func (m *Model) reloadLogs() tea.Cmd {
+	if m.streamsLogs() {
+		return m.startLogStream()
+	}
+	// Following goes back to re-fetching once switched to the merged view or a window
+	var refresh tea.Cmd
+	if m.logStream != nil {
+		m.stopLogStream()
+		refresh = m.scheduleRefresh()
+	}
+	if m.mergedLogs {
+		return tea.Batch(m.loadMergedLogs(), refresh)
+	}
+	return tea.Batch(m.loadLogs(m.currentContainer()), refresh)
+}
+
+// containerStyle returns the color of a container's tag in the merged view
+// Colors follow the container's position in the pod so they stay put between refreshes
+func (m Model) containerStyle(container string) lipgloss.Style {
+	return m.tagStyle(m.containerNames(), container)
+}
+
+// containerNames lists the containers of the selected pod, the tags of the merged view
+func (m Model) containerNames() []string {
+	names := make([]string, len(m.containers))
+	for i, c := range m.containers {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// tagStyle returns the color of a tag by its position among tags
+func (m Model) tagStyle(tags []string, tag string) lipgloss.Style {
+	for i, t := range tags {
+		if t == tag {
+			return m.theme.logContainers[i%len(m.theme.logContainers)]
+		}
+	}
+	return lipgloss.NewStyle()
+}
+
+// mergedLegend maps each tag color to its container name, shown above the merged view
+func (m Model) mergedLegend() string {
+	return m.tagLegend(m.containerNames())
+}
+
+// tagLegend maps each tag color to its tag
+func (m Model) tagLegend(tags []string) string {
+	entries := make([]string, len(tags))
+	for i, tag := range tags {
+		entries[i] = m.tagStyle(tags, tag).Render("■ " + tag)
+	}
+	return strings.Join(entries, "  ")
+}
+
+// renderedMergedLogs renders merged logs with each line's tag colored and padded to the longest container name
+func (m Model) renderedMergedLogs() string {
+	return m.renderTaggedLogs(m.containerNames())
+}
+
+// renderTaggedLogs renders m.content, whose lines carry one of tags, with each tag colored and padded to the longest
+// Severity and grep filtering and pretty-printing look at the line without its tag
+func (m Model) renderTaggedLogs(tagNames []string) string {
+	width := 0
+	for _, tag := range tagNames {
+		width = max(width, len(tag))
+	}
+
+	lines := strings.Split(m.content, "\n")
+	tags := make([]string, len(lines))
+	rests := make([]string, len(lines))
+	for i, line := range lines {
+		tags[i], rests[i], _ = splitMergedLogLine(line)
+	}
+
+	keep := logMask(rests, m.minSeverity, m.logGrep)
+	var keptTags, keptRests []string
+	for i := range lines {
+		if keep[i] {
+			keptTags = append(keptTags, tags[i])
+			keptRests = append(keptRests, rests[i])
+		}
+	}
+
+	// Nothing passed the filter; splitting the empty body would still yield a line without a tag
+	if len(keptRests) == 0 {
+		return ""
+	}
+
+	body := strings.Join(keptRests, "\n")
+	if !m.rawLogs {
+		body = prettyPrintLogs(body, m.theme)
+	}
+	// Pretty-printing renders exactly one line per input line, so the tags still line up
+	rendered := strings.Split(body, "\n")
+	for i := range rendered {
+		tag := fmt.Sprintf("%-*s", width, keptTags[i])
+		rendered[i] = m.tagStyle(tagNames, keptTags[i]).Render(tag) + " " + rendered[i]
+	}
+	return strings.Join(rendered, "\n")
+}
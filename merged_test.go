@@ -0,0 +1,119 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestInterleaveLogs(t *testing.T) {
+	logs := []containerLogs{
+		{container: "etcd", content: "2024-05-01T10:00:00Z started\n2024-05-01T10:00:02Z elected leader\n"},
+		{container: "backup-restore", content: "2024-05-01T10:00:01Z waiting for etcd\n2024-05-01T10:00:03Z snapshot taken\n"},
+		{container: "debugger", err: errors.New("container not started")},
+	}
+
+	want := strings.Join([]string{
+		"[debugger] (logs unavailable: container not started)",
+		"[etcd] started",
+		"[backup-restore] waiting for etcd",
+		"[etcd] elected leader",
+		"[backup-restore] snapshot taken",
+	}, "\n")
+	if got := interleaveLogs(logs, false); got != want {
+		t.Errorf("interleaveLogs() =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := interleaveLogs(logs[:1], true); !strings.HasPrefix(got, "[etcd] 2024-05-01T10:00:00Z started\n") {
+		t.Errorf("interleaveLogs() with timestamps = %q, want them kept", got)
+	}
+}
+
+func TestSplitMergedLogLine(t *testing.T) {
+	tests := []struct {
+		line          string
+		container     string
+		rest          string
+		wantContainer bool
+	}{
+		{"[etcd] {\"level\":\"info\"}", "etcd", "{\"level\":\"info\"}", true},
+		{"[backup-restore] [brackets] inside", "backup-restore", "[brackets] inside", true},
+		{"untagged line", "", "untagged line", false},
+		{"[unterminated", "", "[unterminated", false},
+	}
+	for _, tt := range tests {
+		container, rest, ok := splitMergedLogLine(tt.line)
+		if container != tt.container || rest != tt.rest || ok != tt.wantContainer {
+			t.Errorf("splitMergedLogLine(%q) = %q, %q, %v", tt.line, container, rest, ok)
+		}
+	}
+}
+
+func TestMergedLogView(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("l"))
+	m = update(t, m, containersLoadedMsg{[]Container{{Name: "etcd"}, {Name: "backup-restore"}}})
+	singleHeight := m.viewport.Height
+
+	next, cmd := m.Update(keyMsg("A"))
+	m = next.(Model)
+	if !m.mergedLogs || cmd == nil {
+		t.Fatalf("A on the container list: mergedLogs = %v, want the merged logs loading", m.mergedLogs)
+	}
+	m = update(t, m, logsLoadedMsg{content: "[etcd] started\n[backup-restore] waiting for etcd", merged: true})
+	if m.state != LogState {
+		t.Fatalf("state = %v, want LogState", m.state)
+	}
+	// A single-container load finishing late must not replace the merged view
+	m = update(t, m, logsLoadedMsg{content: "stale"})
+	if m.content == "stale" {
+		t.Error("a single-container load overwrote the merged logs")
+	}
+	if m.viewport.Height != singleHeight-1 {
+		t.Errorf("viewport height = %d, want %d to make room for the legend", m.viewport.Height, singleHeight-1)
+	}
+
+	view := m.View()
+	for _, want := range []string{"[all containers]", "■ etcd  ■ backup-restore", "etcd           started", "backup-restore waiting for etcd"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("merged view missing %q:\n%s", want, view)
+		}
+	}
+
+	// Grep looks past the tag, and hiding every line leaves a notice rather than a panic
+	m.logGrep, _ = parseLogGrep("^wait")
+	if got := m.renderedMergedLogs(); !strings.Contains(got, "waiting for etcd") || strings.Contains(got, "started") {
+		t.Errorf("grep ^wait rendered %q, want only the backup-restore line", got)
+	}
+	m.logGrep, _ = parseLogGrep("nothing")
+	if got := m.viewportContent(); !strings.Contains(got, "No lines match") {
+		t.Errorf("viewportContent() = %q, want a notice that nothing matches", got)
+	}
+	m.logGrep = nil
+
+	// Colors follow the container's position, not the line it appears on
+	if m.containerStyle("backup-restore").GetForeground() != m.theme.logContainers[1].GetForeground() {
+		t.Error("backup-restore isn't colored by its container index")
+	}
+
+	m = update(t, m, keyMsg("A"))
+	if m.mergedLogs || m.viewport.Height != singleHeight {
+		t.Errorf("after toggling back: mergedLogs = %v, viewport height = %d", m.mergedLogs, m.viewport.Height)
+	}
+}
+
+func TestRenderedMergedLogsAllFiltered(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m.containers = []Container{{Name: "etcd"}}
+	m.minSeverity = severityError
+	for _, content := range []string{`[etcd] {"level":"info","msg":"started"}`, ""} {
+		m.content = content
+		if got := m.renderedMergedLogs(); got != "" {
+			t.Errorf("renderedMergedLogs() of %q with every line filtered = %q, want empty", content, got)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// metadataChange adds, or with remove set deletes, one label or annotation of a pod
+type metadataChange struct {
+	field  string // "labels" or "annotations", as in the pod's metadata
+	key    string
+	value  string
+	remove bool
+}
+
+// parseMetadataKind reads which metadata the prompt is about, accepting kubectl's singular words and their initials
+func parseMetadataKind(value string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "label", "labels", "l":
+		return "labels", nil
+	case "annotation", "annotations", "a":
+		return "annotations", nil
+	}
+	return "", fmt.Errorf("give label or annotation, not %q", value)
+}
+
+// parseMetadataKey validates a key the way the API server would, with a trailing - removing it like kubectl label
+func parseMetadataKey(field, value string) (metadataChange, error) {
+	key, remove := strings.CutSuffix(strings.TrimSpace(value), "-")
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return metadataChange{}, fmt.Errorf("invalid %s key %q: %s", strings.TrimSuffix(field, "s"), key, strings.Join(errs, "; "))
+	}
+	return metadataChange{field: field, key: key, remove: remove}, nil
+}
+
+// validateMetadataValue checks a label value; annotation values can be any text
+func validateMetadataValue(change metadataChange) error {
+	if change.field != "labels" {
+		return nil
+	}
+	if errs := validation.IsValidLabelValue(change.value); len(errs) > 0 {
+		return fmt.Errorf("invalid label value %q: %s", change.value, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// metadataPatch builds the strategic merge patch for a change, where null deletes the key
+func metadataPatch(change metadataChange) ([]byte, error) {
+	var value interface{} = change.value
+	if change.remove {
+		value = nil
+	}
+	return json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{change.field: map[string]interface{}{change.key: value}},
+	})
+}
+
+// String describes the change kubectl-style, e.g. "label debug=true" or "annotation example.com/note-"
+func (c metadataChange) String() string {
+	kind := strings.TrimSuffix(c.field, "s")
+	if c.remove {
+		return fmt.Sprintf("%s %s-", kind, c.key)
+	}
+	return fmt.Sprintf("%s %s=%s", kind, c.key, c.value)
+}
+
+// promptPodMetadata asks what to change on the pod's metadata: the kind, then the key, then the value unless it is removed
+func (m *Model) promptPodMetadata(pod Pod) tea.Cmd {
+	return m.openPrompt(fmt.Sprintf("label or annotation of %s", pod.Name), "annotation", func(m *Model, value string) tea.Cmd {
+		field, err := parseMetadataKind(value)
+		if err != nil {
+			return m.setStatus(err.Error())
+		}
+		return m.promptMetadataKey(pod, field)
+	})
+}
+
+// promptMetadataKey asks for the key to set, or to remove with a trailing -
+func (m *Model) promptMetadataKey(pod Pod, field string) tea.Cmd {
+	label := fmt.Sprintf("%s key (key- removes it)", strings.TrimSuffix(field, "s"))
+	return m.openPrompt(label, "", func(m *Model, value string) tea.Cmd {
+		change, err := parseMetadataKey(field, value)
+		if err != nil {
+			return m.setStatus(err.Error())
+		}
+		if change.remove {
+			return m.patchPodMetadata(pod, change)
+		}
+		return m.openPrompt(change.key, "", func(m *Model, value string) tea.Cmd {
+			change.value = value
+			if err := validateMetadataValue(change); err != nil {
+				return m.setStatus(err.Error())
+			}
+			return m.patchPodMetadata(pod, change)
+		})
+	})
+}
+
+// podMetadataPatchedMsg reports the outcome of changing a pod's labels or annotations
+type podMetadataPatchedMsg struct {
+	pod    Pod
+	change metadataChange
+	err    error
+}
+
+// patchPodMetadata applies a label or annotation change to a pod with a strategic merge patch
+func (m *Model) patchPodMetadata(pod Pod, change metadataChange) tea.Cmd {
+	namespace := m.podNamespace(pod)
+	m.beginOperation(metadataOperation(pod.Name))
+	return func() tea.Msg {
+		patch, err := metadataPatch(change)
+		if err != nil {
+			return podMetadataPatchedMsg{pod: pod, change: change, err: fmt.Errorf("failed to build patch for pod %s: %w", pod.Name, err)}
+		}
+		ctx, cancel := m.requestContext()
+		defer cancel()
+		pods := m.kubeClient.CoreV1().Pods(namespace)
+		var before *corev1.Pod
+		if m.dryRun {
+			if before, err = pods.Get(ctx, pod.Name, metav1.GetOptions{}); err != nil {
+				return podMetadataPatchedMsg{pod: pod, change: change, err: fmt.Errorf("failed to get pod %s: %w", pod.Name, err)}
+			}
+		}
+		after, err := pods.Patch(ctx, pod.Name, types.StrategicMergePatchType, patch, m.patchOptions())
+		if apierrors.IsForbidden(err) {
+			err = fmt.Errorf("not permitted to patch pod %s", pod.Name)
+		} else if err != nil {
+			err = fmt.Errorf("failed to patch pod %s: %w", pod.Name, err)
+		}
+		if err == nil && m.dryRun {
+			return dryRunResult(metadataOperation(pod.Name), fmt.Sprintf("apply %s to pod %s", change, pod.Name), before, after)
+		}
+		return podMetadataPatchedMsg{pod: pod, change: change, err: err}
+	}
+}
+
+// showPatchedMetadata describes the patched pod, so its labels and annotations can be checked
+func (m *Model) showPatchedMetadata(msg podMetadataPatchedMsg) tea.Cmd {
+	m.endOperation(metadataOperation(msg.pod.Name))
+	if msg.err != nil {
+		return m.setStatus(msg.err.Error())
+	}
+	status := m.setStatus(fmt.Sprintf("applied %s to pod %s", msg.change, msg.pod.Name))
+	if m.state != DescribeState {
+		m.navigate(DescribeState)
+	}
+	m.selectedPod = msg.pod
+	m.describeEtcd = false
+	m.describeOne = ""
+	return tea.Batch(status, m.startLoading(m.describeLoadingText()), m.loadDescribe())
+}
+
+// writeMetadata lists labels or annotations in describe, sorted by key like kubectl
+func writeMetadata(desc *strings.Builder, title string, values map[string]string) {
+	if len(values) == 0 {
+		desc.WriteString(fmt.Sprintf("%s: <none>\n", title))
+		return
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	desc.WriteString(title + ":\n")
+	for _, key := range keys {
+		desc.WriteString(fmt.Sprintf("  %s=%s\n", key, values[key]))
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestParseMetadataKey(t *testing.T) {
+	change, err := parseMetadataKey("labels", "example.com/debug-")
+	if err != nil || change.key != "example.com/debug" || !change.remove {
+		t.Errorf("parseMetadataKey() = %+v, %v, want the key marked for removal", change, err)
+	}
+	if _, err := parseMetadataKey("annotations", "not a key"); err == nil || !strings.Contains(err.Error(), "invalid annotation key") {
+		t.Errorf("parseMetadataKey() error = %v, want the key rejected", err)
+	}
+	if err := validateMetadataValue(metadataChange{field: "labels", key: "debug", value: "has spaces"}); err == nil {
+		t.Error("validateMetadataValue() accepted a label value with spaces")
+	}
+	if err := validateMetadataValue(metadataChange{field: "annotations", key: "note", value: "has spaces"}); err != nil {
+		t.Errorf("validateMetadataValue() error = %v, annotation values are free text", err)
+	}
+	if _, err := parseMetadataKind("taint"); err == nil {
+		t.Error("parseMetadataKind() accepted something other than a label or annotation")
+	}
+}
+
+// submitPrompt types value into the open prompt and submits it
+func submitPrompt(t *testing.T, m Model, value string) Model {
+	t.Helper()
+	if m.prompt == nil {
+		t.Fatalf("no prompt open to answer %q", value)
+	}
+	m.prompt.input.SetValue(value)
+	next, cmd := m.Update(keyMsg("enter"))
+	m = next.(Model)
+	if cmd != nil && m.prompt == nil {
+		if msg, ok := cmd().(podMetadataPatchedMsg); ok {
+			m = update(t, m, msg)
+		}
+	}
+	return m
+}
+
+func TestPatchPodMetadata(t *testing.T) {
+	pod := testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))
+	m, client := newTestModel([]runtime.Object{pod})
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0", Namespace: testNamespace}}})
+
+	m = update(t, m, keyMsg("M"))
+	m = submitPrompt(t, m, "label")
+	m = submitPrompt(t, m, "debug")
+	m = submitPrompt(t, m, "true")
+	if m.state != DescribeState || !strings.Contains(m.status, "applied label debug=true to pod etcd-main-0") {
+		t.Fatalf("state = %v, status %q, want the patched pod described", m.state, m.status)
+	}
+	patched, err := client.CoreV1().Pods(testNamespace).Get(context.Background(), "etcd-main-0", metav1.GetOptions{})
+	if err != nil || patched.Labels["debug"] != "true" || patched.Labels["app.kubernetes.io/name"] != testEtcdName {
+		t.Fatalf("labels = %v, %v, want debug added next to the existing ones", patched.Labels, err)
+	}
+	m = update(t, m, m.loadDescribe()())
+	if !strings.Contains(m.content, "Labels:\n  app.kubernetes.io/name="+testEtcdName+"\n  debug=true\n") || !strings.Contains(m.content, "Annotations: <none>") {
+		t.Errorf("describe doesn't show the updated metadata:\n%s", m.content)
+	}
+
+	// A trailing - removes it again, straight from the describe view
+	m = update(t, m, keyMsg("M"))
+	m = submitPrompt(t, m, "label")
+	m = submitPrompt(t, m, "debug-")
+	if patched, _ := client.CoreV1().Pods(testNamespace).Get(context.Background(), "etcd-main-0", metav1.GetOptions{}); patched.Labels["debug"] != "" {
+		t.Errorf("labels = %v, want debug removed", patched.Labels)
+	}
+
+	m.readOnly = true
+	m = update(t, m, keyMsg("M"))
+	if m.prompt != nil || !strings.Contains(m.status, "read-only mode") {
+		t.Errorf("M in read-only mode opened a prompt, status %q", m.status)
+	}
+}
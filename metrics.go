@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// podMetricsGVR identifies PodMetrics served by metrics-server
+// We go through the dynamic client so we don't need the metrics clientset as a dependency
+var podMetricsGVR = schema.GroupVersionResource{
+	Group:    "metrics.k8s.io",
+	Version:  "v1beta1",
+	Resource: "pods",
+}
+
+// metricsUnavailable is shown instead of an error when the cluster has no metrics-server
+const metricsUnavailable = "metrics-server not available"
+
+// podMetrics mirrors the parts of metrics.k8s.io/v1beta1 PodMetrics we display
+type podMetrics struct {
+	Metadata   metav1.ObjectMeta `json:"metadata"`
+	Containers []struct {
+		Name  string              `json:"name"`
+		Usage corev1.ResourceList `json:"usage"`
+	} `json:"containers"`
+}
+
+// fetchPodMetrics renders per-container CPU and memory usage of the etcd pods next to their limits
+func (m *Model) fetchPodMetrics() (string, error) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	metricsList, err := m.dynamicClient.Resource(podMetricsGVR).Namespace(m.namespace).List(
+		ctx, metav1.ListOptions{LabelSelector: m.podLabelSelector()})
+	if err != nil {
+		// A missing metrics API is a cluster setup detail, not a failure of this tool
+		if apierrors.IsNotFound(err) || apierrors.IsServiceUnavailable(err) || meta.IsNoMatchError(err) {
+			return metricsUnavailable, nil
+		}
+		return "", fmt.Errorf("failed to get pod metrics: %w", err)
+	}
+
+	podList, err := m.kubeClient.CoreV1().Pods(m.namespace).List(
+		ctx, metav1.ListOptions{LabelSelector: m.podLabelSelector()})
+	if err != nil {
+		return "", fmt.Errorf("failed to list etcd pods: %w", err)
+	}
+
+	// Index container limits by pod and container name
+	limits := map[string]corev1.ResourceList{}
+	for _, pod := range podList.Items {
+		for _, container := range pod.Spec.Containers {
+			limits[pod.Name+"/"+container.Name] = container.Resources.Limits
+		}
+	}
+
+	var out strings.Builder
+	w := tabwriter.NewWriter(&out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "POD\tCONTAINER\tCPU (used/limit)\tMEMORY (used/limit)")
+	for _, item := range metricsList.Items {
+		var metrics podMetrics
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &metrics); err != nil {
+			return "", fmt.Errorf("failed to decode metrics for pod %s: %w", item.GetName(), err)
+		}
+		for _, container := range metrics.Containers {
+			limit := limits[metrics.Metadata.Name+"/"+container.Name]
+			fmt.Fprintf(w, "%s\t%s\t%s / %s\t%s / %s\n",
+				metrics.Metadata.Name, container.Name,
+				formatCPU(container.Usage, true), formatCPU(limit, false),
+				formatMemory(container.Usage, true), formatMemory(limit, false))
+		}
+	}
+	w.Flush()
+
+	if len(metricsList.Items) == 0 {
+		out.WriteString("\nNo metrics reported yet for the etcd pods\n")
+	}
+	return out.String(), nil
+}
+
+// formatCPU renders a CPU quantity in millicores, or "-" when it is not set
+func formatCPU(resources corev1.ResourceList, usage bool) string {
+	q, ok := resources[corev1.ResourceCPU]
+	if !ok {
+		return quantityPlaceholder(usage)
+	}
+	return fmt.Sprintf("%dm", q.MilliValue())
+}
+
+// formatMemory renders a memory quantity in MiB, or "-" when it is not set
+func formatMemory(resources corev1.ResourceList, usage bool) string {
+	q, ok := resources[corev1.ResourceMemory]
+	if !ok {
+		return quantityPlaceholder(usage)
+	}
+	return fmt.Sprintf("%dMi", q.Value()/(1<<20))
+}
+
+// quantityPlaceholder distinguishes missing usage data from an unset limit
+func quantityPlaceholder(usage bool) string {
+	if usage {
+		return "?"
+	}
+	return "-"
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func testPodMetrics(podName, container, cpu, memory string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "metrics.k8s.io/v1beta1",
+		"kind":       "PodMetrics",
+		"metadata": map[string]interface{}{
+			"name":      podName,
+			"namespace": testNamespace,
+			"labels":    map[string]interface{}{"app.kubernetes.io/name": testEtcdName},
+		},
+		"containers": []interface{}{
+			map[string]interface{}{
+				"name":  container,
+				"usage": map[string]interface{}{"cpu": cpu, "memory": memory},
+			},
+		},
+	}}
+}
+
+func TestFetchPodMetrics(t *testing.T) {
+	pod := testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))
+	pod.Spec.Containers[0].Resources.Limits = corev1.ResourceList{
+		corev1.ResourceMemory: resource.MustParse("1Gi"),
+	}
+
+	tests := []struct {
+		name         string
+		metrics      []runtime.Object
+		listErr      error
+		wantContains []string
+		wantErr      bool
+	}{
+		{
+			name:    "usage next to limits",
+			metrics: []runtime.Object{testPodMetrics("etcd-main-0", "etcd", "250m", "512Mi")},
+			wantContains: []string{
+				"POD", "CONTAINER",
+				"etcd-main-0", "250m / -", "512Mi / 1024Mi",
+			},
+		},
+		{
+			name:         "no metrics yet",
+			wantContains: []string{"No metrics reported yet"},
+		},
+		{
+			name:         "metrics-server missing",
+			listErr:      apierrors.NewNotFound(podMetricsGVR.GroupResource(), ""),
+			wantContains: []string{metricsUnavailable},
+		},
+		{
+			name:    "forbidden",
+			listErr: apierrors.NewForbidden(podMetricsGVR.GroupResource(), "", nil),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _ := newTestModel([]runtime.Object{pod})
+			dynamicClient := m.dynamicClient.(*dynamicfake.FakeDynamicClient)
+			// PodMetrics don't follow the kind-to-resource naming the tracker guesses, so add them explicitly
+			for _, obj := range tt.metrics {
+				if err := dynamicClient.Tracker().Create(podMetricsGVR, obj, testNamespace); err != nil {
+					t.Fatalf("failed to seed metrics: %v", err)
+				}
+			}
+			if tt.listErr != nil {
+				dynamicClient.PrependReactor("list", "pods",
+					func(action k8stesting.Action) (bool, runtime.Object, error) {
+						return true, nil, tt.listErr
+					})
+			}
+
+			content, err := m.fetchPodMetrics()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fetchPodMetrics() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(content, want) {
+					t.Errorf("fetchPodMetrics() output missing %q:\n%s", want, content)
+				}
+			}
+		})
+	}
+}
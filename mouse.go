@@ -0,0 +1,125 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// updateMouse handles clicks and the wheel; every action still has its key, the mouse is only a shortcut
+// Clicks select the row under the pointer in the pod and container lists, the wheel scrolls whatever is on screen
+func (m *Model) updateMouse(msg tea.MouseMsg) tea.Cmd {
+	// An open prompt, a filter being typed or the error screen own the input
+	if m.err != nil || m.prompt != nil {
+		return nil
+	}
+	// The breadcrumb is the top row
+	if msg.Y == 0 && msg.Button == tea.MouseButtonLeft && msg.Action == tea.MouseActionPress {
+		if level, ok := m.crumbAt(msg.X); ok {
+			return m.jumpToCrumb(level)
+		}
+		return nil
+	}
+
+	switch m.state {
+	case ListState:
+		if m.list.FilterState() == list.Filtering {
+			return nil
+		}
+		mouseList(&m.list, m.podListDelegate(), m.podListTop(), msg)
+		return nil
+	case ContainerSelectState:
+		if len(m.containers) == 0 || m.containerList.FilterState() == list.Filtering {
+			return nil
+		}
+		top := 1 + lipgloss.Height(m.theme.header.Render(""))
+		mouseList(&m.containerList, list.NewDefaultDelegate(), top, msg)
+		return nil
+	case SplitLogsState:
+		// The wheel scrolls the pane under the pointer
+		pane := &m.splitPanes[0]
+		if msg.X > pane.viewport.Width {
+			pane = &m.splitPanes[1]
+		}
+		var cmd tea.Cmd
+		pane.viewport, cmd = pane.viewport.Update(msg)
+		return cmd
+	}
+
+	// The viewport scrolls itself on wheel events
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return cmd
+}
+
+// mouseList moves the selection of l: the wheel steps through the items, a left click selects the row under the pointer
+// top is the screen row where l is rendered
+func mouseList(l *list.Model, delegate list.ItemDelegate, top int, msg tea.MouseMsg) {
+	switch {
+	case msg.Button == tea.MouseButtonWheelUp:
+		l.CursorUp()
+	case msg.Button == tea.MouseButtonWheelDown:
+		l.CursorDown()
+	case msg.Button == tea.MouseButtonLeft && msg.Action == tea.MouseActionPress:
+		if index, ok := listItemAt(*l, delegate, msg.Y-top); ok {
+			l.Select(index)
+		}
+	}
+}
+
+// listItemAt maps a row relative to the top of a rendered list to the index of the visible item drawn there
+// Items are drawn in blocks of Height rows separated by Spacing, below whatever title bar the list shows
+func listItemAt(l list.Model, delegate list.ItemDelegate, row int) (int, bool) {
+	items := l.VisibleItems()
+	if len(items) == 0 {
+		return 0, false
+	}
+	start, end := l.Paginator.GetSliceBounds(len(items))
+	row -= listItemsTop(l, delegate, items[start], start)
+	stride := delegate.Height() + delegate.Spacing()
+	if row < 0 || row%stride >= delegate.Height() {
+		return 0, false
+	}
+
+	index := start + row/stride
+	if index >= end {
+		return 0, false
+	}
+	return index, true
+}
+
+// listItemsTop finds the row of the first item on the page within the rendered list
+// The height of the title bar depends on list state without a getter, e.g. the spinner, so it's measured instead
+func listItemsTop(l list.Model, delegate list.ItemDelegate, first list.Item, index int) int {
+	var item strings.Builder
+	delegate.Render(&item, l, index, first)
+	firstLine, _, _ := strings.Cut(item.String(), "\n")
+	for i, line := range strings.Split(l.View(), "\n") {
+		if strings.HasPrefix(line, firstLine) {
+			return i
+		}
+	}
+	return 0
+}
+
+// podListTop returns the screen row where the pod list starts, below the breadcrumb, the header, the table headings and the backup summary
+func (m *Model) podListTop() int {
+	top := 1 + lipgloss.Height(m.theme.header.Render(""))
+	if m.compactList {
+		top++
+	}
+	if m.backupSummary != "" {
+		top++
+	}
+	return top
+}
+
+// podListDelegate returns the delegate currently rendering the pod list
+func (m *Model) podListDelegate() list.ItemDelegate {
+	if delegate, ok := m.podTableDelegate(); ok {
+		return delegate
+	}
+	return newPodDelegate(m.theme)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// screenRow returns the first row of the rendered screen containing text
+func screenRow(t *testing.T, m Model, text string) int {
+	t.Helper()
+	for i, line := range strings.Split(m.View(), "\n") {
+		if strings.Contains(line, text) {
+			return i
+		}
+	}
+	t.Fatalf("%q is not on screen:\n%s", text, m.View())
+	return 0
+}
+
+func click(y int) tea.MouseMsg {
+	return tea.MouseMsg{Y: y, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress}
+}
+
+func TestMouseSelectsClickedPod(t *testing.T) {
+	for _, compact := range []bool{false, true} {
+		m, _ := newTestModel(nil)
+		m = update(t, m, tea.WindowSizeMsg{Width: 120, Height: 40})
+		m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}, {Name: "etcd-main-1"}, {Name: "etcd-main-2"}}})
+		m = update(t, m, backupSummaryMsg{"Backup: True"})
+		if compact {
+			m = update(t, m, keyMsg("t"))
+		}
+
+		m = update(t, m, click(screenRow(t, m, "etcd-main-2")))
+		if pod, _ := m.selectedListPod(); pod.Name != "etcd-main-2" {
+			t.Errorf("compact=%v: click selected %q, want etcd-main-2", compact, pod.Name)
+		}
+		// Clicking the header selects nothing
+		m = update(t, m, click(0))
+		if pod, _ := m.selectedListPod(); pod.Name != "etcd-main-2" {
+			t.Errorf("compact=%v: header click moved the selection to %q", compact, pod.Name)
+		}
+
+		m = update(t, m, tea.MouseMsg{Button: tea.MouseButtonWheelUp, Action: tea.MouseActionPress})
+		if pod, _ := m.selectedListPod(); pod.Name != "etcd-main-1" {
+			t.Errorf("compact=%v: wheel up selected %q, want etcd-main-1", compact, pod.Name)
+		}
+	}
+}
+
+func TestMouseWheelScrollsViewport(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, tea.WindowSizeMsg{Width: 80, Height: 10})
+	m.navigate(EventsState)
+	var events []corev1.Event
+	// Distinct reasons, repeats of one event would show as a single row
+	for i := range 50 {
+		events = append(events, *testEvent(fmt.Sprintf("e%d", i), "Pod", "etcd-main-0", corev1.EventTypeNormal, fmt.Sprintf("Started%d", i), time.Minute))
+	}
+	m = update(t, m, eventsLoadedMsg{etcdEvents{events: events}})
+	m.viewport.GotoTop()
+
+	m = update(t, m, tea.MouseMsg{Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress})
+	if m.viewport.YOffset == 0 {
+		t.Error("wheel down did not scroll the viewport")
+	}
+}
+
+func TestMouseIgnoredWhilePromptOpen(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, tea.WindowSizeMsg{Width: 80, Height: 40})
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}, {Name: "etcd-main-1"}}})
+	m.openPrompt("image", "", func(*Model, string) tea.Cmd { return nil })
+
+	m = update(t, m, click(screenRow(t, m, "etcd-main-1")))
+	if pod, _ := m.selectedListPod(); pod.Name != "etcd-main-0" {
+		t.Errorf("click selected %q while a prompt was open", pod.Name)
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// podNamespace returns the namespace a pod lives in
+// Every pod listed with --all-namespaces brings its own; m.namespace covers pods built without one
+func (m *Model) podNamespace(pod Pod) string {
+	if pod.Namespace != "" {
+		return pod.Namespace
+	}
+	return m.namespace
+}
+
+// podDisplayName names a pod unambiguously, which across namespaces takes the namespace too
+func (m *Model) podDisplayName(pod Pod) string {
+	if m.allNamespaces {
+		return m.podNamespace(pod) + "/" + pod.Name
+	}
+	return pod.Name
+}
+
+// samePod reports whether two list entries are the same pod
+// Names repeat across namespaces, e.g. etcd-main-0 in every shoot namespace
+func samePod(a, b Pod) bool {
+	return a.Name == b.Name && a.Namespace == b.Namespace
+}
+
+// namespaceLabel names the namespace being viewed in titles and the footer
+func (m *Model) namespaceLabel() string {
+	if m.allNamespaces {
+		return "<all>"
+	}
+	return m.namespace
+}
+
+// guardSingleNamespace reports whether a screen about the one Etcd resource may open
+// With --all-namespaces there is no single Etcd, so it returns false along with a notice instead
+func (m *Model) guardSingleNamespace(action string) (bool, tea.Cmd) {
+	if !m.allNamespaces {
+		return true, nil
+	}
+	return false, m.setStatus(fmt.Sprintf("%s needs a namespace, restart without --all-namespaces", action))
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// otherNamespacePod builds an etcd member pod like testPod, but in another namespace
+func otherNamespacePod(namespace, name string) *corev1.Pod {
+	pod := testPod(name, corev1.PodRunning, runningContainer("etcd"))
+	pod.Namespace = namespace
+	return pod
+}
+
+func TestAllNamespacesListsEveryNamespace(t *testing.T) {
+	m, _ := newTestModel([]runtime.Object{
+		testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd")),
+		otherNamespacePod("shoot--bar", "etcd-main-0"),
+	})
+	m.namespace, m.allNamespaces = "", true
+
+	pods, err := m.fetchEtcdPods()
+	if err != nil {
+		t.Fatalf("fetchEtcdPods() error = %v", err)
+	}
+	namespaces := map[string]bool{}
+	for _, pod := range pods {
+		namespaces[pod.Namespace] = true
+	}
+	if len(pods) != 2 || !namespaces[testNamespace] || !namespaces["shoot--bar"] {
+		t.Fatalf("fetchEtcdPods() = %+v, want etcd-main-0 from both namespaces", pods)
+	}
+
+	m = update(t, m, podsLoadedMsg{pods: pods})
+	for _, item := range m.list.Items() {
+		pod := item.(Pod)
+		if want := pod.Namespace + "/etcd-main-0"; pod.Title() != want {
+			t.Errorf("Title() = %q, want %q", pod.Title(), want)
+		}
+	}
+}
+
+func TestAllNamespacesUsesPodNamespace(t *testing.T) {
+	m, _ := newTestModel([]runtime.Object{otherNamespacePod("shoot--bar", "etcd-main-0")})
+	m.namespace, m.allNamespaces = "", true
+
+	pod := Pod{Name: "etcd-main-0", Namespace: "shoot--bar"}
+	desc, err := m.describePod(m.podNamespace(pod), pod.Name)
+	if err != nil {
+		t.Fatalf("describePod() error = %v", err)
+	}
+	if !strings.Contains(desc, "Namespace: shoot--bar") {
+		t.Errorf("describePod() described the wrong pod:\n%s", desc)
+	}
+	if got := m.kubectlLogsCommand(m.podNamespace(pod), pod.Name, "etcd"); !strings.Contains(got, "-n shoot--bar") {
+		t.Errorf("kubectlLogsCommand() = %q, want the pod's namespace", got)
+	}
+}
+
+func TestGuardSingleNamespace(t *testing.T) {
+	m, _ := newTestModel(nil)
+	if ok, cmd := m.guardSingleNamespace("members"); !ok || cmd != nil {
+		t.Errorf("guardSingleNamespace() blocked a single namespace")
+	}
+
+	m.allNamespaces = true
+	ok, cmd := m.guardSingleNamespace("members")
+	if ok || cmd == nil || !strings.Contains(m.status, "without --all-namespaces") {
+		t.Errorf("guardSingleNamespace() = %v with status %q, want a notice", ok, m.status)
+	}
+}
+
+func TestSamePodComparesNamespaces(t *testing.T) {
+	a := Pod{Name: "etcd-main-0", Namespace: "shoot--foo"}
+	b := Pod{Name: "etcd-main-0", Namespace: "shoot--bar"}
+	if samePod(a, b) {
+		t.Error("samePod() matched pods from different namespaces")
+	}
+	if !samePod(a, a) {
+		t.Error("samePod() didn't match a pod with itself")
+	}
+}
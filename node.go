@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// formatTaint renders a taint the way kubectl describe node does, e.g. "dedicated=etcd:NoSchedule"
+func formatTaint(taint corev1.Taint) string {
+	out := taint.Key
+	if taint.Value != "" {
+		out += "=" + taint.Value
+	}
+	return out + ":" + string(taint.Effect)
+}
+
+// describeNode renders the node a pod runs on: its conditions, resources, taints and what else is scheduled there
+// The etcd members sharing the node are named, since they go down together with it
+func (m *Model) describeNode(nodeName string) (string, error) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	node, err := m.kubeClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if apierrors.IsForbidden(err) {
+		return "", fmt.Errorf("not permitted to read node %s", nodeName)
+	} else if err != nil {
+		return "", fmt.Errorf("failed to describe node %s: %w", nodeName, err)
+	}
+
+	var desc strings.Builder
+	desc.WriteString(fmt.Sprintf("Name: %s\n", node.Name))
+	schedulable := "yes"
+	if node.Spec.Unschedulable {
+		schedulable = "no (cordoned)"
+	}
+	desc.WriteString(fmt.Sprintf("Schedulable: %s\n", schedulable))
+	desc.WriteString(fmt.Sprintf("Created: %s\n", m.formatTimestamp(node.CreationTimestamp.Time)))
+
+	desc.WriteString("\nConditions:\n")
+	for _, condition := range node.Status.Conditions {
+		line := fmt.Sprintf("  %s: %s", condition.Type, condition.Status)
+		if condition.Reason != "" {
+			line += fmt.Sprintf(" (%s)", condition.Reason)
+		}
+		if condition.Message != "" {
+			line += ": " + condition.Message
+		}
+		desc.WriteString(line + "\n")
+	}
+
+	desc.WriteString("\nAllocatable / Capacity:\n")
+	for _, resource := range nodeSummaryResources {
+		capacity, ok := node.Status.Capacity[resource]
+		if !ok {
+			continue
+		}
+		allocatable := node.Status.Allocatable[resource]
+		desc.WriteString(fmt.Sprintf("  %s: %s / %s\n", resource, allocatable.String(), capacity.String()))
+	}
+
+	desc.WriteString("\nTaints:")
+	if len(node.Spec.Taints) == 0 {
+		desc.WriteString(" <none>")
+	}
+	desc.WriteString("\n")
+	for _, taint := range node.Spec.Taints {
+		desc.WriteString(fmt.Sprintf("  %s\n", formatTaint(taint)))
+	}
+
+	m.writeNodePods(&desc, nodeName)
+	return desc.String(), nil
+}
+
+// writeNodePods appends how many pods are scheduled on the node and which etcd members are among them
+// Pods that finished no longer hold the node's resources, so they aren't counted
+func (m *Model) writeNodePods(desc *strings.Builder, nodeName string) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	desc.WriteString("\nPods:\n")
+	pods, err := m.kubeClient.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	switch {
+	case apierrors.IsForbidden(err):
+		desc.WriteString("  (not permitted to list pods across namespaces)\n")
+	case err != nil:
+		desc.WriteString(fmt.Sprintf("  (unavailable: %v)\n", err))
+	default:
+		scheduled := 0
+		for _, pod := range pods.Items {
+			if pod.Spec.NodeName == nodeName && pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+				scheduled++
+			}
+		}
+		desc.WriteString(fmt.Sprintf("  Scheduled: %d\n", scheduled))
+	}
+
+	var members []string
+	for _, pod := range m.pods {
+		if pod.Node == nodeName {
+			members = append(members, m.podDisplayName(pod))
+		}
+	}
+	if len(members) > 0 {
+		desc.WriteString(fmt.Sprintf("  Etcd members: %s\n", strings.Join(members, ", ")))
+	}
+}
+
+// nodeLoadedMsg carries the describe of the selected pod's node
+type nodeLoadedMsg struct{ content string }
+
+// loadNodeDescribe is a command that describes the selected pod's node asynchronously
+func (m *Model) loadNodeDescribe() tea.Cmd {
+	nodeName := m.selectedPod.Node
+	return func() tea.Msg {
+		content, err := retryFetch(func() (string, error) {
+			return m.describeNode(nodeName)
+		})
+		if err != nil {
+			return errMsg{err}
+		}
+		return nodeLoadedMsg{content}
+	}
+}
+
+// openNodeDescribe shows the node of a pod; a Pending pod has none yet, which the hint explains
+func (m *Model) openNodeDescribe(pod Pod) tea.Cmd {
+	if pod.Node == "" {
+		return m.setStatus(fmt.Sprintf("pod %s isn't scheduled on a node yet", pod.Name))
+	}
+	m.selectedPod = pod
+	m.navigate(NodeState)
+	return tea.Batch(m.startLoading("Gathering node details…"), m.loadNodeDescribe())
+}
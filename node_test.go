@@ -0,0 +1,66 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestNodeDescribe(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-a"},
+		Spec: corev1.NodeSpec{
+			Unschedulable: true,
+			Taints:        []corev1.Taint{{Key: "dedicated", Value: "etcd", Effect: corev1.TaintEffectNoSchedule}},
+		},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionFalse, Reason: "KubeletNotReady", Message: "PLEG is not healthy"},
+			},
+			Capacity:    corev1.ResourceList{corev1.ResourcePods: resource.MustParse("110")},
+			Allocatable: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("100")},
+		},
+	}
+	finished := testPod("compaction-job", corev1.PodSucceeded)
+	finished.Labels = nil
+	other := testPod("kube-proxy", corev1.PodRunning, runningContainer("kube-proxy"))
+	other.Namespace = "kube-system"
+	objects := []runtime.Object{node, finished, other}
+	for _, name := range []string{"etcd-main-0", "etcd-main-1"} {
+		objects = append(objects, testPod(name, corev1.PodRunning, runningContainer("etcd")))
+	}
+	m, _ := newTestModel(objects)
+	m = update(t, m, m.loadPods()())
+
+	m = update(t, m, keyMsg("N"))
+	if m.state != NodeState {
+		t.Fatalf("N went to %v, want the node describe", m.state)
+	}
+	m = update(t, m, m.loadNodeDescribe()())
+	for _, want := range []string{
+		"Name: node-a\nSchedulable: no (cordoned)\n",
+		"  Ready: False (KubeletNotReady): PLEG is not healthy\n",
+		"  pods: 100 / 110\n",
+		"\nTaints:\n  dedicated=etcd:NoSchedule\n",
+		"  Scheduled: 3\n  Etcd members: etcd-main-0, etcd-main-1\n",
+	} {
+		if !strings.Contains(m.content, want) {
+			t.Errorf("node describe missing %q:\n%s", want, m.content)
+		}
+	}
+	m = update(t, m, keyMsg("esc"))
+	if m.state != ListState {
+		t.Errorf("esc went to %v, want the pod list", m.state)
+	}
+
+	// A Pending pod has no node to show yet
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-2", Status: "Pending"}}})
+	m = update(t, m, keyMsg("N"))
+	if m.state != ListState || !strings.Contains(m.status, "isn't scheduled on a node yet") {
+		t.Errorf("N on a Pending pod: state %v, status %q", m.state, m.status)
+	}
+}
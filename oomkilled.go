@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// oomKilledReason is the termination reason the kubelet gives a container killed for exceeding its memory limit
+const oomKilledReason = "OOMKilled"
+
+// oomKill returns the termination of a container that was OOMKilled, either just now or before its last restart
+// A restarted container only keeps the reason in its last termination state, which is where etcd's usually ends up
+func oomKill(status corev1.ContainerStatus) (*corev1.ContainerStateTerminated, bool) {
+	for _, terminated := range []*corev1.ContainerStateTerminated{status.State.Terminated, status.LastTerminationState.Terminated} {
+		if terminated != nil && terminated.Reason == oomKilledReason {
+			return terminated, true
+		}
+	}
+	return nil, false
+}
+
+// oomKilledContainers summarizes the OOMKilled containers of a pod, e.g. "etcd (exit code 137)"
+// It is empty when none was
+func oomKilledContainers(statuses []corev1.ContainerStatus) string {
+	var killed []string
+	for _, status := range statuses {
+		if terminated, ok := oomKill(status); ok {
+			killed = append(killed, fmt.Sprintf("%s (exit code %d)", status.Name, terminated.ExitCode))
+		}
+	}
+	return strings.Join(killed, ", ")
+}
+
+// podStatus derives the STATUS of a pod from its phase, calling out an OOMKilled container
+func podStatus(phase corev1.PodPhase, oomKilled string) string {
+	if oomKilled != "" {
+		return fmt.Sprintf("%s (%s)", phase, oomKilledReason)
+	}
+	return string(phase)
+}
+
+// oomKilledLine renders the badge describe shows under a container that was OOMKilled, or "" when it wasn't
+func (m *Model) oomKilledLine(indent string, statuses []corev1.ContainerStatus, container string) string {
+	for _, status := range statuses {
+		if status.Name != container {
+			continue
+		}
+		terminated, ok := oomKill(status)
+		if !ok {
+			return ""
+		}
+		badge := m.theme.oomKilled.Render(fmt.Sprintf("%s (exit code %d)", oomKilledReason, terminated.ExitCode))
+		line := indent + "Last Termination: " + badge
+		if !terminated.FinishedAt.IsZero() {
+			line += " at " + m.formatTimestamp(terminated.FinishedAt.Time)
+		}
+		return line + "\n"
+	}
+	return ""
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// oomKilledContainer is a container that was restarted after being OOMKilled
+func oomKilledContainer(name string) corev1.ContainerStatus {
+	status := runningContainer(name)
+	status.RestartCount = 1
+	status.LastTerminationState = corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+		Reason:   oomKilledReason,
+		ExitCode: 137,
+	}}
+	return status
+}
+
+func TestOOMKilledContainers(t *testing.T) {
+	current := corev1.ContainerStatus{Name: "backup-restore", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+		Reason:   oomKilledReason,
+		ExitCode: 137,
+	}}}
+	completed := corev1.ContainerStatus{Name: "init", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+		Reason: "Completed",
+	}}}
+
+	tests := []struct {
+		name     string
+		statuses []corev1.ContainerStatus
+		want     string
+	}{
+		{name: "none", statuses: []corev1.ContainerStatus{runningContainer("etcd"), completed}, want: ""},
+		{name: "before the last restart", statuses: []corev1.ContainerStatus{oomKilledContainer("etcd")}, want: "etcd (exit code 137)"},
+		{name: "just now", statuses: []corev1.ContainerStatus{runningContainer("etcd"), current}, want: "backup-restore (exit code 137)"},
+		{name: "several", statuses: []corev1.ContainerStatus{oomKilledContainer("etcd"), current},
+			want: "etcd (exit code 137), backup-restore (exit code 137)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := oomKilledContainers(tt.statuses); got != tt.want {
+				t.Errorf("oomKilledContainers() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOOMKilledPodStatus(t *testing.T) {
+	pod := testPod("etcd-main-0", corev1.PodRunning, oomKilledContainer("etcd"), runningContainer("backup-restore"))
+	m, _ := newTestModel([]runtime.Object{pod})
+
+	pods, err := m.fetchEtcdPods()
+	if err != nil {
+		t.Fatalf("fetchEtcdPods() error = %v", err)
+	}
+	if pods[0].Status != "Running (OOMKilled)" || pods[0].OOMKilled != "etcd (exit code 137)" {
+		t.Errorf("status = %q, OOMKilled = %q, want the OOMKilled etcd called out", pods[0].Status, pods[0].OOMKilled)
+	}
+	if desc := pods[0].Description(); !strings.Contains(desc, "OOMKilled: etcd (exit code 137)") {
+		t.Errorf("Description() = %q, want the OOMKilled container", desc)
+	}
+
+	// The badge must not shift the columns after STATUS
+	d := newCompactPodDelegate(pods, false, m.theme)
+	var row strings.Builder
+	d.Render(&row, list.New([]list.Item{pods[0]}, d, 120, 10), 0, pods[0])
+	if want := d.format(podTableRow(pods[0])); !strings.HasSuffix(row.String(), want) {
+		t.Errorf("row = %q, want it to end in %q", row.String(), want)
+	}
+
+	desc, err := m.describePod(testNamespace, "etcd-main-0")
+	if err != nil {
+		t.Fatalf("describePod() error = %v", err)
+	}
+	if !strings.Contains(desc, "    Last Termination: OOMKilled (exit code 137)") {
+		t.Errorf("describePod() output missing the OOMKilled etcd:\n%s", desc)
+	}
+	if strings.Count(desc, "Last Termination") != 1 {
+		t.Errorf("describePod() marks more than the etcd container:\n%s", desc)
+	}
+}
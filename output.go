@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"sigs.k8s.io/yaml"
+)
+
+// outputReport is the document printed by --output
+type outputReport struct {
+	Namespace  string                 `json:"namespace"`
+	Etcd       string                 `json:"etcd"`
+	Pods       []Pod                  `json:"pods"`
+	EtcdStatus map[string]interface{} `json:"etcdStatus,omitempty"`
+}
+
+// validateOutputFormat rejects unknown --output values before any cluster access
+func validateOutputFormat(format string) error {
+	switch format {
+	case "", "json", "yaml":
+		return nil
+	}
+	return fmt.Errorf("unsupported output format %q, must be json or yaml", format)
+}
+
+// writeOutput fetches the pods, and optionally the Etcd status, and writes them to w as json or yaml
+// It reuses the same fetch logic as the TUI so both always agree
+func (m *Model) writeOutput(w io.Writer, format string, withEtcd bool) error {
+	if withEtcd && m.allNamespaces {
+		return errors.New("--with-etcd needs a namespace, it can't be combined with --all-namespaces")
+	}
+	pods, err := m.fetchEtcdPods()
+	if err != nil {
+		return err
+	}
+	// Scripts iterating over .pods shouldn't have to handle null
+	if pods == nil {
+		pods = []Pod{}
+	}
+
+	report := outputReport{Namespace: m.namespace, Etcd: m.etcdName, Pods: pods}
+	if withEtcd {
+		etcd, err := m.fetchEtcdResource()
+		if err != nil {
+			return err
+		}
+		status, _ := etcd.Object["status"].(map[string]interface{})
+		report.EtcdStatus = status
+	}
+
+	var data []byte
+	switch format {
+	case "json":
+		data, err = json.MarshalIndent(report, "", "  ")
+		data = append(data, '\n')
+	case "yaml":
+		data, err = yaml.Marshal(report)
+	default:
+		return validateOutputFormat(format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode %s output: %w", format, err)
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+func TestWriteOutput(t *testing.T) {
+	etcd := testEtcdWithMembers(map[string]interface{}{"name": "etcd-main-0", "role": "Leader", "status": "Ready"})
+	pods := []runtime.Object{testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))}
+
+	for _, format := range []string{"json", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			m, _ := newTestModel(pods, etcd)
+			var out bytes.Buffer
+			if err := m.writeOutput(&out, format, true); err != nil {
+				t.Fatalf("writeOutput() error = %v", err)
+			}
+
+			var report outputReport
+			if err := yaml.Unmarshal(out.Bytes(), &report); err != nil {
+				t.Fatalf("output is not valid %s: %v\n%s", format, err, out.String())
+			}
+			if len(report.Pods) != 1 || report.Pods[0].Name != "etcd-main-0" || report.Pods[0].Role != "leader" {
+				t.Errorf("pods = %+v", report.Pods)
+			}
+			if report.EtcdStatus == nil {
+				t.Error("etcdStatus missing with withEtcd set")
+			}
+		})
+	}
+}
+
+func TestWriteOutputEmptyPods(t *testing.T) {
+	m, _ := newTestModel(nil)
+	var out bytes.Buffer
+	if err := m.writeOutput(&out, "json", false); err != nil {
+		t.Fatalf("writeOutput() error = %v", err)
+	}
+	var report map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if pods, ok := report["pods"].([]interface{}); !ok || len(pods) != 0 {
+		t.Errorf("pods = %v, want an empty array", report["pods"])
+	}
+	if _, ok := report["etcdStatus"]; ok {
+		t.Error("etcdStatus present without withEtcd")
+	}
+}
+
+func TestWriteOutputErrors(t *testing.T) {
+	t.Run("list failure", func(t *testing.T) {
+		m, client := newTestModel(nil)
+		client.PrependReactor("list", "pods", failingReactor())
+		if err := m.writeOutput(&bytes.Buffer{}, "json", false); err == nil {
+			t.Fatal("writeOutput() expected an error when listing pods fails")
+		}
+	})
+
+	t.Run("missing etcd", func(t *testing.T) {
+		m, _ := newTestModel(nil)
+		if err := m.writeOutput(&bytes.Buffer{}, "yaml", true); err == nil {
+			t.Fatal("writeOutput() expected an error when the Etcd resource is missing")
+		}
+	})
+}
+
+func TestValidateOutputFormat(t *testing.T) {
+	for _, format := range []string{"", "json", "yaml"} {
+		if err := validateOutputFormat(format); err != nil {
+			t.Errorf("validateOutputFormat(%q) error = %v", format, err)
+		}
+	}
+	if err := validateOutputFormat("table"); err == nil || !strings.Contains(err.Error(), "table") {
+		t.Errorf("validateOutputFormat(table) error = %v", err)
+	}
+}
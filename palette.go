@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// paletteCommands are the commands the palette takes, in the order the usage lists them
+var paletteCommands = []string{"logs", "describe", "ns", "etcd", "refresh", "quit"}
+
+// openPalette opens the prompt for a command, like vim's command line
+// tab completes the command and pod, namespace or Etcd names from what is loaded
+func (m *Model) openPalette() tea.Cmd {
+	cmd := m.openPrompt("", "", func(m *Model, line string) tea.Cmd {
+		return m.runPaletteCommand(line)
+	})
+	m.prompt.input.ShowSuggestions = true
+	m.prompt.input.SetSuggestions(m.paletteSuggestions())
+	return cmd
+}
+
+// paletteSuggestions are the whole command lines the palette completes to
+func (m *Model) paletteSuggestions() []string {
+	var suggestions []string
+	for _, name := range paletteCommands {
+		switch name {
+		case "logs", "describe":
+			for _, pod := range m.listedPods() {
+				suggestions = append(suggestions, name+" "+m.podDisplayName(pod))
+			}
+		case "ns":
+			for _, namespace := range m.knownNamespaces() {
+				suggestions = append(suggestions, name+" "+namespace)
+			}
+		case "etcd":
+			if m.etcdName != "" {
+				suggestions = append(suggestions, name+" "+m.etcdName)
+			}
+		}
+		suggestions = append(suggestions, name)
+	}
+	return suggestions
+}
+
+// knownNamespaces are the namespaces of the listed pods along with the current one, sorted
+func (m *Model) knownNamespaces() []string {
+	var namespaces []string
+	if m.namespace != "" {
+		namespaces = append(namespaces, m.namespace)
+	}
+	for _, pod := range m.listedPods() {
+		if namespace := m.podNamespace(pod); namespace != "" && !slices.Contains(namespaces, namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	slices.Sort(namespaces)
+	return namespaces
+}
+
+// findPod looks a pod of the list up by name, or by namespace/name as listed with --all-namespaces
+// Finished pods hidden from the list aren't found, like they can't be selected there
+func (m *Model) findPod(name string) (Pod, bool) {
+	for _, pod := range m.listedPods() {
+		if m.podDisplayName(pod) == name || pod.Name == name {
+			return pod, true
+		}
+	}
+	return Pod{}, false
+}
+
+// runPaletteCommand parses a palette line and starts the flow the matching key would
+func (m *Model) runPaletteCommand(line string) tea.Cmd {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil
+	}
+	name, args := fields[0], fields[1:]
+	switch name {
+	case "refresh", "quit", "q":
+		if len(args) > 0 {
+			return m.setStatus(fmt.Sprintf("%s takes no arguments", name))
+		}
+	case "logs", "describe", "ns", "etcd":
+		if len(args) != 1 {
+			return m.setStatus(fmt.Sprintf("usage: %s <%s>", name, paletteArgument(name)))
+		}
+	default:
+		return m.setStatus(fmt.Sprintf("unknown command %q, try %s", name, strings.Join(paletteCommands, ", ")))
+	}
+
+	switch name {
+	case "refresh":
+		m.err = nil
+		return m.refreshCurrentView()
+	case "quit", "q":
+		return m.quit()
+	case "ns":
+		if m.namespaceSelector != "" {
+			return m.setStatus("ns needs a single namespace, restart without --namespace-label-selector")
+		}
+		if ok, cmd := m.guardSingleNamespace("ns"); !ok {
+			return cmd
+		}
+		m.err = nil
+		m.namespace = args[0]
+		return m.reselectEtcd()
+	case "etcd":
+		if ok, cmd := m.guardSingleNamespace("etcd"); !ok {
+			return cmd
+		}
+		m.err = nil
+		m.resetCrumbs(ListState)
+		m.pods = nil
+		m.list.SetItems(nil)
+		m.backupSummary = ""
+		m.diffMarks = nil
+		return m.pickEtcd(args[0])
+	}
+
+	pod, ok := m.findPod(args[0])
+	if !ok {
+		return m.setStatus(fmt.Sprintf("no pod %s in the list", args[0]))
+	}
+	// The pod's screens open from the list, so going back returns there
+	m.err = nil
+	m.resetCrumbs(ListState)
+	m.selectedPod = pod
+	if name == "logs" {
+		m.navigate(ContainerSelectState)
+		m.skipToDefault = true
+		return m.loadContainers()
+	}
+	m.navigate(DescribeState)
+	return tea.Batch(m.startLoading(m.describeLoadingText()), m.loadDescribe())
+}
+
+// paletteArgument names what a palette command takes, for its usage
+func paletteArgument(name string) string {
+	switch name {
+	case "ns":
+		return "namespace"
+	case "etcd":
+		return "name"
+	}
+	return "pod"
+}
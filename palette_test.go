@@ -0,0 +1,105 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func paletteModel(t *testing.T) Model {
+	t.Helper()
+	m, _ := newTestModel(nil)
+	return update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}, {Name: "etcd-main-1"}}})
+}
+
+// runPalette types a line into the palette and submits it, without running the commands it returns
+func runPalette(t *testing.T, m Model, line string) Model {
+	t.Helper()
+	m = update(t, m, keyMsg(":"))
+	if m.prompt == nil {
+		t.Fatal(": didn't open the palette")
+	}
+	m.prompt.input.SetValue(line)
+	return update(t, m, keyMsg("enter"))
+}
+
+func TestPaletteCompletion(t *testing.T) {
+	m := paletteModel(t)
+	m = update(t, m, keyMsg(":"))
+	if m.prompt == nil {
+		t.Fatal(": didn't open the palette")
+	}
+	for _, want := range []string{"logs etcd-main-1", "describe etcd-main-0", "ns shoot--foo", "etcd etcd-main", "refresh", "quit"} {
+		if !slices.Contains(m.prompt.input.AvailableSuggestions(), want) {
+			t.Errorf("palette doesn't suggest %q: %v", want, m.prompt.input.AvailableSuggestions())
+		}
+	}
+	for _, key := range []string{"l", "o", "g", "s", " ", "e", "t", "c", "d", "-", "m", "a", "i", "n", "-", "1", "tab"} {
+		m = update(t, m, keyMsg(key))
+	}
+	if got := m.prompt.input.Value(); got != "logs etcd-main-1" {
+		t.Errorf("tab completed to %q", got)
+	}
+}
+
+func TestPaletteCommands(t *testing.T) {
+	tests := []struct {
+		line       string
+		wantState  AppState
+		wantPod    string
+		wantStatus string
+	}{
+		{line: "describe etcd-main-1", wantState: DescribeState, wantPod: "etcd-main-1"},
+		{line: "logs etcd-main-0", wantState: ContainerSelectState, wantPod: "etcd-main-0"},
+		{line: "logs etcd-main-7", wantState: ListState, wantStatus: "no pod etcd-main-7 in the list"},
+		{line: "logs", wantState: ListState, wantStatus: "usage: logs <pod>"},
+		{line: "ns shoot--bar", wantState: EtcdSelectState},
+		{line: "etcd etcd-events", wantState: ListState},
+		{line: "refresh now", wantState: ListState, wantStatus: "refresh takes no arguments"},
+		{line: "scale 3", wantState: ListState, wantStatus: `unknown command "scale", try logs, describe, ns, etcd, refresh, quit`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			m := paletteModel(t)
+			m = runPalette(t, m, tt.line)
+			if m.state != tt.wantState {
+				t.Errorf("state = %v, want %v", m.state, tt.wantState)
+			}
+			if tt.wantPod != "" && m.selectedPod.Name != tt.wantPod {
+				t.Errorf("selected pod = %q, want %q", m.selectedPod.Name, tt.wantPod)
+			}
+			if !strings.Contains(m.status, tt.wantStatus) {
+				t.Errorf("status = %q, want %q", m.status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestPaletteNamespaceAndEtcd(t *testing.T) {
+	m := paletteModel(t)
+	m = runPalette(t, m, "ns shoot--bar")
+	if m.namespace != "shoot--bar" || m.etcdName != "" || len(m.pods) != 0 {
+		t.Errorf("ns left namespace %q, etcd %q, %d pods", m.namespace, m.etcdName, len(m.pods))
+	}
+
+	m = paletteModel(t)
+	m = update(t, m, keyMsg("d"))
+	m = runPalette(t, m, "etcd etcd-events")
+	if m.etcdName != "etcd-events" || len(m.navStack) != 0 {
+		t.Errorf("etcd left etcd %q, navigation %v", m.etcdName, m.navStack)
+	}
+}
+
+func TestPaletteAllNamespaces(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m.allNamespaces = true
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0", Namespace: "shoot--foo"}, {Name: "etcd-main-0", Namespace: "shoot--bar"}}})
+	m = runPalette(t, m, "describe shoot--bar/etcd-main-0")
+	if m.state != DescribeState || m.selectedPod.Namespace != "shoot--bar" {
+		t.Errorf("describe picked %s/%s in %v", m.selectedPod.Namespace, m.selectedPod.Name, m.state)
+	}
+	m = runPalette(t, m, "ns shoot--foo")
+	if !strings.Contains(m.status, "ns needs a namespace") {
+		t.Errorf("ns with --all-namespaces: status %q", m.status)
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pinMarker prefixes the title of a pinned pod
+const pinMarker = "▲ "
+
+// pinKey names a pod in the pins of the config file
+// The namespace is part of it, every shoot namespace has its own etcd-main-0
+func (m *Model) pinKey(pod Pod) string {
+	return m.podNamespace(pod) + "/" + pod.Name
+}
+
+// isPinned reports whether a pod is pinned
+func (m *Model) isPinned(pod Pod) bool {
+	return slices.Contains(m.pins, m.pinKey(pod))
+}
+
+// pinnedFirst moves the pinned pods to the top, keeping the order within the pinned and the other pods
+func pinnedFirst(pods []Pod) []Pod {
+	sorted := slices.Clone(pods)
+	slices.SortStableFunc(sorted, func(a, b Pod) int {
+		switch {
+		case a.Pinned == b.Pinned:
+			return 0
+		case a.Pinned:
+			return -1
+		}
+		return 1
+	})
+	return sorted
+}
+
+// togglePin pins or unpins a pod and saves the pins, keeping the cursor on the pod as it moves
+// Pins are kept by name, so they hold across refreshes, restarts of the viewer and of the pod itself
+func (m *Model) togglePin(pod Pod) tea.Cmd {
+	key := m.pinKey(pod)
+	status := "pinned " + m.podDisplayName(pod)
+	if i := slices.Index(m.pins, key); i >= 0 {
+		m.pins = slices.Delete(slices.Clone(m.pins), i, i+1)
+		status = "unpinned " + m.podDisplayName(pod)
+	} else {
+		m.pins = append(slices.Clone(m.pins), key)
+	}
+	m.setPods(m.pods)
+	m.selectListPod(pod)
+	return tea.Batch(m.setStatus(status), m.persistConfig())
+}
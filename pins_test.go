@@ -0,0 +1,69 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// listedNames returns the names of the pods in the order the list shows them
+func listedNames(m Model) []string {
+	var names []string
+	for _, item := range m.list.Items() {
+		names = append(names, item.(Pod).Name)
+	}
+	return names
+}
+
+func TestTogglePin(t *testing.T) {
+	pods := []Pod{{Name: "etcd-main-0"}, {Name: "etcd-main-1"}, {Name: "etcd-main-2"}}
+	m, _ := newTestModel(nil)
+	m = update(t, m, podsLoadedMsg{pods: pods})
+
+	m.list.Select(2)
+	m = update(t, m, keyMsg("p"))
+	if got, want := listedNames(m), []string{"etcd-main-2", "etcd-main-0", "etcd-main-1"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("list after pinning = %v, want %v", got, want)
+	}
+	if pod, _ := m.selectedListPod(); pod.Name != "etcd-main-2" || pod.Title() != pinMarker+"etcd-main-2" {
+		t.Errorf("selected %q after pinning, want the pinned pod", pod.Title())
+	}
+	if m.status != "pinned etcd-main-2" || !reflect.DeepEqual(m.currentConfig().Pins, []string{"shoot--foo/etcd-main-2"}) {
+		t.Errorf("status %q, pins %v", m.status, m.currentConfig().Pins)
+	}
+
+	// A refresh brings new pods by the same names, the pin holds
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}, {Name: "etcd-main-1"}, {Name: "etcd-main-2"}}})
+	if got := listedNames(m); got[0] != "etcd-main-2" {
+		t.Errorf("list after refreshing = %v, want etcd-main-2 first", got)
+	}
+
+	m = update(t, m, keyMsg("p"))
+	if got, want := listedNames(m), []string{"etcd-main-0", "etcd-main-1", "etcd-main-2"}; !reflect.DeepEqual(got, want) || len(m.pins) != 0 {
+		t.Errorf("list after unpinning = %v, pins %v", got, m.pins)
+	}
+}
+
+func TestPinsPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	cfg := defaultConfig()
+	cfg.Pins = []string{"shoot--foo/etcd-main-1"}
+	if err := saveConfig(path, cfg); err != nil {
+		t.Fatalf("saveConfig() error = %v", err)
+	}
+	loaded, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("loadConfig() error = %v", err)
+	}
+
+	m, _ := newTestModel(nil)
+	m.applyConfig(loaded)
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}, {Name: "etcd-main-1"}, {Name: "etcd-main-1", Namespace: "shoot--bar"}}})
+	items := m.list.Items()
+	if first := items[0].(Pod); first.Name != "etcd-main-1" || first.Namespace != "" {
+		t.Errorf("first pod = %+v, want the pinned etcd-main-1 of shoot--foo", first)
+	}
+	if other := items[2].(Pod); other.Pinned {
+		t.Errorf("etcd-main-1 of shoot--bar is pinned too")
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// crdGVR identifies CustomResourceDefinitions, read with the dynamic client rather than the apiextensions clientset
+var crdGVR = schema.GroupVersionResource{
+	Group:    "apiextensions.k8s.io",
+	Version:  "v1",
+	Resource: "customresourcedefinitions",
+}
+
+// printerColumn is one of the additionalPrinterColumns a CRD declares for kubectl get
+type printerColumn struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	JSONPath string `json:"jsonPath"`
+	Priority int64  `json:"priority"`
+}
+
+// fallbackPrinterColumns are the columns etcd-druid declares, shown when the CRD can't be read, e.g. without RBAC on CRDs
+var fallbackPrinterColumns = []printerColumn{
+	{Name: "Ready", Type: "string", JSONPath: ".status.ready"},
+	{Name: "Quorate", Type: "string", JSONPath: `.status.conditions[?(@.type=="Quorate")].status`},
+	{Name: "All Members Ready", Type: "string", JSONPath: `.status.conditions[?(@.type=="AllMembersReady")].status`},
+	{Name: "Backup Ready", Type: "string", JSONPath: `.status.conditions[?(@.type=="BackupReady")].status`},
+	{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+}
+
+// fetchPrinterColumns reads the printer columns of the served Etcd version from its CRD
+// Like kubectl get without -o wide, only priority 0 columns are kept; any failure falls back to fallbackPrinterColumns
+func (m *Model) fetchPrinterColumns() []printerColumn {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	crd, err := m.dynamicClient.Resource(crdGVR).Get(ctx, etcdGVR.GroupResource().String(), metav1.GetOptions{})
+	if err != nil {
+		return fallbackPrinterColumns
+	}
+
+	var wrapped struct {
+		Spec struct {
+			Versions []struct {
+				Name    string          `json:"name"`
+				Columns []printerColumn `json:"additionalPrinterColumns"`
+			} `json:"versions"`
+		} `json:"spec"`
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(crd.Object, &wrapped); err != nil {
+		return fallbackPrinterColumns
+	}
+	var columns []printerColumn
+	for _, version := range wrapped.Spec.Versions {
+		if version.Name != etcdGVR.Version {
+			continue
+		}
+		for _, column := range version.Columns {
+			if column.Priority == 0 {
+				columns = append(columns, column)
+			}
+		}
+	}
+	if len(columns) == 0 {
+		return fallbackPrinterColumns
+	}
+	return columns
+}
+
+// printerColumnValue extracts a column from an Etcd the way kubectl does, joining multiple results with commas
+// Dates show as an age like kubectl, or as RFC3339 when absolute times are on; a missing value is shown as -
+func (m *Model) printerColumnValue(etcd *unstructured.Unstructured, column printerColumn) string {
+	path := jsonpath.New(column.Name).AllowMissingKeys(true)
+	if err := path.Parse("{" + column.JSONPath + "}"); err != nil {
+		return "<invalid>"
+	}
+	results, err := path.FindResults(etcd.Object)
+	if err != nil {
+		return "-"
+	}
+	var values []string
+	for _, result := range results {
+		for _, value := range result {
+			values = append(values, fmt.Sprint(value.Interface()))
+		}
+	}
+	if len(values) == 0 {
+		return "-"
+	}
+	joined := strings.Join(values, ",")
+	if column.Type == "date" {
+		if t, err := time.Parse(time.RFC3339, joined); err == nil {
+			return m.formatTimestamp(t)
+		}
+	}
+	return joined
+}
+
+// renderEtcdTable lays out Etcd resources under the printer columns, after the name and, across namespaces, the namespace
+// Rows are indented to sit under a heading like the other tables of the dashboard
+func (m *Model) renderEtcdTable(etcds []unstructured.Unstructured, columns []printerColumn, withNamespace bool) string {
+	var out strings.Builder
+	w := tabwriter.NewWriter(&out, 0, 0, 2, ' ', 0)
+	header := []string{"NAME"}
+	if withNamespace {
+		header = append([]string{"NAMESPACE"}, header...)
+	}
+	for _, column := range columns {
+		header = append(header, strings.ToUpper(column.Name))
+	}
+	fmt.Fprintln(w, "  "+strings.Join(header, "\t"))
+	for i := range etcds {
+		row := []string{etcds[i].GetName()}
+		if withNamespace {
+			row = append([]string{etcds[i].GetNamespace()}, row...)
+		}
+		for _, column := range columns {
+			row = append(row, m.printerColumnValue(&etcds[i], column))
+		}
+		fmt.Fprintln(w, "  "+strings.Join(row, "\t"))
+	}
+	w.Flush()
+	return out.String()
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// testEtcdCRD builds the Etcd CRD with the given printer columns on the served version
+func testEtcdCRD(columns ...map[string]interface{}) *unstructured.Unstructured {
+	list := make([]interface{}, len(columns))
+	for i, column := range columns {
+		list[i] = column
+	}
+	crd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"versions": []interface{}{
+				map[string]interface{}{"name": "v1beta1", "additionalPrinterColumns": []interface{}{}},
+				map[string]interface{}{"name": etcdGVR.Version, "additionalPrinterColumns": list},
+			},
+		},
+	}}
+	crd.SetAPIVersion("apiextensions.k8s.io/v1")
+	crd.SetKind("CustomResourceDefinition")
+	crd.SetName(etcdGVR.GroupResource().String())
+	return crd
+}
+
+func TestFetchPrinterColumns(t *testing.T) {
+	crd := testEtcdCRD(
+		map[string]interface{}{"name": "Ready", "type": "string", "jsonPath": ".status.ready"},
+		map[string]interface{}{"name": "Cluster Size", "type": "integer", "jsonPath": ".spec.replicas", "priority": int64(1)},
+		map[string]interface{}{"name": "Quorate", "type": "string", "jsonPath": `.status.conditions[?(@.type=="Quorate")].status`},
+	)
+	m, _ := newTestModel(nil, crd)
+	columns := m.fetchPrinterColumns()
+	if len(columns) != 2 || columns[0].Name != "Ready" || columns[1].Name != "Quorate" {
+		t.Errorf("fetchPrinterColumns() = %+v, want the priority 0 columns of %s", columns, etcdGVR.Version)
+	}
+
+	// Without the CRD the columns etcd-druid declares are assumed
+	m, _ = newTestModel(nil)
+	if columns := m.fetchPrinterColumns(); len(columns) != len(fallbackPrinterColumns) {
+		t.Errorf("fetchPrinterColumns() = %+v, want the fallback", columns)
+	}
+}
+
+func TestRenderEtcdTable(t *testing.T) {
+	etcd := testEtcd("etcd-main")
+	etcd.SetCreationTimestamp(metav1.NewTime(time.Now().Add(-2 * time.Hour)))
+	etcd.Object["status"] = map[string]interface{}{
+		"ready": true,
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Quorate", "status": "True"},
+			map[string]interface{}{"type": "BackupReady", "status": "False"},
+		},
+	}
+
+	m, _ := newTestModel(nil)
+	table := m.renderEtcdTable([]unstructured.Unstructured{*etcd}, fallbackPrinterColumns, true)
+	lines := strings.Split(table, "\n")
+	if !strings.HasPrefix(lines[0], "  NAMESPACE   NAME       READY  QUORATE  ALL MEMBERS READY  BACKUP READY  AGE") {
+		t.Errorf("header = %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "  "+testNamespace+"  etcd-main  true   True     -                  False         2h") {
+		t.Errorf("row = %q, want the values extracted by JSONPath", lines[1])
+	}
+}
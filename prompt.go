@@ -0,0 +1,53 @@
+package main
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// inputPrompt is a single-line input shown above the footer, e.g. to ask for an image name
+// While a prompt is open it receives every key; enter submits and esc cancels
+type inputPrompt struct {
+	input    textinput.Model
+	onSubmit func(m *Model, value string) tea.Cmd
+}
+
+// openPrompt shows a prompt with the given label and initial value
+func (m *Model) openPrompt(label, value string, onSubmit func(m *Model, value string) tea.Cmd) tea.Cmd {
+	input := textinput.New()
+	input.Prompt = label + ": "
+	input.SetValue(value)
+	input.CursorEnd()
+	// Focus before storing the input, the prompt keeps a copy and ignores keys unless focused
+	focus := input.Focus()
+	m.prompt = &inputPrompt{input: input, onSubmit: onSubmit}
+	m.layout()
+	return focus
+}
+
+// updatePrompt routes a key to the open prompt
+func (m *Model) updatePrompt(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.prompt = nil
+		m.layout()
+		return nil
+	case "enter":
+		p := m.prompt
+		m.prompt = nil
+		m.layout()
+		return p.onSubmit(m, p.input.Value())
+	}
+
+	var cmd tea.Cmd
+	m.prompt.input, cmd = m.prompt.input.Update(msg)
+	return cmd
+}
+
+// promptView renders the open prompt, if any
+func (m Model) promptView() string {
+	if m.prompt == nil {
+		return ""
+	}
+	return m.theme.prompt.Render(m.prompt.input.View())
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPromptTakesKeys(t *testing.T) {
+	m, _ := newTestModel(nil)
+	var submitted string
+	m.openPrompt("debug image", defaultDebugImage, func(m *Model, image string) tea.Cmd {
+		submitted = image
+		return nil
+	})
+
+	// The prompt holds a copy of the input, which only takes keys if it was focused before being stored
+	m = update(t, m, keyMsg(":1.36"))
+	if got := m.prompt.input.Value(); got != "busybox:1.36" {
+		t.Fatalf("prompt value = %q, want the typed keys appended to the default image", got)
+	}
+	m = update(t, m, keyMsg("enter"))
+	if m.prompt != nil || submitted != "busybox:1.36" {
+		t.Errorf("enter submitted %q, prompt open %v, want busybox:1.36 submitted", submitted, m.prompt != nil)
+	}
+}
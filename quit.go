@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// editOperation names the apply of an edited pod or Etcd, which editedMsg reports without the name
+const editOperation = "applying an edit"
+
+// Names of the other operations, built the same way where they begin and where their result arrives
+func execOperation(podName string) string     { return "disk usage exec in pod " + podName }
+func debugOperation(podName string) string    { return "adding a debug container to pod " + podName }
+func cordonOperation(nodeName string) string  { return "cordoning node " + nodeName }
+func restartOperation(etcdName string) string { return "rolling restart of statefulset " + etcdName }
+func scaleOperation(etcdName string) string   { return "scaling etcd " + etcdName }
+func reconcileOperation(name string) string   { return "annotating etcd " + name + " to reconcile" }
+func metadataOperation(podName string) string { return "patching the metadata of pod " + podName }
+func bundleOperation(podName string) string   { return "bundling the logs of pod " + podName }
+
+// beginOperation records a request that shouldn't be cut off by quitting, e.g. an exec or a patch
+func (m *Model) beginOperation(name string) {
+	m.operations = append(m.operations, name)
+}
+
+// endOperation forgets an operation once its result arrived
+// A copy is modified since earlier Model values may still share the slice
+func (m *Model) endOperation(name string) {
+	if i := slices.Index(m.operations, name); i >= 0 {
+		m.operations = slices.Delete(slices.Clone(m.operations), i, i+1)
+	}
+}
+
+// activeOperations lists what quitting would terminate, including a log tail or follow while it is on screen
+func (m *Model) activeOperations() []string {
+	active := slices.Clone(m.operations)
+	if m.state == ClusterLogsState && m.clusterLogs != nil {
+		active = append(active, fmt.Sprintf("following the etcd logs of %d pods", len(m.clusterLogs.streams)))
+	}
+	if m.state == LogState && m.followLogs {
+		active = append(active, "following the logs of pod "+m.selectedPod.Name)
+	}
+	return active
+}
+
+// quit exits right away when nothing is running, and otherwise asks first, naming what would be terminated
+// Quitting again while asked exits without waiting for the answer
+func (m *Model) quit() tea.Cmd {
+	active := m.activeOperations()
+	if len(active) == 0 || (m.quitAsked && m.prompt != nil) {
+		return tea.Quit
+	}
+	m.quitAsked = true
+	label := fmt.Sprintf("quit and stop %s? (y/N)", strings.Join(active, ", "))
+	return m.openPrompt(label, "", func(m *Model, answer string) tea.Cmd {
+		m.quitAsked = false
+		if answer := strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+			return m.setStatus("quit cancelled")
+		}
+		return tea.Quit
+	})
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// quits reports whether cmd exits the program
+func quits(cmd tea.Cmd) bool {
+	if cmd == nil {
+		return false
+	}
+	_, ok := cmd().(tea.QuitMsg)
+	return ok
+}
+
+func TestQuitWithoutOperations(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	if _, cmd := m.Update(keyMsg("q")); !quits(cmd) {
+		t.Error("q with nothing running did not quit right away")
+	}
+}
+
+func TestQuitConfirmsActiveOperations(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("u"))
+
+	next, cmd := m.Update(keyMsg("q"))
+	m = next.(Model)
+	if quits(cmd) || m.prompt == nil || !strings.Contains(m.promptView(), "disk usage exec in pod etcd-main-0") {
+		t.Fatalf("q during an exec: prompt %q, want a confirmation naming the exec", m.promptView())
+	}
+	next, _ = m.Update(keyMsg("enter"))
+	m = next.(Model)
+	if m.status != "quit cancelled" {
+		t.Errorf("status = %q after declining, want quit cancelled", m.status)
+	}
+
+	// A second ctrl+c doesn't wait for the answer
+	m = update(t, m, keyMsg("q"))
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlC}); !quits(cmd) {
+		t.Error("ctrl+c at the quit confirmation did not quit")
+	}
+
+	// Once the exec returned there is nothing left to confirm
+	m.prompt = nil
+	m = update(t, m, diskUsageMsg{podName: "etcd-main-0", usage: "1Gi of 8Gi"})
+	if _, cmd := m.Update(keyMsg("q")); !quits(cmd) {
+		t.Errorf("q after the exec finished did not quit, operations = %v", m.operations)
+	}
+}
+
+func TestActiveOperationsIncludeClusterLogTail(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}, {Name: "etcd-main-1"}}})
+	m = update(t, m, keyMsg("L"))
+	if got := m.activeOperations(); len(got) != 1 || got[0] != "following the etcd logs of 2 pods" {
+		t.Errorf("activeOperations() = %q, want the cluster log streams", got)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// operationAnnotation asks etcd-druid for an operation on the Etcd, which removes it again once started
+const operationAnnotation = "gardener.cloud/operation"
+
+// isOperationAnnotation reports whether an annotation of the Etcd steers etcd-druid,
+// e.g. the operation or druid.gardener.cloud/suspend-etcd-spec-reconcile
+func isOperationAnnotation(key string) bool {
+	return key == operationAnnotation || strings.HasPrefix(key, "druid.gardener.cloud/")
+}
+
+// describeOperationAnnotations renders the annotations of the Etcd that steer its reconciliation, sorted by key
+func describeOperationAnnotations(desc *strings.Builder, annotations map[string]string) {
+	var keys []string
+	for key := range annotations {
+		if isOperationAnnotation(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	desc.WriteString("\nOperation Annotations:\n")
+	if len(keys) == 0 {
+		desc.WriteString("  <none>\n")
+	}
+	for _, key := range keys {
+		desc.WriteString(fmt.Sprintf("  %s: %s\n", key, annotations[key]))
+	}
+}
+
+// etcdReconcileMsg reports the outcome of annotating the Etcd resource for a reconcile
+type etcdReconcileMsg struct{ err error }
+
+// promptReconcileEtcd asks for confirmation before annotating the Etcd resource to be reconciled
+func (m *Model) promptReconcileEtcd() tea.Cmd {
+	if ok, cmd := m.guardMutation("reconcile"); !ok {
+		return cmd
+	}
+	label := fmt.Sprintf("annotate etcd %s with %s=reconcile? (y/N)", m.etcdName, operationAnnotation)
+	return m.openPrompt(label, "", func(m *Model, answer string) tea.Cmd {
+		if answer := strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+			return m.setStatus("reconcile cancelled")
+		}
+		return m.reconcileEtcd()
+	})
+}
+
+// reconcileEtcd sets the operation annotation to reconcile, like
+// kubectl annotate etcd <name> gardener.cloud/operation=reconcile
+func (m *Model) reconcileEtcd() tea.Cmd {
+	m.beginOperation(reconcileOperation(m.etcdName))
+	return func() tea.Msg {
+		ctx, cancel := m.requestContext()
+		defer cancel()
+		etcds := m.dynamicClient.Resource(etcdGVR).Namespace(m.namespace)
+		var before *unstructured.Unstructured
+		if m.dryRun {
+			var err error
+			if before, err = etcds.Get(ctx, m.etcdName, metav1.GetOptions{}); err != nil {
+				return etcdReconcileMsg{fmt.Errorf("failed to get etcd %s: %w", m.etcdName, err)}
+			}
+		}
+		patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:"reconcile"}}}`, operationAnnotation)
+		after, err := etcds.Patch(ctx, m.etcdName, types.MergePatchType, []byte(patch), m.patchOptions())
+		if apierrors.IsForbidden(err) {
+			return etcdReconcileMsg{fmt.Errorf("not permitted to patch etcd %s", m.etcdName)}
+		}
+		if err != nil {
+			return etcdReconcileMsg{fmt.Errorf("failed to annotate etcd %s: %w", m.etcdName, err)}
+		}
+		if m.dryRun {
+			return dryRunResult(reconcileOperation(m.etcdName), "reconcile etcd "+m.etcdName, before, after)
+		}
+		return etcdReconcileMsg{}
+	}
+}
+
+// showReconcile reports the annotation and describes the Etcd again, where it shows until etcd-druid picks it up
+func (m *Model) showReconcile(msg etcdReconcileMsg) tea.Cmd {
+	m.endOperation(reconcileOperation(m.etcdName))
+	if msg.err != nil {
+		return m.setStatus(msg.err.Error())
+	}
+	status := m.setStatus(fmt.Sprintf("annotated etcd %s to reconcile, etcd-druid removes the annotation once it starts", m.etcdName))
+	if m.state == DescribeState && m.describeEtcd {
+		return tea.Batch(status, m.loadEtcdDescribe())
+	}
+	return status
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDescribeOperationAnnotations(t *testing.T) {
+	var desc strings.Builder
+	describeOperationAnnotations(&desc, map[string]string{
+		"gardener.cloud/operation":                         "reconcile",
+		"druid.gardener.cloud/suspend-etcd-spec-reconcile": "true",
+		"gardener.cloud/timestamp":                         "2026-10-14T08:00:00Z",
+	})
+	want := "\nOperation Annotations:\n  druid.gardener.cloud/suspend-etcd-spec-reconcile: true\n  gardener.cloud/operation: reconcile\n"
+	if desc.String() != want {
+		t.Errorf("describeOperationAnnotations() = %q, want %q", desc.String(), want)
+	}
+
+	desc.Reset()
+	describeOperationAnnotations(&desc, nil)
+	if !strings.Contains(desc.String(), "  <none>\n") {
+		t.Errorf("without annotations = %q", desc.String())
+	}
+}
+
+func TestReconcileEtcd(t *testing.T) {
+	m, _ := newTestModel(nil, testEtcd(testEtcdName))
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("D"))
+
+	m = update(t, m, keyMsg("R"))
+	if m.prompt == nil || !strings.Contains(m.prompt.input.Prompt, "gardener.cloud/operation=reconcile") {
+		t.Fatal("R did not ask for confirmation")
+	}
+	m = update(t, m, keyMsg("enter"))
+	if m.status != "reconcile cancelled" {
+		t.Fatalf("status = %q after declining, want reconcile cancelled", m.status)
+	}
+
+	m = update(t, m, keyMsg("R"))
+	m = update(t, m, keyMsg("y"))
+	_, cmd := m.Update(keyMsg("enter"))
+	msg, ok := cmd().(etcdReconcileMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("reconcile = %+v", msg)
+	}
+	etcd, err := m.dynamicClient.Resource(etcdGVR).Namespace(testNamespace).Get(context.Background(), testEtcdName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get etcd: %v", err)
+	}
+	if got := etcd.GetAnnotations()[operationAnnotation]; got != "reconcile" {
+		t.Errorf("operation annotation = %q, want reconcile", got)
+	}
+
+	next, cmd := m.Update(msg)
+	m = next.(Model)
+	if cmd == nil || !strings.Contains(m.status, "annotated etcd etcd-main to reconcile") {
+		t.Errorf("after annotating: status %q, want the Etcd status reloading", m.status)
+	}
+	desc, err := m.describeEtcdResource()
+	if err != nil || !strings.Contains(desc, "Operation Annotations:\n  gardener.cloud/operation: reconcile\n") {
+		t.Errorf("describe after annotating = %v:\n%s", err, desc)
+	}
+}
+
+func TestReconcileEtcdReadOnly(t *testing.T) {
+	m, _ := newTestModel(nil, testEtcd(testEtcdName))
+	m.readOnly = true
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("D"))
+	m = update(t, m, keyMsg("R"))
+	if m.prompt != nil || !strings.Contains(m.status, "read-only mode: reconcile is disabled") {
+		t.Errorf("R in read-only mode: prompt open %v, status %q", m.prompt != nil, m.status)
+	}
+}
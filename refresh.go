@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// statefulSetStatusMsg carries the rollout state of the StatefulSet for a hard refresh
+type statefulSetStatusMsg struct {
+	id      int
+	summary string
+	err     error
+}
+
+// hardRefreshDoneMsg arrives once every fetch of a hard refresh was handled
+type hardRefreshDoneMsg struct{ id int }
+
+// fetchStatefulSetStatus summarizes the StatefulSet like kubectl rollout status, see rolloutStatusLine
+func (m *Model) fetchStatefulSetStatus() (string, error) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	sts, err := m.kubeClient.AppsV1().StatefulSets(m.namespace).Get(ctx, m.etcdName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get statefulset %s: %w", m.etcdName, err)
+	}
+	return rolloutStatusLine(sts), nil
+}
+
+// loadStatefulSetStatus is a command that fetches the StatefulSet status of hard refresh id asynchronously
+func (m *Model) loadStatefulSetStatus(id int) tea.Cmd {
+	// Each namespace has its own StatefulSet, like the backups
+	if m.allNamespaces {
+		return nil
+	}
+	return func() tea.Msg {
+		summary, err := retryFetch(m.fetchStatefulSetStatus)
+		return statefulSetStatusMsg{id: id, summary: summary, err: err}
+	}
+}
+
+// hardRefreshFetches returns the fetches of a hard refresh: the pods with the members from the Etcd status,
+// the backup health, the StatefulSet and the current screen unless that is the pod list anyway
+// None of them is a batch: the sequence of hardRefresh only waits on the commands of the outer batch
+func (m *Model) hardRefreshFetches(id int) []tea.Cmd {
+	cmds := []tea.Cmd{m.loadPods(), m.loadBackupSummary(), m.loadStatefulSetStatus(id)}
+	if m.state != ListState {
+		cmds = append(cmds, m.currentViewFetches()...)
+	}
+	return cmds
+}
+
+// hardRefresh re-fetches everything at once, so nothing on screen predates a change to the cluster
+// The viewer keeps no cache besides what is shown, which is why the current screen is among the fetches
+// The fetches run concurrently; a sequence reports the end once all of them were handled
+func (m *Model) hardRefresh() tea.Cmd {
+	// Before the Etcd is known there is only the current screen
+	if m.etcdName == "" {
+		return m.refreshCurrentView()
+	}
+	m.hardRefreshID++
+	id := m.hardRefreshID
+	m.hardRefreshing = true
+	m.refreshedStatefulSet = ""
+	done := func() tea.Msg { return hardRefreshDoneMsg{id} }
+	return tea.Batch(m.spinner.Tick, tea.Sequence(tea.Batch(m.hardRefreshFetches(id)...), done))
+}
+
+// setStatefulSetStatus keeps the StatefulSet status until the hard refresh it belongs to reports
+func (m *Model) setStatefulSetStatus(msg statefulSetStatusMsg) {
+	if msg.id != m.hardRefreshID {
+		return
+	}
+	m.refreshedStatefulSet = msg.summary
+	if msg.err != nil {
+		m.refreshedStatefulSet = msg.err.Error()
+	}
+}
+
+// finishHardRefresh stops the spinner and reports the refresh along with the StatefulSet status
+func (m *Model) finishHardRefresh(msg hardRefreshDoneMsg) tea.Cmd {
+	if msg.id != m.hardRefreshID {
+		return nil
+	}
+	m.hardRefreshing = false
+	status := "refreshed the pods and the Etcd status"
+	if m.refreshedStatefulSet != "" {
+		status += "; " + m.refreshedStatefulSet
+	}
+	return m.setStatus(status)
+}
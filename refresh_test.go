@@ -0,0 +1,76 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestHardRefresh(t *testing.T) {
+	sts := testStatefulSet(1, appsv1.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 1, CurrentReplicas: 1,
+		CurrentRevision: "etcd-main-1", UpdateRevision: "etcd-main-1"})
+	m, _ := newTestModel([]runtime.Object{testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd")), sts})
+	m = update(t, m, tea.WindowSizeMsg{Width: 200, Height: 30})
+	m.navigate(EventsState)
+
+	next, cmd := m.Update(keyMsg("ctrl+r"))
+	m = next.(Model)
+	if cmd == nil || !strings.Contains(m.footerView(), "refreshing everything") {
+		t.Fatalf("footer = %q, want the spinner of the hard refresh", m.footerView())
+	}
+	fetches := m.hardRefreshFetches(m.hardRefreshID)
+	if len(fetches) != 4 {
+		t.Fatalf("hard refresh runs %d fetches, want the pods, backups, statefulset and the events on screen", len(fetches))
+	}
+	for _, fetch := range fetches {
+		m = update(t, m, fetch())
+	}
+	if len(m.pods) != 1 || !strings.Contains(m.content, "No recent events") {
+		t.Errorf("pods = %v, events %q, want both fetched again", m.pods, m.content)
+	}
+
+	// The end of an earlier refresh leaves the spinner running
+	m = update(t, m, hardRefreshDoneMsg{id: m.hardRefreshID - 1})
+	if !m.hardRefreshing {
+		t.Fatal("a stale completion stopped the hard refresh")
+	}
+	m = update(t, m, hardRefreshDoneMsg{id: m.hardRefreshID})
+	if m.hardRefreshing || !strings.Contains(m.status, "statefulset rolling update complete 1 pods at revision etcd-main-1") {
+		t.Errorf("status = %q, want the refresh reported with the statefulset status", m.status)
+	}
+}
+
+func TestHardRefreshWaitsOnEveryPane(t *testing.T) {
+	m, _ := newTestModel([]runtime.Object{
+		testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd")),
+		testPod("etcd-main-1", corev1.PodRunning, runningContainer("etcd")),
+	})
+	m = update(t, m, tea.WindowSizeMsg{Width: 101, Height: 30})
+	m = update(t, m, m.loadPods()())
+	m = update(t, m, keyMsg(" "))
+	m = update(t, m, keyMsg("down"))
+	m = update(t, m, keyMsg(" "))
+	m = update(t, m, keyMsg("V"))
+	if m.state != SplitLogsState {
+		t.Fatalf("state = %v, want the split view", m.state)
+	}
+
+	// A batch among the fetches would be sent on unawaited, ending the refresh before its fetches
+	var panes int
+	for _, fetch := range m.hardRefreshFetches(m.hardRefreshID) {
+		msg := fetch()
+		if _, ok := msg.(tea.BatchMsg); ok {
+			t.Fatal("a fetch of the hard refresh is a batch the sequence doesn't wait on")
+		}
+		if _, ok := msg.(splitLogsLoadedMsg); ok {
+			panes++
+		}
+	}
+	if panes != 2 {
+		t.Errorf("hard refresh fetches %d panes, want both", panes)
+	}
+}
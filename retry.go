@@ -0,0 +1,36 @@
+package main
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+// fetchBackoff spaces out retries of reads that hit a transient API error
+// Four attempts over roughly three seconds rides out a brief API server restart or failover
+// Tests shrink it so retries don't slow them down
+var fetchBackoff = wait.Backoff{
+	Steps:    4,
+	Duration: 400 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.1,
+}
+
+// isTransientError reports whether an API error is likely to go away on its own, by its category
+// Anything unrecognised is treated as permanent so real problems surface right away
+func isTransientError(err error) bool {
+	return classifyError(err).category == errorTransient
+}
+
+// retryFetch runs a read, retrying it with fetchBackoff while it fails with a transient error
+// The last error is returned once the attempts are used up
+func retryFetch[T any](fetch func() (T, error)) (T, error) {
+	var result T
+	err := retry.OnError(fetchBackoff, isTransientError, func() error {
+		var err error
+		result, err = fetch()
+		return err
+	})
+	return result, err
+}
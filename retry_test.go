@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestIsTransientError(t *testing.T) {
+	pods := schema.GroupResource{Resource: "pods"}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"server timeout", apierrors.NewServerTimeout(pods, "list", 1), true},
+		{"too many requests", apierrors.NewTooManyRequests("slow down", 1), true},
+		{"service unavailable", apierrors.NewServiceUnavailable("restarting"), true},
+		{"connection refused", fmt.Errorf("failed to list etcd pods: %w",
+			&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}), true},
+		{"dns failure", fmt.Errorf("failed to list etcd pods: %w",
+			&net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "api.example.com", IsNotFound: true}}), false},
+		{"request timeout", fmt.Errorf("failed to list etcd pods: %w", context.DeadlineExceeded), false},
+		{"not found", apierrors.NewNotFound(pods, "etcd-main-0"), false},
+		{"forbidden", apierrors.NewForbidden(pods, "etcd-main-0", errors.New("rbac")), false},
+		{"unknown", errors.New("something else"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTransientError(tt.err); got != tt.want {
+				t.Errorf("isTransientError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryFetch(t *testing.T) {
+	saved := fetchBackoff
+	fetchBackoff = wait.Backoff{Steps: 3, Duration: time.Millisecond}
+	t.Cleanup(func() { fetchBackoff = saved })
+
+	unavailable := apierrors.NewServiceUnavailable("restarting")
+
+	t.Run("recovers from transient errors", func(t *testing.T) {
+		calls := 0
+		got, err := retryFetch(func() (string, error) {
+			calls++
+			if calls < 3 {
+				return "", unavailable
+			}
+			return "ok", nil
+		})
+		if err != nil || got != "ok" {
+			t.Fatalf("retryFetch() = %q, %v, want ok", got, err)
+		}
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		calls := 0
+		_, err := retryFetch(func() (string, error) {
+			calls++
+			return "", unavailable
+		})
+		if !apierrors.IsServiceUnavailable(err) || calls != 3 {
+			t.Errorf("retryFetch() error = %v after %d calls, want the last error after 3", err, calls)
+		}
+	})
+
+	t.Run("permanent errors are not retried", func(t *testing.T) {
+		calls := 0
+		_, err := retryFetch(func() (string, error) {
+			calls++
+			return "", apierrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "etcd-main-0")
+		})
+		if err == nil || calls != 1 {
+			t.Errorf("retryFetch() error = %v after %d calls, want one call", err, calls)
+		}
+	})
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// restartedAtAnnotation is the pod template annotation kubectl rollout restart sets
+// Changing it changes the template, so the StatefulSet controller replaces every pod in turn
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// statefulSetRestartedMsg reports the outcome of patching the StatefulSet for a rolling restart
+type statefulSetRestartedMsg struct{ err error }
+
+// promptRestartStatefulSet asks for confirmation before restarting every etcd member
+func (m *Model) promptRestartStatefulSet() tea.Cmd {
+	if ok, cmd := m.guardMutation("restart"); !ok {
+		return cmd
+	}
+	label := fmt.Sprintf("restart all members of statefulset %s? (y/N)", m.etcdName)
+	return m.openPrompt(label, "", func(m *Model, answer string) tea.Cmd {
+		if answer := strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+			return m.setStatus("restart cancelled")
+		}
+		return m.restartStatefulSet()
+	})
+}
+
+// restartStatefulSet stamps the pod template with the current time, like kubectl rollout restart
+// etcd-druid names the StatefulSet after the Etcd resource
+func (m *Model) restartStatefulSet() tea.Cmd {
+	m.beginOperation(restartOperation(m.etcdName))
+	return func() tea.Msg {
+		ctx, cancel := m.requestContext()
+		defer cancel()
+		statefulSets := m.kubeClient.AppsV1().StatefulSets(m.namespace)
+		var before *appsv1.StatefulSet
+		if m.dryRun {
+			var err error
+			if before, err = statefulSets.Get(ctx, m.etcdName, metav1.GetOptions{}); err != nil {
+				return statefulSetRestartedMsg{fmt.Errorf("failed to get statefulset %s: %w", m.etcdName, err)}
+			}
+		}
+		patch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`,
+			restartedAtAnnotation, time.Now().Format(time.RFC3339))
+		after, err := statefulSets.Patch(ctx, m.etcdName, types.StrategicMergePatchType, []byte(patch), m.patchOptions())
+		if apierrors.IsForbidden(err) {
+			return statefulSetRestartedMsg{fmt.Errorf("not permitted to patch statefulset %s", m.etcdName)}
+		}
+		if err != nil {
+			return statefulSetRestartedMsg{fmt.Errorf("failed to restart statefulset %s: %w", m.etcdName, err)}
+		}
+		if m.dryRun {
+			return dryRunResult(restartOperation(m.etcdName), "restart all members of statefulset "+m.etcdName, before, after)
+		}
+		return statefulSetRestartedMsg{}
+	}
+}
+
+// fetchRolloutStatus renders the progress of the StatefulSet's rolling update and the revision of each pod
+func (m *Model) fetchRolloutStatus() (string, error) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	sts, err := m.kubeClient.AppsV1().StatefulSets(m.namespace).Get(ctx, m.etcdName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get statefulset %s: %w", m.etcdName, err)
+	}
+	podList, err := m.kubeClient.CoreV1().Pods(m.namespace).List(ctx, metav1.ListOptions{LabelSelector: m.podLabelSelector()})
+	if err != nil {
+		return "", fmt.Errorf("failed to list etcd pods: %w", err)
+	}
+
+	var out strings.Builder
+	out.WriteString(rolloutStatusLine(sts) + "\n\n")
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	out.WriteString(fmt.Sprintf("Replicas: %d desired, %d updated, %d ready\n",
+		replicas, sts.Status.UpdatedReplicas, sts.Status.ReadyReplicas))
+	out.WriteString(fmt.Sprintf("Current revision: %s\n", sts.Status.CurrentRevision))
+	out.WriteString(fmt.Sprintf("Update revision: %s\n", sts.Status.UpdateRevision))
+	if restartedAt := sts.Spec.Template.Annotations[restartedAtAnnotation]; restartedAt != "" {
+		out.WriteString(fmt.Sprintf("Restarted at: %s\n", restartedAt))
+	}
+
+	out.WriteString("\nPods:\n")
+	for _, pod := range podList.Items {
+		revision := pod.Labels[appsv1.ControllerRevisionHashLabelKey]
+		marker := ""
+		if revision != "" && revision == sts.Status.UpdateRevision {
+			marker = " (updated)"
+		}
+		out.WriteString(fmt.Sprintf("  %s: revision %s%s, ready: %t\n", pod.Name, revision, marker, podReady(pod)))
+	}
+	return out.String(), nil
+}
+
+// rolloutStatusLine summarizes a StatefulSet rollout in the words of kubectl rollout status
+func rolloutStatusLine(sts *appsv1.StatefulSet) string {
+	if sts.Spec.UpdateStrategy.Type != appsv1.RollingUpdateStatefulSetStrategyType {
+		return fmt.Sprintf("rollout status is only available for %s strategy type", appsv1.RollingUpdateStatefulSetStrategyType)
+	}
+	if sts.Status.ObservedGeneration == 0 || sts.Generation > sts.Status.ObservedGeneration {
+		return "Waiting for statefulset spec update to be observed..."
+	}
+	if sts.Spec.Replicas != nil && sts.Status.ReadyReplicas < *sts.Spec.Replicas {
+		return fmt.Sprintf("Waiting for %d pods to be ready...", *sts.Spec.Replicas-sts.Status.ReadyReplicas)
+	}
+	if rollingUpdate := sts.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil &&
+		rollingUpdate.Partition != nil && *rollingUpdate.Partition > 0 && sts.Spec.Replicas != nil {
+		if sts.Status.UpdatedReplicas < *sts.Spec.Replicas-*rollingUpdate.Partition {
+			return fmt.Sprintf("Waiting for partitioned roll out to finish: %d out of %d new pods have been updated...",
+				sts.Status.UpdatedReplicas, *sts.Spec.Replicas-*rollingUpdate.Partition)
+		}
+		return fmt.Sprintf("partitioned roll out complete: %d new pods have been updated...", sts.Status.UpdatedReplicas)
+	}
+	if sts.Status.UpdateRevision != sts.Status.CurrentRevision {
+		return fmt.Sprintf("waiting for statefulset rolling update to complete %d pods at revision %s...",
+			sts.Status.UpdatedReplicas, sts.Status.UpdateRevision)
+	}
+	return fmt.Sprintf("statefulset rolling update complete %d pods at revision %s...",
+		sts.Status.CurrentReplicas, sts.Status.CurrentRevision)
+}
+
+// podReady reports whether the pod's Ready condition is true
+func podReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// rolloutLoadedMsg carries the rendered rollout status
+type rolloutLoadedMsg struct{ content string }
+
+// loadRolloutStatus is a command that fetches the rollout status asynchronously
+func (m *Model) loadRolloutStatus() tea.Cmd {
+	return func() tea.Msg {
+		content, err := retryFetch(m.fetchRolloutStatus)
+		if err != nil {
+			return errMsg{err}
+		}
+		return rolloutLoadedMsg{content}
+	}
+}
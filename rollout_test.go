@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// testStatefulSet builds the StatefulSet etcd-druid creates for the test Etcd
+func testStatefulSet(replicas int32, status appsv1.StatefulSetStatus) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: testEtcdName, Namespace: testNamespace, Generation: 1},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:       &replicas,
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType},
+		},
+		Status: status,
+	}
+}
+
+func TestRestartStatefulSet(t *testing.T) {
+	m, client := newTestModel([]runtime.Object{testStatefulSet(3, appsv1.StatefulSetStatus{})})
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+
+	// Anything but yes cancels
+	m = update(t, m, keyMsg("R"))
+	if m.prompt == nil {
+		t.Fatal("R did not ask for confirmation")
+	}
+	m = update(t, m, keyMsg("n"))
+	next, cmd := m.Update(keyMsg("enter"))
+	m = next.(Model)
+	if cmd == nil || m.status != "restart cancelled" {
+		t.Fatalf("status = %q after declining, want restart cancelled", m.status)
+	}
+
+	m = update(t, m, keyMsg("R"))
+	m = update(t, m, keyMsg("y"))
+	_, cmd = m.Update(keyMsg("enter"))
+	msg, ok := cmd().(statefulSetRestartedMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("restart = %+v", msg)
+	}
+	sts, err := client.AppsV1().StatefulSets(testNamespace).Get(context.Background(), testEtcdName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get statefulset: %v", err)
+	}
+	if sts.Spec.Template.Annotations[restartedAtAnnotation] == "" {
+		t.Errorf("pod template annotations = %v, want %s set", sts.Spec.Template.Annotations, restartedAtAnnotation)
+	}
+
+	m = update(t, m, msg)
+	if m.state != RolloutState {
+		t.Errorf("state = %v after restarting, want RolloutState", m.state)
+	}
+}
+
+func TestRestartGuardedByReadOnly(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m.readOnly = true
+	m = update(t, m, keyMsg("R"))
+	if m.prompt != nil || !strings.Contains(m.status, "read-only") {
+		t.Errorf("prompt open = %v, status = %q, want the read-only notice", m.prompt != nil, m.status)
+	}
+}
+
+func TestRolloutStatusLine(t *testing.T) {
+	partition := int32(2)
+	partitioned := testStatefulSet(3, appsv1.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 3, UpdatedReplicas: 0})
+	partitioned.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition}
+	onDelete := testStatefulSet(3, appsv1.StatefulSetStatus{})
+	onDelete.Spec.UpdateStrategy.Type = appsv1.OnDeleteStatefulSetStrategyType
+
+	tests := []struct {
+		name string
+		sts  *appsv1.StatefulSet
+		want string
+	}{
+		{"on delete", onDelete, "rollout status is only available for RollingUpdate strategy type"},
+		{"not observed", testStatefulSet(3, appsv1.StatefulSetStatus{}), "Waiting for statefulset spec update to be observed..."},
+		{"pods not ready", testStatefulSet(3, appsv1.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 1}), "Waiting for 2 pods to be ready..."},
+		{"partitioned", partitioned, "Waiting for partitioned roll out to finish: 0 out of 1 new pods have been updated..."},
+		{"rolling", testStatefulSet(3, appsv1.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 3, UpdatedReplicas: 1,
+			CurrentRevision: "etcd-main-a", UpdateRevision: "etcd-main-b"}),
+			"waiting for statefulset rolling update to complete 1 pods at revision etcd-main-b..."},
+		{"complete", testStatefulSet(3, appsv1.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 3, CurrentReplicas: 3,
+			CurrentRevision: "etcd-main-b", UpdateRevision: "etcd-main-b"}),
+			"statefulset rolling update complete 3 pods at revision etcd-main-b..."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rolloutStatusLine(tt.sts); got != tt.want {
+				t.Errorf("rolloutStatusLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchRolloutStatus(t *testing.T) {
+	updated := testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))
+	updated.Labels[appsv1.ControllerRevisionHashLabelKey] = "etcd-main-b"
+	updated.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	old := testPod("etcd-main-1", corev1.PodRunning, runningContainer("etcd"))
+	old.Labels[appsv1.ControllerRevisionHashLabelKey] = "etcd-main-a"
+	sts := testStatefulSet(2, appsv1.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 1, UpdatedReplicas: 1,
+		CurrentRevision: "etcd-main-a", UpdateRevision: "etcd-main-b"})
+	m, _ := newTestModel([]runtime.Object{sts, updated, old})
+
+	got, err := m.fetchRolloutStatus()
+	if err != nil {
+		t.Fatalf("fetchRolloutStatus() error = %v", err)
+	}
+	for _, want := range []string{
+		"Waiting for 1 pods to be ready...\n",
+		"Replicas: 2 desired, 1 updated, 1 ready\n",
+		"  etcd-main-0: revision etcd-main-b (updated), ready: true\n",
+		"  etcd-main-1: revision etcd-main-a, ready: false\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("fetchRolloutStatus() missing %q:\n%s", want, got)
+		}
+	}
+}
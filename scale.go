@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// etcdScaledMsg reports the outcome of patching the replicas of the Etcd resource
+type etcdScaledMsg struct {
+	replicas int
+	err      error
+}
+
+// parseReplicas validates a replica count typed into the scale prompt
+func parseReplicas(value string) (int, error) {
+	replicas, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || replicas < 0 {
+		return 0, fmt.Errorf("invalid replica count %q, want a number of 0 or more", value)
+	}
+	return replicas, nil
+}
+
+// scaleConfirmation asks whether to go ahead with the new replica count
+// An even count tolerates no more member failures than the odd count below it while needing one more vote for quorum
+func (m *Model) scaleConfirmation(replicas int) string {
+	if replicas > 0 && replicas%2 == 0 {
+		return fmt.Sprintf("%d replicas is even and tolerates no more failures than %d, scale etcd %s anyway? (y/N)",
+			replicas, replicas-1, m.etcdName)
+	}
+	return fmt.Sprintf("scale etcd %s to %d replicas? (y/N)", m.etcdName, replicas)
+}
+
+// promptScaleEtcd asks for the new replica count of the Etcd resource, then for confirmation
+func (m *Model) promptScaleEtcd() tea.Cmd {
+	if ok, cmd := m.guardMutation("scale"); !ok {
+		return cmd
+	}
+	return m.openPrompt(fmt.Sprintf("scale etcd %s to replicas", m.etcdName), "", func(m *Model, value string) tea.Cmd {
+		replicas, err := parseReplicas(value)
+		if err != nil {
+			return m.setStatus(err.Error())
+		}
+		return m.openPrompt(m.scaleConfirmation(replicas), "", func(m *Model, answer string) tea.Cmd {
+			if answer := strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+				return m.setStatus("scale cancelled")
+			}
+			return m.scaleEtcd(replicas)
+		})
+	})
+}
+
+// scaleEtcd patches .spec.replicas of the Etcd resource; etcd-druid then scales the StatefulSet
+func (m *Model) scaleEtcd(replicas int) tea.Cmd {
+	m.beginOperation(scaleOperation(m.etcdName))
+	return func() tea.Msg {
+		ctx, cancel := m.requestContext()
+		defer cancel()
+		etcds := m.dynamicClient.Resource(etcdGVR).Namespace(m.namespace)
+		var before *unstructured.Unstructured
+		if m.dryRun {
+			var err error
+			if before, err = etcds.Get(ctx, m.etcdName, metav1.GetOptions{}); err != nil {
+				return etcdScaledMsg{err: fmt.Errorf("failed to get etcd %s: %w", m.etcdName, err)}
+			}
+		}
+		patch := fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas)
+		after, err := etcds.Patch(ctx, m.etcdName, types.MergePatchType, []byte(patch), m.patchOptions())
+		if apierrors.IsForbidden(err) {
+			return etcdScaledMsg{err: fmt.Errorf("not permitted to patch etcd %s", m.etcdName)}
+		}
+		if err != nil {
+			return etcdScaledMsg{err: fmt.Errorf("failed to scale etcd %s: %w", m.etcdName, err)}
+		}
+		if m.dryRun {
+			return dryRunResult(scaleOperation(m.etcdName), fmt.Sprintf("scale etcd %s to %d replicas", m.etcdName, replicas), before, after)
+		}
+		return etcdScaledMsg{replicas: replicas}
+	}
+}
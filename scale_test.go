@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestParseReplicas(t *testing.T) {
+	for value, want := range map[string]int{"3": 3, " 5 ": 5, "0": 0} {
+		if got, err := parseReplicas(value); err != nil || got != want {
+			t.Errorf("parseReplicas(%q) = %d, %v, want %d", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"", "-1", "three", "2.5"} {
+		if _, err := parseReplicas(value); err == nil {
+			t.Errorf("parseReplicas(%q) succeeded, want an error", value)
+		}
+	}
+}
+
+func TestScaleConfirmationWarnsAboutEvenCounts(t *testing.T) {
+	m, _ := newTestModel(nil)
+	if got := m.scaleConfirmation(4); !strings.Contains(got, "even") {
+		t.Errorf("scaleConfirmation(4) = %q, want a warning about even counts", got)
+	}
+	if got := m.scaleConfirmation(3); strings.Contains(got, "even") {
+		t.Errorf("scaleConfirmation(3) = %q, want no warning", got)
+	}
+}
+
+func TestScaleEtcd(t *testing.T) {
+	etcd := testEtcd(testEtcdName)
+	_ = unstructured.SetNestedField(etcd.Object, int64(3), "spec", "replicas")
+	m, _ := newTestModel(nil, etcd)
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("D"))
+
+	// Anything but yes cancels
+	m = update(t, m, keyMsg("s"))
+	m = update(t, m, keyMsg("5"))
+	m = update(t, m, keyMsg("enter"))
+	if m.prompt == nil {
+		t.Fatal("entering the replicas did not ask for confirmation")
+	}
+	m = update(t, m, keyMsg("enter"))
+	if m.status != "scale cancelled" {
+		t.Fatalf("status = %q after declining, want scale cancelled", m.status)
+	}
+
+	m = update(t, m, keyMsg("s"))
+	m = update(t, m, keyMsg("5"))
+	m = update(t, m, keyMsg("enter"))
+	m = update(t, m, keyMsg("y"))
+	_, cmd := m.Update(keyMsg("enter"))
+	msg, ok := cmd().(etcdScaledMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("scale = %+v", msg)
+	}
+	patched, err := m.dynamicClient.Resource(etcdGVR).Namespace(testNamespace).Get(context.Background(), testEtcdName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get etcd: %v", err)
+	}
+	if replicas, _, _ := unstructured.NestedInt64(patched.Object, "spec", "replicas"); replicas != 5 {
+		t.Errorf("spec.replicas = %d, want 5", replicas)
+	}
+
+	next, cmd := m.Update(msg)
+	m = next.(Model)
+	if m.state != DescribeState || !m.describeEtcd || cmd == nil || !strings.Contains(m.status, "scaled etcd etcd-main to 5") {
+		t.Errorf("after scaling: state %v, status %q, want the Etcd status reloading", m.state, m.status)
+	}
+}
+
+func TestScaleEtcdReadOnly(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m.readOnly = true
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("D"))
+	m = update(t, m, keyMsg("s"))
+	if m.prompt != nil || !strings.Contains(m.status, "read-only") {
+		t.Errorf("s in read-only mode: prompt open %v, status %q", m.prompt != nil, m.status)
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// splitSeparator is the column drawn between the two panes of the split log view
+const splitSeparator = "│"
+
+// logPane is one side of the split log view, bound to a pod's container and scrolled on its own
+type logPane struct {
+	pod       Pod
+	container string
+	viewport  viewport.Model
+	content   string
+	truncated bool
+	err       error // shown in the pane, the other side may still have logs
+}
+
+// openSplitLogs shows the logs of the two marked pods side by side, both from the etcd container
+func (m *Model) openSplitLogs() tea.Cmd {
+	for i, pod := range m.diffMarks {
+		m.splitPanes[i] = logPane{pod: pod, container: etcdContainer, viewport: viewport.New(0, 0)}
+	}
+	m.splitFocus = 0
+	m.navigate(SplitLogsState)
+	return m.loadSplitLogs()
+}
+
+// splitLogsLoadedMsg carries the logs of one pane of the split view
+type splitLogsLoadedMsg struct {
+	pane      int
+	source    string // logSource of the fetch, so results for a container switched away from are dropped
+	content   string
+	truncated bool
+	err       error
+}
+
+// loadSplitLogs is a command that fetches the logs of both panes asynchronously
+func (m *Model) loadSplitLogs() tea.Cmd {
+	return tea.Batch(m.loadPaneLogs(0), m.loadPaneLogs(1))
+}
+
+// loadPaneLogs is a command that fetches the logs of one pane with the log view settings
+// A failure stays in its pane, as one member being unreachable is often what is being looked at
+func (m *Model) loadPaneLogs(i int) tea.Cmd {
+	pane := m.splitPanes[i]
+	namespace := m.podNamespace(pane.pod)
+	return func() tea.Msg {
+		var truncated bool
+		content, err := retryFetch(func() (string, error) {
+			var content string
+			var err error
+			content, truncated, err = m.getPodLogs(namespace, pane.pod.Name, pane.container)
+			return content, err
+		})
+		return splitLogsLoadedMsg{pane: i, source: logSource(namespace, pane.pod.Name, pane.container), content: content, truncated: truncated, err: err}
+	}
+}
+
+// setPaneLogs shows fetched logs in their pane, following new lines unless the pane was scrolled up
+func (m *Model) setPaneLogs(msg splitLogsLoadedMsg) {
+	pane := &m.splitPanes[msg.pane]
+	if m.state != SplitLogsState || msg.source != logSource(m.podNamespace(pane.pod), pane.pod.Name, pane.container) {
+		return
+	}
+	follow := pane.content == "" || pane.viewport.AtBottom()
+	pane.content, pane.truncated, pane.err = msg.content, msg.truncated, msg.err
+	pane.viewport.SetContent(m.paneContent(*pane))
+	if follow {
+		pane.viewport.GotoBottom()
+	}
+}
+
+// paneContent renders a pane's logs like the log view does, or why there are none
+func (m *Model) paneContent(pane logPane) string {
+	if pane.err != nil {
+		return m.theme.eventWarning.Render("Error: " + pane.err.Error())
+	}
+	if strings.TrimSpace(pane.content) == "" {
+		return fmt.Sprintf("No logs available for the %s container of pod %s", pane.container, pane.pod.Name)
+	}
+	content := filterLogs(pane.content, m.minSeverity, m.logGrep)
+	if m.rawLogs {
+		return content
+	}
+	return prettyPrintLogs(content, m.theme)
+}
+
+// refreshPanes re-renders both panes after a display setting changed
+func (m *Model) refreshPanes() {
+	for i := range m.splitPanes {
+		m.splitPanes[i].viewport.SetContent(m.paneContent(m.splitPanes[i]))
+	}
+}
+
+// layoutPanes splits the width evenly between the panes, below their title line
+func (m *Model) layoutPanes(body int) {
+	left := (m.width - lipgloss.Width(splitSeparator)) / 2
+	widths := [2]int{left, m.width - lipgloss.Width(splitSeparator) - left}
+	for i := range m.splitPanes {
+		m.splitPanes[i].viewport.Width = max(widths[i], 1)
+		m.splitPanes[i].viewport.Height = max(body-1, 1)
+	}
+}
+
+// promptPaneContainer asks which container the focused pane shows
+func (m *Model) promptPaneContainer() tea.Cmd {
+	pane := &m.splitPanes[m.splitFocus]
+	return m.openPrompt(fmt.Sprintf("container of %s", pane.pod.Name), pane.container, func(m *Model, value string) tea.Cmd {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return nil
+		}
+		pane := &m.splitPanes[m.splitFocus]
+		pane.container, pane.content, pane.err = value, "", nil
+		return m.loadPaneLogs(m.splitFocus)
+	})
+}
+
+// singleLogs leaves the split view for the log view of the focused pane; esc returns to the split
+func (m *Model) singleLogs() tea.Cmd {
+	pane := m.splitPanes[m.splitFocus]
+	m.selectedPod = pane.pod
+	m.setContainers([]Container{{Name: pane.container}})
+	m.setMergedLogs(false)
+	return m.loadLogs(pane.container)
+}
+
+// splitView renders the panes side by side, the focused one with a highlighted title
+func (m *Model) splitView() string {
+	var panes [2]string
+	for i, pane := range m.splitPanes {
+		title := fmt.Sprintf("%s [%s]", m.podDisplayName(pane.pod), pane.container)
+		if pane.truncated {
+			title += " (truncated)"
+		}
+		style := m.theme.crumb
+		if i == m.splitFocus {
+			style = m.theme.crumbCurrent
+		}
+		width := pane.viewport.Width
+		panes[i] = lipgloss.NewStyle().Width(width).MaxWidth(width).Render(style.Render(title)) + "\n" + pane.viewport.View()
+	}
+	height := lipgloss.Height(panes[0])
+	separator := m.theme.crumb.Render(strings.TrimSuffix(strings.Repeat(splitSeparator+"\n", height), "\n"))
+	return lipgloss.JoinHorizontal(lipgloss.Top, panes[0], separator, panes[1])
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestSplitLogs(t *testing.T) {
+	m, _ := newTestModel([]runtime.Object{
+		testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd")),
+		testPod("etcd-main-1", corev1.PodRunning, runningContainer("etcd")),
+	})
+	m = update(t, m, tea.WindowSizeMsg{Width: 101, Height: 30})
+	m = update(t, m, m.loadPods()())
+
+	m = update(t, m, keyMsg("V"))
+	if m.state != ListState || !strings.Contains(m.status, "mark two pods") {
+		t.Fatalf("V without marks: state = %v, status = %q", m.state, m.status)
+	}
+	m = update(t, m, keyMsg(" "))
+	m = update(t, m, keyMsg("down"))
+	m = update(t, m, keyMsg(" "))
+	m = update(t, m, keyMsg("V"))
+	if m.state != SplitLogsState {
+		t.Fatalf("state = %v, want the split view", m.state)
+	}
+	for i := range m.splitPanes {
+		m = update(t, m, m.loadPaneLogs(i)())
+	}
+
+	// The width is shared evenly, less the separator
+	if m.splitPanes[0].viewport.Width != 50 || m.splitPanes[1].viewport.Width != 50 {
+		t.Errorf("pane widths = %d, %d, want 50 each", m.splitPanes[0].viewport.Width, m.splitPanes[1].viewport.Width)
+	}
+	m = update(t, m, tea.WindowSizeMsg{Width: 81, Height: 30})
+	if m.splitPanes[0].viewport.Width != 40 || m.splitPanes[1].viewport.Width != 40 {
+		t.Errorf("pane widths after resizing = %d, %d, want 40 each", m.splitPanes[0].viewport.Width, m.splitPanes[1].viewport.Width)
+	}
+	view := m.View()
+	for _, want := range []string{"etcd-main-0 [etcd]", "etcd-main-1 [etcd]", "fake logs" + strings.Repeat(" ", 31) + splitSeparator + "fake logs"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("split view missing %q:\n%s", want, view)
+		}
+	}
+
+	// tab moves the focus, which picks the pane shown on its own
+	m = update(t, m, keyMsg("tab"))
+	if m.splitFocus != 1 {
+		t.Fatalf("splitFocus = %d after tab, want the right pane", m.splitFocus)
+	}
+	next, cmd := m.Update(keyMsg("enter"))
+	m = update(t, next.(Model), cmd())
+	if m.state != LogState || m.selectedPod.Name != "etcd-main-1" || m.currentContainer() != "etcd" {
+		t.Fatalf("enter showed %v of %s/%s, want the logs of the focused pane", m.state, m.selectedPod.Name, m.currentContainer())
+	}
+	m = update(t, m, keyMsg("esc"))
+	if m.state != SplitLogsState {
+		t.Errorf("esc from the single view went to %v, want the split view", m.state)
+	}
+}
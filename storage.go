@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// selectedNodeAnnotation is set on a WaitForFirstConsumer claim once the scheduler picked a node for its pod
+const selectedNodeAnnotation = "volume.kubernetes.io/selected-node"
+
+// podClaims returns the PVCs a pod mounts, in the order of its volumes
+func podClaims(pod *corev1.Pod) []string {
+	var claims []string
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil {
+			claims = append(claims, volume.PersistentVolumeClaim.ClaimName)
+		}
+	}
+	return claims
+}
+
+// writeStorage appends the PVCs of a Pending pod and why those not Bound are stuck
+// An unbound claim is one of the most common reasons an etcd member stays Pending
+func (m *Model) writeStorage(desc *strings.Builder, pod *corev1.Pod) {
+	claims := podClaims(pod)
+	if len(claims) == 0 {
+		return
+	}
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	desc.WriteString("\nStorage:\n")
+
+	for _, name := range claims {
+		pvc, err := m.kubeClient.CoreV1().PersistentVolumeClaims(pod.Namespace).Get(ctx, name, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			desc.WriteString(fmt.Sprintf("  %s: missing, the pod can't start until it exists\n", name))
+			continue
+		case apierrors.IsForbidden(err):
+			desc.WriteString(fmt.Sprintf("  %s: (not permitted to read persistentvolumeclaims)\n", name))
+			continue
+		case err != nil:
+			desc.WriteString(fmt.Sprintf("  %s: (unavailable: %v)\n", name, err))
+			continue
+		}
+		desc.WriteString(fmt.Sprintf("  %s: %s\n", name, claimSummary(pvc)))
+		if pvc.Status.Phase == corev1.ClaimBound {
+			continue
+		}
+		desc.WriteString(fmt.Sprintf("    Reason: %s\n", m.claimPendingReason(ctx, pod, pvc)))
+		if event, ok := latestClaimWarning(m.claimEvents(ctx, pod.Namespace, name), name); ok {
+			desc.WriteString(fmt.Sprintf("    Last Warning: %s %s (%s)\n", event.Reason, strings.TrimSpace(event.Message), m.formatTimestamp(eventTime(event))))
+		}
+	}
+}
+
+// claimSummary renders the phase of a claim with what it is bound to, e.g. "Bound to pv-1 (10Gi, gp3)"
+func claimSummary(pvc *corev1.PersistentVolumeClaim) string {
+	class := "<no storage class>"
+	if pvc.Spec.StorageClassName != nil && *pvc.Spec.StorageClassName != "" {
+		class = *pvc.Spec.StorageClassName
+	}
+	if pvc.Status.Phase == corev1.ClaimBound {
+		capacity := pvc.Status.Capacity[corev1.ResourceStorage]
+		return fmt.Sprintf("Bound to %s (%s, %s)", pvc.Spec.VolumeName, capacity.String(), class)
+	}
+	phase := pvc.Status.Phase
+	if phase == "" {
+		phase = corev1.ClaimPending
+	}
+	request := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	return fmt.Sprintf("%s (requests %s, %s)", phase, request.String(), class)
+}
+
+// claimPendingReason explains why a claim isn't Bound from its storage class and the pod's scheduling
+func (m *Model) claimPendingReason(ctx context.Context, pod *corev1.Pod, pvc *corev1.PersistentVolumeClaim) string {
+	if pvc.Status.Phase == corev1.ClaimLost {
+		return fmt.Sprintf("the volume %s is gone, the claim needs to be recreated", pvc.Spec.VolumeName)
+	}
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return "no storage class, waiting for a matching PersistentVolume to be created by hand"
+	}
+	className := *pvc.Spec.StorageClassName
+	class, err := m.kubeClient.StorageV1().StorageClasses().Get(ctx, className, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		return fmt.Sprintf("storage class %s does not exist", className)
+	case apierrors.IsForbidden(err):
+		return fmt.Sprintf("waiting for storage class %s (not permitted to read storageclasses)", className)
+	case err != nil:
+		return fmt.Sprintf("waiting for storage class %s (unavailable: %v)", className, err)
+	}
+
+	if class.VolumeBindingMode == nil || *class.VolumeBindingMode != storagev1.VolumeBindingWaitForFirstConsumer {
+		return fmt.Sprintf("waiting for %s to provision a volume", class.Provisioner)
+	}
+	// With WaitForFirstConsumer the volume is only provisioned once the scheduler placed the pod
+	if node := pvc.Annotations[selectedNodeAnnotation]; node != "" {
+		return fmt.Sprintf("WaitForFirstConsumer, waiting for %s to provision a volume for node %s", class.Provisioner, node)
+	}
+	if pod.Spec.NodeName == "" {
+		return "WaitForFirstConsumer, waiting for the pod to be scheduled; its scheduling events tell why it isn't"
+	}
+	return fmt.Sprintf("WaitForFirstConsumer, waiting for a volume on node %s", pod.Spec.NodeName)
+}
+
+// claimEvents lists the events on a claim, where provisioners and the PV controller report why they can't bind
+// A failed list only leaves out the last warning, the claim's phase and reason are shown regardless
+func (m *Model) claimEvents(ctx context.Context, namespace, claim string) []corev1.Event {
+	selector := fields.SelectorFromSet(fields.Set{"involvedObject.kind": "PersistentVolumeClaim", "involvedObject.name": claim})
+	eventList, err := m.kubeClient.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: selector.String()})
+	if err != nil {
+		return nil
+	}
+	return eventList.Items
+}
+
+// latestClaimWarning finds the newest warning event on a claim, such as ProvisioningFailed
+// The events are filtered again, not every client applies the field selector of claimEvents
+func latestClaimWarning(events []corev1.Event, claim string) (corev1.Event, bool) {
+	var latest corev1.Event
+	found := false
+	for _, event := range events {
+		if event.Type != corev1.EventTypeWarning || event.InvolvedObject.Kind != "PersistentVolumeClaim" || event.InvolvedObject.Name != claim {
+			continue
+		}
+		if !found || eventTime(event).After(eventTime(latest)) {
+			latest, found = event, true
+		}
+	}
+	return latest, found
+}
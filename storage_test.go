@@ -0,0 +1,153 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// testClaim builds a PVC of the test namespace requesting 10Gi of a storage class
+func testClaim(name, class string, phase corev1.PersistentVolumeClaimPhase) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: &class,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{Phase: phase},
+	}
+}
+
+// pendingPodWithClaim builds an unscheduled Pending pod mounting a PVC
+func pendingPodWithClaim(claim string) *corev1.Pod {
+	pod := testPod("etcd-main-0", corev1.PodPending)
+	pod.Spec.NodeName = ""
+	pod.Spec.Volumes = []corev1.Volume{{
+		Name:         "etcd-main",
+		VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim}},
+	}}
+	return pod
+}
+
+func TestDescribePodStorage(t *testing.T) {
+	waitForConsumer := storagev1.VolumeBindingWaitForFirstConsumer
+	lazyClass := &storagev1.StorageClass{
+		ObjectMeta:        metav1.ObjectMeta{Name: "gp3"},
+		Provisioner:       "ebs.csi.aws.com",
+		VolumeBindingMode: &waitForConsumer,
+	}
+	immediateClass := &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "standard"}, Provisioner: "pd.csi.storage.gke.io"}
+
+	bound := testClaim("etcd-main-etcd-main-0", "gp3", corev1.ClaimBound)
+	bound.Spec.VolumeName = "pv-1"
+	bound.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")}
+
+	selected := testClaim("etcd-main-etcd-main-0", "gp3", corev1.ClaimPending)
+	selected.Annotations = map[string]string{selectedNodeAnnotation: "node-b"}
+
+	failed := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "provisioning-failed", Namespace: testNamespace},
+		InvolvedObject: corev1.ObjectReference{Kind: "PersistentVolumeClaim", Name: "etcd-main-etcd-main-0"},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "ProvisioningFailed",
+		Message:        "quota exceeded",
+		LastTimestamp:  metav1.NewTime(time.Now()),
+	}
+
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		want    []string
+	}{
+		{
+			name:    "bound",
+			objects: []runtime.Object{bound},
+			want:    []string{"etcd-main-etcd-main-0: Bound to pv-1 (10Gi, gp3)"},
+		},
+		{
+			name:    "waiting for the pod",
+			objects: []runtime.Object{testClaim("etcd-main-etcd-main-0", "gp3", corev1.ClaimPending), lazyClass},
+			want: []string{
+				"etcd-main-etcd-main-0: Pending (requests 10Gi, gp3)",
+				"Reason: WaitForFirstConsumer, waiting for the pod to be scheduled",
+			},
+		},
+		{
+			name:    "provisioning failed",
+			objects: []runtime.Object{selected, lazyClass, failed},
+			want: []string{
+				"Reason: WaitForFirstConsumer, waiting for ebs.csi.aws.com to provision a volume for node node-b",
+				"Last Warning: ProvisioningFailed quota exceeded",
+			},
+		},
+		{
+			name:    "immediate binding",
+			objects: []runtime.Object{testClaim("etcd-main-etcd-main-0", "standard", corev1.ClaimPending), immediateClass},
+			want:    []string{"Reason: waiting for pd.csi.storage.gke.io to provision a volume"},
+		},
+		{
+			name:    "storage class missing",
+			objects: []runtime.Object{testClaim("etcd-main-etcd-main-0", "gp3", corev1.ClaimPending)},
+			want:    []string{"Reason: storage class gp3 does not exist"},
+		},
+		{
+			name: "claim missing",
+			want: []string{"etcd-main-etcd-main-0: missing, the pod can't start until it exists"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _ := newTestModel(append(tt.objects, pendingPodWithClaim("etcd-main-etcd-main-0")))
+			desc, err := m.describePod(testNamespace, "etcd-main-0")
+			if err != nil {
+				t.Fatalf("describePod() error = %v", err)
+			}
+			for _, want := range append(tt.want, "\nStorage:\n") {
+				if !strings.Contains(desc, want) {
+					t.Errorf("describePod() missing %q:\n%s", want, desc)
+				}
+			}
+		})
+	}
+}
+
+func TestDescribeRunningPodSkipsStorage(t *testing.T) {
+	pod := pendingPodWithClaim("etcd-main-etcd-main-0")
+	pod.Status.Phase = corev1.PodRunning
+	m, _ := newTestModel([]runtime.Object{pod})
+	desc, err := m.describePod(testNamespace, "etcd-main-0")
+	if err != nil {
+		t.Fatalf("describePod() error = %v", err)
+	}
+	if strings.Contains(desc, "Storage:") {
+		t.Errorf("describePod() of a Running pod shows its storage:\n%s", desc)
+	}
+}
+
+func TestClaimEventsFieldSelector(t *testing.T) {
+	m, client := newTestModel([]runtime.Object{testClaim("etcd-main-etcd-main-0", "gp3", corev1.ClaimPending), pendingPodWithClaim("etcd-main-etcd-main-0")})
+	var selectors []string
+	client.PrependReactor("list", "events", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		selectors = append(selectors, action.(k8stesting.ListActionImpl).ListRestrictions.Fields.String())
+		return false, nil, nil
+	})
+	if _, err := m.describePod(testNamespace, "etcd-main-0"); err != nil {
+		t.Fatalf("describePod() error = %v", err)
+	}
+	// Only the events of the unbound claim are listed, not every event of the namespace
+	want := "involvedObject.kind=PersistentVolumeClaim,involvedObject.name=etcd-main-etcd-main-0"
+	if !slices.Contains(selectors, want) || slices.Contains(selectors, "") {
+		t.Errorf("events listed with field selectors %q, want %q", selectors, want)
+	}
+}
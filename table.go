@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// podTableColumns are the headings of the compact pod table, in the order kubectl get pods uses
+// BACKUP is one addition, since the sidecar matters as much as etcd itself; HOST IP, like IP from
+// kubectl get pods -o wide, saves a describe when debugging the connections between members
+var podTableColumns = []string{"NAME", "READY", "STATUS", "RESTARTS", "BACKUP", "AGE", "IP", "NODE", "HOST IP"}
+
+// noIP stands in for an address a pod hasn't been assigned yet
+const noIP = "—"
+
+// restartWarningThreshold is the restart count above which a pod is highlighted as likely crash-looping
+const restartWarningThreshold = 3
+
+// podTableHeadings returns the column headings, led by NAMESPACE like kubectl get pods -A
+func podTableHeadings(withNamespace bool) []string {
+	if withNamespace {
+		return append([]string{"NAMESPACE"}, podTableColumns...)
+	}
+	return podTableColumns
+}
+
+// podTableRow returns the cells of pod under podTableHeadings
+// A pod showing its namespace gets it in its own column rather than in the name
+func podTableRow(p Pod) []string {
+	backup := p.Backup
+	if backup == "" {
+		backup = "-"
+	}
+	podIP, hostIP := p.PodIP, p.HostIP
+	if podIP == "" {
+		podIP = noIP
+	}
+	if hostIP == "" {
+		hostIP = noIP
+	}
+	withNamespace := p.ShowNamespace
+	p.ShowNamespace = false
+	row := []string{p.Title(), p.Ready, p.Status, strconv.Itoa(int(p.Restarts)), backup, p.Age, podIP, p.Node, hostIP}
+	if withNamespace {
+		return append([]string{p.Namespace}, row...)
+	}
+	return row
+}
+
+// compactPodDelegate renders each pod as a single aligned table row
+// Being a list delegate keeps selection, filtering and paging identical to the normal layout
+type compactPodDelegate struct {
+	widths        []int
+	withNamespace bool
+	theme         theme
+}
+
+// newCompactPodDelegate sizes the columns to fit the headings and every pod
+func newCompactPodDelegate(pods []Pod, withNamespace bool, th theme) compactPodDelegate {
+	headings := podTableHeadings(withNamespace)
+	widths := make([]int, len(headings))
+	for i, column := range headings {
+		widths[i] = len(column)
+	}
+	for _, pod := range pods {
+		for i, cell := range podTableRow(pod) {
+			widths[i] = max(widths[i], lipgloss.Width(cell))
+		}
+	}
+	return compactPodDelegate{widths: widths, withNamespace: withNamespace, theme: th}
+}
+
+func (d compactPodDelegate) Height() int                         { return 1 }
+func (d compactPodDelegate) Spacing() int                        { return 0 }
+func (d compactPodDelegate) Update(tea.Msg, *list.Model) tea.Cmd { return nil }
+
+func (d compactPodDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	pod, ok := item.(Pod)
+	if !ok {
+		return
+	}
+	style, cursor := lipgloss.NewStyle(), "  "
+	if index == m.Index() {
+		style, cursor = d.theme.tableSelected, "> "
+	}
+	cells := d.padCells(podTableRow(pod))
+	headings := podTableHeadings(d.withNamespace)
+	highlights := map[int]lipgloss.Style{}
+	if pod.Restarts > restartWarningThreshold {
+		highlights[slices.Index(headings, "RESTARTS")] = d.theme.eventWarning.Inherit(style)
+	}
+	switch {
+	case pod.Terminating:
+		highlights[slices.Index(headings, "STATUS")] = d.theme.terminating.Inherit(style)
+	case pod.OOMKilled != "":
+		highlights[slices.Index(headings, "STATUS")] = d.theme.oomKilled.Inherit(style)
+	}
+	if len(highlights) == 0 {
+		fmt.Fprint(w, style.MaxWidth(m.Width()).Render(cursor+strings.Join(cells, "")))
+		return
+	}
+	// The cells are styled separately so a highlight doesn't end the row's style early
+	// Only the text is highlighted, the padding after it keeps the row's style so a badge doesn't run into the next column
+	row := style.Render(cursor)
+	for i, cell := range cells {
+		highlight, ok := highlights[i]
+		if !ok {
+			row += style.Render(cell)
+			continue
+		}
+		text := strings.TrimRight(cell, " ")
+		row += highlight.Render(text) + style.Render(cell[len(text):])
+	}
+	fmt.Fprint(w, lipgloss.NewStyle().MaxWidth(m.Width()).Render(row))
+}
+
+// header renders the column headings, aligned with the rows below
+func (d compactPodDelegate) header(width int) string {
+	return d.theme.tableHeader.MaxWidth(width).Render("  " + d.format(podTableHeadings(d.withNamespace)))
+}
+
+// format pads the cells to the column widths; the last column is left unpadded
+func (d compactPodDelegate) format(cells []string) string {
+	return strings.Join(d.padCells(cells), "")
+}
+
+// padCells pads each cell to its column width plus the gap to the next column
+func (d compactPodDelegate) padCells(cells []string) []string {
+	padded := make([]string, len(cells))
+	for i, cell := range cells {
+		if i == len(cells)-1 {
+			padded[i] = cell
+			break
+		}
+		padded[i] = fmt.Sprintf("%-*s   ", d.widths[i], cell)
+	}
+	return padded
+}
+
+// updatePodDelegate switches the pod list between the default and the compact layout
+// The compact table shows its own column headings in place of the list title
+func (m *Model) updatePodDelegate() {
+	if m.compactList {
+		m.list.SetDelegate(newCompactPodDelegate(m.pods, m.allNamespaces, m.theme))
+		m.list.SetShowTitle(false)
+	} else {
+		m.list.SetDelegate(newPodDelegate(m.theme))
+		m.list.SetShowTitle(true)
+	}
+	m.layout()
+}
+
+// podTableDelegate returns the compact delegate when the table layout is active
+func (m Model) podTableDelegate() (compactPodDelegate, bool) {
+	if !m.compactList {
+		return compactPodDelegate{}, false
+	}
+	return newCompactPodDelegate(m.pods, m.allNamespaces, m.theme), true
+}
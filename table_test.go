@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestCompactPodDelegateAlignsColumns(t *testing.T) {
+	pods := []Pod{
+		{Name: "etcd-main-0", Ready: "2/2", Status: "Running", Restarts: 0, Age: "1h0m0s", Node: "node-a", Role: "leader"},
+		{Name: "etcd-main-10", Ready: "1/2", Status: "Pending", Restarts: 12, Backup: "not ready", Age: "5s", Node: "node-b", HostIP: "10.250.0.2"},
+	}
+	d := newCompactPodDelegate(pods, false, newTheme("dark", palettes["dark"]))
+
+	header := d.format(podTableColumns)
+	row := d.format(podTableRow(pods[1]))
+	// Every column after NAME starts at the same offset in the header and the rows, counted in cells as the — of a missing IP is wider in bytes
+	for i, column := range podTableColumns[1:] {
+		want := lipgloss.Width(header[:strings.Index(header, column)])
+		got := lipgloss.Width(row[:strings.Index(row, podTableRow(pods[1])[i+1])])
+		if got != want {
+			t.Errorf("column %s starts at %d in the row, want %d\nheader: %q\nrow:    %q", column, got, want, header, row)
+		}
+	}
+	if !strings.Contains(row, "Pending   12         not ready   5s       —    node-b   10.250.0.2") {
+		t.Errorf("pending row = %q, want — for the missing pod IP", row)
+	}
+	if !strings.HasPrefix(d.format(podTableRow(pods[0])), "etcd-main-0 (leader)") {
+		t.Errorf("leader row = %q, want the leader tag in the name column", d.format(podTableRow(pods[0])))
+	}
+}
+
+func TestToggleCompactList(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}, {Name: "etcd-main-1"}}})
+
+	m = update(t, m, keyMsg("t"))
+	if !m.compactList {
+		t.Fatal("compactList not enabled by t")
+	}
+	if !strings.Contains(m.View(), "RESTARTS") {
+		t.Error("table headings missing from the compact view")
+	}
+
+	// Actions still resolve against the selected item
+	m = update(t, m, keyMsg("down"))
+	m = update(t, m, keyMsg("d"))
+	if m.selectedPod.Name != "etcd-main-1" {
+		t.Errorf("selectedPod = %q, want etcd-main-1", m.selectedPod.Name)
+	}
+}
+
+func TestCompactPodDelegateHighlightsRestarts(t *testing.T) {
+	pods := []Pod{
+		{Name: "etcd-main-0", Ready: "2/2", Status: "Running", Restarts: 0, Age: "1h0m0s", Node: "node-a"},
+		{Name: "etcd-main-1", Ready: "1/2", Status: "Running", Restarts: restartWarningThreshold + 1, Age: "1h0m0s", Node: "node-b"},
+	}
+	th := newTheme("dark", palettes["dark"])
+	d := newCompactPodDelegate(pods, false, th)
+	l := list.New([]list.Item{pods[0], pods[1]}, d, 120, 10)
+
+	// Styling the restart cell on its own must not shift the columns after it
+	for i, pod := range pods {
+		var row strings.Builder
+		d.Render(&row, l, i, pod)
+		if want := d.format(podTableRow(pod)); !strings.HasSuffix(row.String(), want) {
+			t.Errorf("row of %s = %q, want it to end in %q", pod.Name, row.String(), want)
+		}
+	}
+
+	// With colors the restarting pod, and only that one, shows in the warning color in either layout
+	previous := lipgloss.ColorProfile()
+	t.Cleanup(func() { lipgloss.SetColorProfile(previous) })
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	warning, _, _ := strings.Cut(lipgloss.NewStyle().Foreground(th.eventWarning.GetForeground()).Render("x"), "x")
+	for _, delegate := range []list.ItemDelegate{d, newPodDelegate(th)} {
+		for i, pod := range pods {
+			var row strings.Builder
+			delegate.Render(&row, l, i, pod)
+			if highlighted := strings.Contains(row.String(), warning); highlighted != (pod.Restarts > restartWarningThreshold) {
+				t.Errorf("%T row of %s with %d restarts: highlighted = %v", delegate, pod.Name, pod.Restarts, highlighted)
+			}
+		}
+	}
+
+	if desc := pods[1].Description(); !strings.Contains(desc, fmt.Sprintf("Restarts: %d", restartWarningThreshold+1)) {
+		t.Errorf("Description() = %q, want the restart count", desc)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tabbedView is the log or describe view of a pod that tab switched away from
+// It is kept so switching back shows it as it was left instead of fetching it again
+type tabbedView struct {
+	state   AppState
+	pod     string // podDisplayName of the pod the view belongs to
+	content string
+	yOffset int
+}
+
+// canToggleLogDescribe reports whether tab switches between the logs and describe of the selected pod
+// The Etcd resource and a single container's describe have no counterpart among the logs
+func (m *Model) canToggleLogDescribe() bool {
+	if m.selectedPod.Name == "" {
+		return false
+	}
+	return m.state == LogState || (m.state == DescribeState && !m.describeEtcd && m.describeOne == "")
+}
+
+// toggleLogDescribe switches between the logs and the describe of the selected pod
+// The view is swapped in place, so esc from either still returns to where the pair was opened from
+// The other view is only fetched the first time; after that it comes back with its scroll position
+func (m *Model) toggleLogDescribe() tea.Cmd {
+	if !m.canToggleLogDescribe() {
+		return nil
+	}
+	other := DescribeState
+	if m.state == DescribeState {
+		other = LogState
+	}
+	pod := m.podDisplayName(m.selectedPod)
+	saved := m.tabbed
+	// A view still loading has nothing worth keeping
+	m.tabbed = nil
+	if m.loading == "" {
+		m.tabbed = &tabbedView{state: m.state, pod: pod, content: m.content, yOffset: m.viewport.YOffset}
+	}
+	m.stopLoading()
+
+	if saved != nil && saved.state == other && saved.pod == pod {
+		m.state = other
+		m.layout()
+		m.content = saved.content
+		m.refreshViewport()
+		m.viewport.SetYOffset(saved.yOffset)
+		// Followed logs pick up where they were left
+		if m.streamsLogs() {
+			return m.reloadLogs()
+		}
+		if m.isLive() {
+			return tea.Batch(m.reloadLogs(), m.scheduleRefresh())
+		}
+		return nil
+	}
+
+	m.content = ""
+	if other == DescribeState {
+		m.state = DescribeState
+		m.layout()
+		m.refreshViewport()
+		return tea.Batch(m.startLoading(m.describeLoadingText()), m.loadDescribe())
+	}
+	// Opening the logs takes the container picked the same way l does
+	m.state = ContainerSelectState
+	m.skipToDefault = true
+	m.layout()
+	return m.loadContainers()
+}
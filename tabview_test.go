@@ -0,0 +1,90 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestToggleLogDescribe(t *testing.T) {
+	pod := testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))
+	m, _ := newTestModel([]runtime.Object{pod})
+	m = update(t, m, tea.WindowSizeMsg{Width: 80, Height: 12})
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("l"))
+	m = update(t, m, containersLoadedMsg{[]Container{{Name: "etcd"}}})
+	m = update(t, m, logsLoadedMsg{content: strings.Repeat("log line\n", 50)})
+	m.viewport.SetYOffset(20)
+
+	// The describe is fetched the first time
+	next, cmd := m.Update(keyMsg("tab"))
+	m = next.(Model)
+	if m.state != DescribeState || cmd == nil {
+		t.Fatalf("state = %v after tab, want the describe loading", m.state)
+	}
+	m = update(t, m, describeLoadedMsg{content: strings.Repeat("describe line\n", 50)})
+	m.viewport.SetYOffset(5)
+
+	// Back to the logs as they were left, without fetching them again
+	next, cmd = m.Update(keyMsg("tab"))
+	m = next.(Model)
+	if m.state != LogState || cmd != nil {
+		t.Fatalf("state = %v after the second tab, want the kept logs", m.state)
+	}
+	if !strings.HasPrefix(m.content, "log line") || m.viewport.YOffset != 20 {
+		t.Errorf("logs restored at offset %d, want 20", m.viewport.YOffset)
+	}
+
+	m = update(t, m, keyMsg("tab"))
+	if !strings.HasPrefix(m.content, "describe line") || m.viewport.YOffset != 5 {
+		t.Errorf("describe restored at offset %d, want 5", m.viewport.YOffset)
+	}
+
+	// Toggling swaps the views in place, so esc returns to the list
+	m = update(t, m, keyMsg("esc"))
+	if m.state != ListState || m.tabbed != nil {
+		t.Errorf("esc went to %v, want the list with the kept view dropped", m.state)
+	}
+}
+
+func TestToggleDescribeToLogs(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m = update(t, m, keyMsg("d"))
+	m = update(t, m, describeLoadedMsg{content: "describe"})
+
+	// The logs open on the container l would pick
+	next, cmd := m.Update(keyMsg("tab"))
+	m = next.(Model)
+	if m.state != ContainerSelectState || cmd == nil {
+		t.Fatalf("state = %v after tab, want the containers loading", m.state)
+	}
+	m = update(t, m, containersLoadedMsg{[]Container{{Name: "etcd"}}})
+	m = update(t, m, logsLoadedMsg{content: "logs"})
+	if m.state != LogState || m.content != "logs" {
+		t.Fatalf("state = %v, content = %q, want the logs", m.state, m.content)
+	}
+
+	m = update(t, m, keyMsg("tab"))
+	if m.state != DescribeState || m.content != "describe" {
+		t.Errorf("state = %v, content = %q, want the kept describe", m.state, m.content)
+	}
+	m = update(t, m, keyMsg("esc"))
+	if m.state != ListState {
+		t.Errorf("esc went to %v, want the list", m.state)
+	}
+}
+
+func TestToggleLogDescribeSkipsEtcdDescribe(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m = update(t, m, podsLoadedMsg{pods: []Pod{{Name: "etcd-main-0"}}})
+	m.selectedPod = m.pods[0]
+	m = update(t, m, keyMsg("D"))
+	m = update(t, m, keyMsg("tab"))
+	if m.state != DescribeState {
+		t.Errorf("tab in the Etcd describe went to %v, want it ignored", m.state)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// parseNamespaceSelector validates a --namespace-label-selector value and returns it in canonical form
+func parseNamespaceSelector(value string) (string, error) {
+	selector, err := labels.Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid --namespace-label-selector %q: %w", value, err)
+	}
+	return selector.String(), nil
+}
+
+// fetchSelectedNamespaces lists the namespaces matching --namespace-label-selector, sorted by name
+func (m *Model) fetchSelectedNamespaces() ([]string, error) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	namespaceList, err := m.kubeClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{LabelSelector: m.namespaceSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces matching %s: %w", m.namespaceSelector, err)
+	}
+	namespaces := make([]string, len(namespaceList.Items))
+	for i, namespace := range namespaceList.Items {
+		namespaces[i] = namespace.Name
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+// fetchTenantEtcds lists the Etcd resources of every selected namespace, sorted by namespace and name
+// A tenant whose Etcds RBAC hides is skipped rather than hiding the others
+func (m *Model) fetchTenantEtcds() ([]unstructured.Unstructured, error) {
+	namespaces, err := m.fetchSelectedNamespaces()
+	if err != nil {
+		return nil, err
+	}
+	var etcds []unstructured.Unstructured
+	for _, namespace := range namespaces {
+		ctx, cancel := m.requestContext()
+		etcdList, err := m.dynamicClient.Resource(etcdGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		cancel()
+		if apierrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list Etcd resources in %s: %w", namespace, err)
+		}
+		items := etcdList.Items
+		sort.Slice(items, func(i, j int) bool { return items[i].GetName() < items[j].GetName() })
+		etcds = append(etcds, items...)
+	}
+	return etcds, nil
+}
+
+// etcdScope names where the selection screen looks for Etcd resources
+func (m *Model) etcdScope() string {
+	if m.namespaceSelector != "" {
+		return "namespaces matching " + m.namespaceSelector
+	}
+	return m.namespace
+}
+
+// selectTenantEtcd switches to the Etcd picked as namespace/name from the selected namespaces
+func (m *Model) selectTenantEtcd(name string) tea.Cmd {
+	namespace, etcdName, ok := strings.Cut(name, "/")
+	if !ok || namespace == "" || etcdName == "" {
+		return m.setStatus(fmt.Sprintf("give the etcd as namespace/name, %q has no namespace", name))
+	}
+	m.namespace = namespace
+	return m.selectEtcd(etcdName)
+}
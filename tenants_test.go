@@ -0,0 +1,101 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// tenantNamespace builds a namespace with the given labels
+func tenantNamespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+// tenantEtcd builds an Etcd resource in the given namespace
+func tenantEtcd(namespace, name string) runtime.Object {
+	etcd := testEtcd(name)
+	etcd.SetNamespace(namespace)
+	return etcd
+}
+
+// newTenantSelectModel builds a Model started with only --namespace-label-selector tenant=true
+func newTenantSelectModel(t *testing.T, etcdObjects ...runtime.Object) Model {
+	t.Helper()
+	tenant := map[string]string{"tenant": "true"}
+	m, _ := newTestModel([]runtime.Object{
+		tenantNamespace("shoot--a", tenant),
+		tenantNamespace("shoot--b", tenant),
+		tenantNamespace("garden", map[string]string{"tenant": "false"}),
+	}, etcdObjects...)
+	m.namespace, m.etcdName = "", ""
+	m.namespaceSelector = "tenant=true"
+	m.state = EtcdSelectState
+	return m
+}
+
+func TestFetchTenantEtcdNames(t *testing.T) {
+	m := newTenantSelectModel(t, tenantEtcd("shoot--b", "etcd-main"), tenantEtcd("shoot--a", "etcd-main"),
+		tenantEtcd("shoot--a", "etcd-events"), tenantEtcd("garden", "etcd-main"))
+	names, err := m.fetchEtcdNames()
+	if err != nil {
+		t.Fatalf("fetchEtcdNames() error = %v", err)
+	}
+	if got := strings.Join(names, ","); got != "shoot--a/etcd-events,shoot--a/etcd-main,shoot--b/etcd-main" {
+		t.Errorf("fetchEtcdNames() = %s, want the Etcds of the labeled namespaces only", got)
+	}
+}
+
+func TestFetchTenantEtcdNamesSkipsForbiddenNamespace(t *testing.T) {
+	m := newTenantSelectModel(t, tenantEtcd("shoot--a", "etcd-main"), tenantEtcd("shoot--b", "etcd-main"))
+	m.dynamicClient.(*dynamicfake.FakeDynamicClient).PrependReactor("list", "etcds",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if action.GetNamespace() != "shoot--a" {
+				return false, nil, nil
+			}
+			return true, nil, apierrors.NewForbidden(etcdGVR.GroupResource(), "", nil)
+		})
+	names, err := m.fetchEtcdNames()
+	if err != nil {
+		t.Fatalf("fetchEtcdNames() error = %v", err)
+	}
+	if got := strings.Join(names, ","); got != "shoot--b/etcd-main" {
+		t.Errorf("fetchEtcdNames() = %s, want only the readable tenant", got)
+	}
+}
+
+func TestTenantSelectPicksNamespaceAndEtcd(t *testing.T) {
+	m := newTenantSelectModel(t, tenantEtcd("shoot--a", "etcd-main"), tenantEtcd("shoot--b", "etcd-main"))
+	m = update(t, m, m.Init()())
+	if m.prompt == nil || !strings.Contains(m.content, "namespaces matching tenant=true") {
+		t.Fatalf("content = %q, want the tenant Etcds and the name prompt", m.content)
+	}
+
+	// A bare name doesn't say which tenant
+	m.prompt.input.SetValue("etcd-main")
+	m = update(t, m, keyMsg("enter"))
+	if m.state != EtcdSelectState || !strings.Contains(m.status, "namespace/name") {
+		t.Fatalf("state = %v with status %q, want to ask for the namespace too", m.state, m.status)
+	}
+
+	m = update(t, m, keyMsg("enter"))
+	m.prompt.input.SetValue("shoot--b/etcd-main")
+	m = update(t, m, keyMsg("enter"))
+	if m.state != ListState || m.namespace != "shoot--b" || m.etcdName != "etcd-main" {
+		t.Errorf("state = %v with etcd %s/%s, want the pod list of shoot--b/etcd-main", m.state, m.namespace, m.etcdName)
+	}
+}
+
+func TestParseNamespaceSelector(t *testing.T) {
+	if got, err := parseNamespaceSelector("tenant=true, tier!=system"); err != nil || got != "tenant=true,tier!=system" {
+		t.Errorf("parseNamespaceSelector() = %q, %v, want the canonical selector", got, err)
+	}
+	if _, err := parseNamespaceSelector("tenant in (a"); err == nil {
+		t.Error("parseNamespaceSelector() accepted a malformed selector")
+	}
+}
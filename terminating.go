@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// terminatingStatus is what kubectl shows for a pod being deleted, whose phase still says Running
+const terminatingStatus = "Terminating"
+
+// terminatingPodStatus renders the STATUS of a pod being deleted, with the time left of its grace period
+// The deletion timestamp is when the kubelet kills whatever is still running, so past it no time is shown
+func terminatingPodStatus(pod *corev1.Pod, now time.Time) string {
+	left := pod.DeletionTimestamp.Sub(now).Truncate(time.Second)
+	if left <= 0 {
+		return terminatingStatus
+	}
+	return fmt.Sprintf("%s (%s left)", terminatingStatus, left)
+}
+
+// podDelegate is the default two-line rendering of pods, which sets terminating pods apart in the theme's color
+// and highlights the description, where the restart count is, of a pod restarting more than restartWarningThreshold
+type podDelegate struct {
+	list.DefaultDelegate
+	terminating    lipgloss.Style
+	restartWarning lipgloss.Style
+}
+
+// newPodDelegate returns the default two-line rendering of pods in the list
+func newPodDelegate(th theme) podDelegate {
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.Inherit(th.selectedTitle)
+	return podDelegate{DefaultDelegate: delegate, terminating: th.terminating, restartWarning: th.eventWarning}
+}
+
+func (d podDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	pod, ok := item.(Pod)
+	switch {
+	case ok && pod.Terminating:
+		// The selected title keeps the selection color, so the cursor stays easy to find
+		color := d.terminating.GetForeground()
+		d.Styles.NormalTitle = d.Styles.NormalTitle.Foreground(color)
+		d.Styles.NormalDesc = d.Styles.NormalDesc.Foreground(color)
+		d.Styles.SelectedDesc = d.Styles.SelectedDesc.Foreground(color)
+	case ok && pod.Restarts > restartWarningThreshold:
+		color := d.restartWarning.GetForeground()
+		d.Styles.NormalDesc = d.Styles.NormalDesc.Foreground(color)
+		d.Styles.SelectedDesc = d.Styles.SelectedDesc.Foreground(color)
+	}
+	d.DefaultDelegate.Render(w, m, index, item)
+}
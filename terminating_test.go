@@ -0,0 +1,54 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestTerminatingPodStatus(t *testing.T) {
+	now := time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)
+	pod := testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))
+	deadline := metav1.NewTime(now.Add(25*time.Second + 500*time.Millisecond))
+	pod.DeletionTimestamp = &deadline
+	if got := terminatingPodStatus(pod, now); got != "Terminating (25s left)" {
+		t.Errorf("terminatingPodStatus() = %q, want the grace period left", got)
+	}
+	if got := terminatingPodStatus(pod, now.Add(time.Minute)); got != "Terminating" {
+		t.Errorf("terminatingPodStatus() past the deadline = %q, want no time left shown", got)
+	}
+}
+
+func TestFetchEtcdPodsTerminating(t *testing.T) {
+	deleting := testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))
+	deadline := metav1.NewTime(time.Now().Add(time.Minute))
+	deleting.DeletionTimestamp = &deadline
+	m, _ := newTestModel([]runtime.Object{deleting, testPod("etcd-main-1", corev1.PodRunning, runningContainer("etcd"))})
+
+	pods, err := m.fetchEtcdPods()
+	if err != nil {
+		t.Fatalf("fetchEtcdPods() error = %v", err)
+	}
+	if !pods[0].Terminating || !strings.HasPrefix(pods[0].Status, "Terminating (") {
+		t.Errorf("pod being deleted = %+v, want it reported Terminating instead of Running", pods[0])
+	}
+	if pods[1].Terminating || pods[1].Status != "Running" {
+		t.Errorf("running pod = %+v, want it left as is", pods[1])
+	}
+
+	// Both layouts render it
+	m = update(t, m, tea.WindowSizeMsg{Width: 200, Height: 30})
+	m = update(t, m, podsLoadedMsg{pods: pods})
+	for _, compact := range []bool{false, true} {
+		m.compactList = compact
+		m.updatePodDelegate()
+		if view := m.View(); !strings.Contains(view, "Terminating (") {
+			t.Errorf("compact=%v: list doesn't show the terminating status:\n%s", compact, view)
+		}
+	}
+}
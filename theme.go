@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// themeNames are the presets the theme toggle cycles through, in order
+var themeNames = []string{"dark", "light", "high-contrast"}
+
+// themeAuto picks dark or light from the terminal background
+const themeAuto = "auto"
+
+// palette is the handful of colors a theme is built from
+type palette struct {
+	accent     lipgloss.Color // titles, selection and the prompt
+	muted      lipgloss.Color // help text, line numbers and debug logs
+	subtle     lipgloss.Color // log timestamps, log fields and table headings
+	barFg      lipgloss.Color
+	barBg      lipgloss.Color
+	info       lipgloss.Color
+	warn       lipgloss.Color // also used for footer notices
+	error      lipgloss.Color
+	alertFg    lipgloss.Color // the insecure connection badge
+	alertBg    lipgloss.Color
+	chromaName string // chroma style for YAML highlighting
+}
+
+var palettes = map[string]palette{
+	"dark": {
+		accent: "212", muted: "240", subtle: "245",
+		barFg: "252", barBg: "236",
+		info: "42", warn: "214", error: "196",
+		alertFg: "231", alertBg: "160",
+		chromaName: "monokai",
+	},
+	"light": {
+		accent: "125", muted: "244", subtle: "240",
+		barFg: "235", barBg: "254",
+		info: "28", warn: "130", error: "160",
+		alertFg: "231", alertBg: "160",
+		chromaName: "github",
+	},
+	// Only the 16 basic colors, which terminals render at full intensity
+	"high-contrast": {
+		accent: "11", muted: "7", subtle: "15",
+		barFg: "0", barBg: "15",
+		info: "10", warn: "11", error: "9",
+		alertFg: "15", alertBg: "1",
+		chromaName: "vim",
+	},
+}
+
+// theme holds every style used to render the TUI
+// Styling using lipgloss - this makes our TUI visually appealing
+type theme struct {
+	name string
+
+	header   lipgloss.Style
+	help     lipgloss.Style
+	footer   lipgloss.Style
+	status   lipgloss.Style
+	insecure lipgloss.Style
+	prompt   lipgloss.Style
+
+	// Pod list, in both the default and the compact table layout
+	selectedTitle lipgloss.Style
+	tableHeader   lipgloss.Style
+	tableSelected lipgloss.Style
+
+	eventWarning lipgloss.Style
+	eventNew     lipgloss.Style // events that just streamed in, on top of eventWarning for warnings
+	lineNumber   lipgloss.Style
+	oomKilled    lipgloss.Style // badge marking an OOMKilled container in the list and describe
+	terminating  lipgloss.Style // pods being deleted, in the list
+
+	// The breadcrumb above every screen, with the current level standing out
+	crumb        lipgloss.Style
+	crumbCurrent lipgloss.Style
+
+	// Unified diff lines in the diff view
+	diffFile    lipgloss.Style
+	diffHunk    lipgloss.Style
+	diffAdded   lipgloss.Style
+	diffRemoved lipgloss.Style
+
+	// Styles for pretty-printed JSON logs
+	logTime  lipgloss.Style
+	logField lipgloss.Style
+	logDebug lipgloss.Style
+	logInfo  lipgloss.Style
+	logWarn  lipgloss.Style
+	logError lipgloss.Style
+
+	// Container tags in the merged log view, assigned by container index
+	logContainers []lipgloss.Style
+
+	yamlStyle string
+}
+
+// newTheme builds the styles of a preset
+func newTheme(name string, p palette) theme {
+	return theme{
+		name: name,
+
+		header: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(p.accent).
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderBottom(true).
+			MarginBottom(1),
+		help: lipgloss.NewStyle().
+			Foreground(p.muted).
+			MarginTop(1),
+		footer: lipgloss.NewStyle().
+			Foreground(p.barFg).
+			Background(p.barBg).
+			Padding(0, 1),
+		status: lipgloss.NewStyle().
+			Foreground(p.warn).
+			Bold(true),
+		insecure: lipgloss.NewStyle().
+			Foreground(p.alertFg).
+			Background(p.alertBg).
+			Bold(true).
+			Padding(0, 1),
+		prompt: lipgloss.NewStyle().Foreground(p.accent),
+
+		selectedTitle: lipgloss.NewStyle().Foreground(p.accent).Bold(true),
+		tableHeader:   lipgloss.NewStyle().Bold(true).Foreground(p.subtle),
+		tableSelected: lipgloss.NewStyle().Bold(true).Foreground(p.accent),
+
+		eventWarning: lipgloss.NewStyle().Foreground(p.error),
+		eventNew:     lipgloss.NewStyle().Bold(true).Reverse(true),
+		lineNumber:   lipgloss.NewStyle().Foreground(p.muted),
+		oomKilled:    lipgloss.NewStyle().Foreground(p.alertFg).Background(p.alertBg).Bold(true),
+		terminating:  lipgloss.NewStyle().Foreground(p.warn),
+
+		crumb:        lipgloss.NewStyle().Foreground(p.muted),
+		crumbCurrent: lipgloss.NewStyle().Foreground(p.accent).Bold(true),
+
+		diffFile:    lipgloss.NewStyle().Bold(true),
+		diffHunk:    lipgloss.NewStyle().Foreground(p.accent),
+		diffAdded:   lipgloss.NewStyle().Foreground(p.info),
+		diffRemoved: lipgloss.NewStyle().Foreground(p.error),
+
+		logTime:  lipgloss.NewStyle().Foreground(p.subtle),
+		logField: lipgloss.NewStyle().Foreground(p.subtle),
+		logDebug: lipgloss.NewStyle().Foreground(p.muted),
+		logInfo:  lipgloss.NewStyle().Foreground(p.info),
+		logWarn:  lipgloss.NewStyle().Foreground(p.warn),
+		logError: lipgloss.NewStyle().Foreground(p.error).Bold(true),
+
+		logContainers: []lipgloss.Style{
+			lipgloss.NewStyle().Foreground(p.accent),
+			lipgloss.NewStyle().Foreground(p.info),
+			lipgloss.NewStyle().Foreground(p.warn),
+			lipgloss.NewStyle().Foreground(p.subtle),
+			lipgloss.NewStyle().Foreground(p.error),
+		},
+
+		yamlStyle: p.chromaName,
+	}
+}
+
+// lookupTheme returns the named preset, with "auto" or an empty name following the terminal background
+func lookupTheme(name string) (theme, error) {
+	if name == "" || name == themeAuto {
+		name = "light"
+		if lipgloss.HasDarkBackground() {
+			name = "dark"
+		}
+	}
+	p, ok := palettes[name]
+	if !ok {
+		return theme{}, fmt.Errorf("unknown theme %q, must be %s or %s", name, strings.Join(themeNames, ", "), themeAuto)
+	}
+	return newTheme(name, p), nil
+}
+
+// nextThemeName returns the preset after current, wrapping around
+func nextThemeName(current string) string {
+	for i, name := range themeNames {
+		if name == current {
+			return themeNames[(i+1)%len(themeNames)]
+		}
+	}
+	return themeNames[0]
+}
+
+// setTheme switches every style over to th, including the list delegates that hold a copy
+func (m *Model) setTheme(th theme) {
+	m.theme = th
+	m.updatePodDelegate()
+}
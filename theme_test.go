@@ -0,0 +1,45 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLookupTheme(t *testing.T) {
+	for _, name := range themeNames {
+		th, err := lookupTheme(name)
+		if err != nil {
+			t.Errorf("lookupTheme(%q) error = %v", name, err)
+		}
+		if th.name != name || th.yamlStyle == "" {
+			t.Errorf("lookupTheme(%q) = %q with chroma style %q", name, th.name, th.yamlStyle)
+		}
+	}
+
+	if _, err := lookupTheme("solarized"); err == nil || !strings.Contains(err.Error(), "high-contrast") {
+		t.Errorf("lookupTheme(solarized) error = %v, want the valid names listed", err)
+	}
+
+	// Auto always resolves to one of the presets
+	th, err := lookupTheme(themeAuto)
+	if err != nil || (th.name != "dark" && th.name != "light") {
+		t.Errorf("lookupTheme(auto) = %q, %v", th.name, err)
+	}
+}
+
+func TestThemeToggle(t *testing.T) {
+	m, _ := newTestModel(nil)
+	if m.theme.name != "dark" {
+		t.Fatalf("initial theme = %q, want dark", m.theme.name)
+	}
+
+	for _, want := range []string{"light", "high-contrast", "dark"} {
+		m = update(t, m, keyMsg("T"))
+		if m.theme.name != want || m.themeName != want {
+			t.Errorf("theme after T = %q (saved as %q), want %q", m.theme.name, m.themeName, want)
+		}
+	}
+	if got := m.currentConfig().Theme; got != "dark" {
+		t.Errorf("currentConfig().Theme = %q, want dark", got)
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// defaultRequestTimeout bounds each API call so a hung API server can't freeze a screen
+// It is generous enough for fetching a full log over a slow link
+const defaultRequestTimeout = 30 * time.Second
+
+// requestContext returns the context for one API call, bounded by requestTimeout
+// A zero timeout, from --request-timeout=0, waits as long as the API server takes
+func (m *Model) requestContext() (context.Context, context.CancelFunc) {
+	if m.requestTimeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), m.requestTimeout)
+}
+
+// isRequestTimeout reports whether err comes from a request running out of requestTimeout
+func isRequestTimeout(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded)
+}
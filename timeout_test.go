@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestRequestContext(t *testing.T) {
+	m, _ := newTestModel(nil)
+	m.requestTimeout = time.Minute
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > time.Minute {
+		t.Errorf("Deadline() = %v, %v, want within a minute", deadline, ok)
+	}
+
+	m.requestTimeout = 0
+	ctx, cancel = m.requestContext()
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Errorf("Deadline() set with a zero requestTimeout")
+	}
+}
+
+func TestRequestTimeoutRetry(t *testing.T) {
+	m, client := newTestModel([]runtime.Object{testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))})
+	timingOut := true
+	client.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if timingOut {
+			return true, nil, fmt.Errorf("Get \"https://api/pods\": %w", context.DeadlineExceeded)
+		}
+		return false, nil, nil
+	})
+
+	m = update(t, m, m.loadPods()())
+	if view := m.View(); !strings.Contains(view, "request timed out after "+defaultRequestTimeout.String()) ||
+		!strings.Contains(view, "'r' to retry") {
+		t.Fatalf("View() doesn't report the timeout:\n%s", view)
+	}
+
+	timingOut = false
+	next, cmd := m.Update(keyMsg("r"))
+	m = next.(Model)
+	if m.err != nil || cmd == nil {
+		t.Fatalf("err = %v after retrying, want it dismissed and the pods re-fetched", m.err)
+	}
+	m = update(t, m, m.loadPods()())
+	if len(m.pods) != 1 {
+		t.Errorf("pods = %d after retrying, want 1", len(m.pods))
+	}
+}
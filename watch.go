@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// watchCondition is one of the predefined conditions a pod watch waits for
+// met sees every pod of the Etcd by name and how many replicas the StatefulSet wants
+type watchCondition struct {
+	description string
+	met         func(pods map[string]*corev1.Pod, replicas int) bool
+}
+
+// containersReady reports whether every container of a pod that isn't being deleted is ready
+func containersReady(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil || len(pod.Status.ContainerStatuses) == 0 {
+		return false
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if !status.Ready {
+			return false
+		}
+	}
+	return true
+}
+
+// watchAllReady is met once as many pods as there are replicas are ready, with no other pod left unready
+// Counting the replicas keeps a rollout from looking done while a deleted pod is yet to be recreated
+func watchAllReady() watchCondition {
+	return watchCondition{
+		description: "all members Ready",
+		met: func(pods map[string]*corev1.Pod, replicas int) bool {
+			for _, pod := range pods {
+				if !containersReady(pod) {
+					return false
+				}
+			}
+			return len(pods) > 0 && len(pods) >= replicas
+		},
+	}
+}
+
+// watchPodRunning is met once the named pod runs; a pod being deleted doesn't count, its replacement will
+func watchPodRunning(name string) watchCondition {
+	return watchCondition{
+		description: fmt.Sprintf("pod %s Running", name),
+		met: func(pods map[string]*corev1.Pod, _ int) bool {
+			pod, ok := pods[name]
+			return ok && pod.DeletionTimestamp == nil && pod.Status.Phase == corev1.PodRunning
+		},
+	}
+}
+
+// watchPodReady is met once every container of the named pod is ready
+func watchPodReady(name string) watchCondition {
+	return watchCondition{
+		description: fmt.Sprintf("pod %s Ready", name),
+		met: func(pods map[string]*corev1.Pod, _ int) bool {
+			pod, ok := pods[name]
+			return ok && containersReady(pod)
+		},
+	}
+}
+
+// watchConditions are the conditions offered for the selected pod, in the order the prompt numbers them
+func watchConditions(pod Pod) []watchCondition {
+	return []watchCondition{watchAllReady(), watchPodRunning(pod.Name), watchPodReady(pod.Name)}
+}
+
+// podWatch is the watch waiting for a condition, see startPodWatch
+// The pods are kept as the watch last reported them, so the condition is checked without listing again
+type podWatch struct {
+	id        int
+	condition watchCondition
+	watcher   watch.Interface // nil until the watch was opened
+	pods      map[string]*corev1.Pod
+	replicas  int
+}
+
+// podWatchStartedMsg carries the pods listed when a watch was opened, along with the watch continuing from them
+type podWatchStartedMsg struct {
+	id       int
+	watcher  watch.Interface
+	pods     []corev1.Pod
+	replicas int
+	err      error
+}
+
+// podWatchEventMsg carries one event of a watch; closed is set once the API server ended it
+type podWatchEventMsg struct {
+	id     int
+	event  watch.Event
+	closed bool
+}
+
+// openPodWatch lists the pods and watches them from the listed version, so no change in between is missed
+// The replicas come from the StatefulSet; without it the pods listed now are what all of them means
+func (m *Model) openPodWatch(id int) (podWatchStartedMsg, error) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+	pods, err := m.kubeClient.CoreV1().Pods(m.namespace).List(ctx, metav1.ListOptions{LabelSelector: m.podLabelSelector()})
+	if err != nil {
+		return podWatchStartedMsg{}, fmt.Errorf("failed to list etcd pods: %w", err)
+	}
+	replicas := len(pods.Items)
+	if sts, err := m.kubeClient.AppsV1().StatefulSets(m.namespace).Get(ctx, m.etcdName, metav1.GetOptions{}); err == nil && sts.Spec.Replicas != nil {
+		replicas = int(*sts.Spec.Replicas)
+	}
+	// The watch outlives the request timeout, it ends when stopped
+	watcher, err := m.kubeClient.CoreV1().Pods(m.namespace).Watch(context.Background(), metav1.ListOptions{
+		LabelSelector:   m.podLabelSelector(),
+		ResourceVersion: pods.ResourceVersion,
+	})
+	if err != nil {
+		return podWatchStartedMsg{}, fmt.Errorf("failed to watch etcd pods: %w", err)
+	}
+	return podWatchStartedMsg{id: id, watcher: watcher, pods: pods.Items, replicas: replicas}, nil
+}
+
+// loadPodWatch is a command that opens the watch with id asynchronously
+func (m *Model) loadPodWatch(id int) tea.Cmd {
+	return func() tea.Msg {
+		msg, err := retryFetch(func() (podWatchStartedMsg, error) {
+			return m.openPodWatch(id)
+		})
+		if err != nil {
+			return podWatchStartedMsg{id: id, err: err}
+		}
+		return msg
+	}
+}
+
+// nextWatchEvent is a command that waits for the next event of a watch
+func nextWatchEvent(id int, watcher watch.Interface) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-watcher.ResultChan()
+		return podWatchEventMsg{id: id, event: event, closed: !ok}
+	}
+}
+
+// promptPodWatch asks which condition to wait for, or stops the watch already running
+func (m *Model) promptPodWatch(pod Pod) tea.Cmd {
+	if m.podWatch != nil {
+		description := m.podWatch.condition.description
+		m.stopPodWatch()
+		return m.setStatus("stopped watching for " + description)
+	}
+	conditions := watchConditions(pod)
+	choices := make([]string, len(conditions))
+	for i, condition := range conditions {
+		choices[i] = fmt.Sprintf("%d: %s", i+1, condition.description)
+	}
+	return m.openPrompt("watch for "+strings.Join(choices, ", "), "1", func(m *Model, value string) tea.Cmd {
+		choice, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || choice < 1 || choice > len(conditions) {
+			return m.setStatus(fmt.Sprintf("no watch condition %q, pick 1-%d", value, len(conditions)))
+		}
+		return m.startPodWatch(conditions[choice-1])
+	})
+}
+
+// startPodWatch starts waiting for condition in the background; the footer shows it until it is met
+func (m *Model) startPodWatch(condition watchCondition) tea.Cmd {
+	m.podWatchID++
+	m.podWatch = &podWatch{id: m.podWatchID, condition: condition}
+	m.watchAlert = ""
+	return m.loadPodWatch(m.podWatchID)
+}
+
+// stopPodWatch ends the running watch; its events still in flight are dropped by id
+func (m *Model) stopPodWatch() {
+	if m.podWatch != nil && m.podWatch.watcher != nil {
+		m.podWatch.watcher.Stop()
+	}
+	m.podWatch = nil
+}
+
+// setPodWatch seeds the watch with the listed pods, which may meet the condition already
+func (m *Model) setPodWatch(msg podWatchStartedMsg) tea.Cmd {
+	if m.podWatch == nil || msg.id != m.podWatch.id {
+		if msg.watcher != nil {
+			msg.watcher.Stop()
+		}
+		return nil
+	}
+	if msg.err != nil {
+		m.podWatch = nil
+		return m.setStatus(msg.err.Error())
+	}
+	m.podWatch.watcher = msg.watcher
+	m.podWatch.replicas = msg.replicas
+	m.podWatch.pods = map[string]*corev1.Pod{}
+	for i := range msg.pods {
+		m.podWatch.pods[msg.pods[i].Name] = &msg.pods[i]
+	}
+	return m.checkPodWatch()
+}
+
+// applyWatchEvent updates the watched pods with an event and checks the condition again
+// The API server ends watches after a while, in which case the watch is opened anew
+func (m *Model) applyWatchEvent(msg podWatchEventMsg) tea.Cmd {
+	if m.podWatch == nil || msg.id != m.podWatch.id {
+		return nil
+	}
+	if msg.closed || msg.event.Type == watch.Error {
+		m.podWatch.watcher.Stop()
+		m.podWatch.watcher = nil
+		return m.loadPodWatch(msg.id)
+	}
+	pod, ok := msg.event.Object.(*corev1.Pod)
+	if !ok {
+		return nextWatchEvent(msg.id, m.podWatch.watcher)
+	}
+	if msg.event.Type == watch.Deleted {
+		delete(m.podWatch.pods, pod.Name)
+	} else {
+		m.podWatch.pods[pod.Name] = pod
+	}
+	return m.checkPodWatch()
+}
+
+// checkPodWatch alerts once the condition is met, otherwise waits for the next event
+func (m *Model) checkPodWatch() tea.Cmd {
+	w := m.podWatch
+	if !w.condition.met(w.pods, w.replicas) {
+		return nextWatchEvent(w.id, w.watcher)
+	}
+	m.stopPodWatch()
+	// The alert stays in the footer until the next key, it may be met while looking at another screen
+	m.watchAlert = fmt.Sprintf("%s at %s", w.condition.description, time.Now().Format(time.TimeOnly))
+	return tea.Batch(m.ringBell(), m.loadPods())
+}
+
+// ringBell is a command that rings the terminal bell, unless there is no terminal to ring as in tests
+func (m *Model) ringBell() tea.Cmd {
+	if m.bell == nil {
+		return nil
+	}
+	bell := m.bell
+	return func() tea.Msg {
+		fmt.Fprint(bell, "\a")
+		return nil
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestWatchConditions(t *testing.T) {
+	ready := testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd"))
+	starting := testPod("etcd-main-1", corev1.PodRunning, corev1.ContainerStatus{Name: "etcd"})
+	pending := testPod("etcd-main-2", corev1.PodPending)
+	deleting := testPod("etcd-main-1", corev1.PodRunning, runningContainer("etcd"))
+	deleting.DeletionTimestamp = &metav1.Time{}
+
+	tests := []struct {
+		name      string
+		condition watchCondition
+		pods      []*corev1.Pod
+		replicas  int
+		want      bool
+	}{
+		{"all ready", watchAllReady(), []*corev1.Pod{ready}, 1, true},
+		{"a member starting", watchAllReady(), []*corev1.Pod{ready, starting}, 2, false},
+		{"a member missing", watchAllReady(), []*corev1.Pod{ready}, 2, false},
+		{"a member deleting", watchAllReady(), []*corev1.Pod{ready, deleting}, 2, false},
+		{"running", watchPodRunning("etcd-main-1"), []*corev1.Pod{starting}, 0, true},
+		{"pending", watchPodRunning("etcd-main-2"), []*corev1.Pod{pending}, 0, false},
+		{"running while deleting", watchPodRunning("etcd-main-1"), []*corev1.Pod{deleting}, 0, false},
+		{"absent", watchPodRunning("etcd-main-1"), nil, 0, false},
+		{"ready", watchPodReady("etcd-main-0"), []*corev1.Pod{ready}, 0, true},
+		{"not ready", watchPodReady("etcd-main-1"), []*corev1.Pod{starting}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pods := map[string]*corev1.Pod{}
+			for _, pod := range tt.pods {
+				pods[pod.Name] = pod
+			}
+			if got := tt.condition.met(pods, tt.replicas); got != tt.want {
+				t.Errorf("%s met = %v, want %v", tt.condition.description, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPodWatch(t *testing.T) {
+	starting := testPod("etcd-main-1", corev1.PodRunning, corev1.ContainerStatus{Name: "etcd"})
+	m, client := newTestModel([]runtime.Object{
+		testStatefulSet(2, appsv1.StatefulSetStatus{}),
+		testPod("etcd-main-0", corev1.PodRunning, runningContainer("etcd")),
+		starting,
+	})
+	var bell bytes.Buffer
+	m.bell = &bell
+	m = update(t, m, m.loadPods()())
+
+	m = update(t, m, keyMsg("W"))
+	if m.prompt == nil || !strings.Contains(m.prompt.input.Prompt, "1: all members Ready") {
+		t.Fatalf("W didn't ask for the condition")
+	}
+	m.prompt.input.SetValue("1")
+	next, cmd := m.Update(keyMsg("enter"))
+	m = next.(Model)
+	if m.podWatch == nil || cmd == nil {
+		t.Fatal("choosing a condition didn't start a watch")
+	}
+	next, cmd = m.Update(cmd())
+	m = next.(Model)
+	if m.watchAlert != "" || cmd == nil {
+		t.Fatalf("watch met with a member still starting: %q", m.watchAlert)
+	}
+	if !strings.Contains(m.footerView(), "watching for all members Ready") {
+		t.Errorf("footer doesn't show the watch: %s", m.footerView())
+	}
+
+	// The second member becoming ready meets the condition
+	starting.Status.ContainerStatuses[0].Ready = true
+	if _, err := client.CoreV1().Pods(testNamespace).Update(context.Background(), starting, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("failed to update pod: %v", err)
+	}
+	next, cmd = m.Update(cmd())
+	m = next.(Model)
+	if m.podWatch != nil || !strings.HasPrefix(m.watchAlert, "all members Ready at ") {
+		t.Fatalf("watch not met: watch %v, alert %q", m.podWatch, m.watchAlert)
+	}
+	for _, msg := range cmd().(tea.BatchMsg) {
+		if msg != nil {
+			msg()
+		}
+	}
+	if bell.String() != "\a" {
+		t.Errorf("bell rang %q, want once", bell.String())
+	}
+	if !strings.Contains(m.footerView(), "met: all members Ready") {
+		t.Errorf("footer doesn't show the alert: %s", m.footerView())
+	}
+	m = update(t, m, keyMsg("j"))
+	if m.watchAlert != "" {
+		t.Errorf("a key didn't acknowledge the alert")
+	}
+}
+
+func TestPodWatchStop(t *testing.T) {
+	m, _ := newTestModel([]runtime.Object{testPod("etcd-main-0", corev1.PodPending)})
+	m = update(t, m, m.loadPods()())
+	cmd := m.startPodWatch(watchPodRunning("etcd-main-0"))
+	started := cmd()
+	m = update(t, m, keyMsg("W"))
+	if m.podWatch != nil || m.prompt != nil || !strings.Contains(m.status, "stopped watching for pod etcd-main-0 Running") {
+		t.Fatalf("W with a watch running: watch %v, status %q", m.podWatch, m.status)
+	}
+	// The watch opened for the stopped one is closed again on arrival
+	m = update(t, m, started)
+	if m.podWatch != nil {
+		t.Errorf("a stopped watch came back")
+	}
+}
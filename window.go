@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// logWindow limits the logs to a time range; the zero value shows the usual tail
+// The API only has a start, sinceTime, so lines after the end are cut off while reading
+type logWindow struct {
+	start time.Time
+	end   time.Time // zero reads up to now
+}
+
+// logWindowLayout renders the window the way parseLogWindow reads it back, in local time
+const logWindowLayout = "2006-01-02 15:04:05"
+
+// clockRange matches a window of two bare clock times, e.g. 14:00-14:10
+var clockRange = regexp.MustCompile(`^(\d{1,2}:\d{2}(?::\d{2})?)\s*-\s*(\d{1,2}:\d{2}(?::\d{2})?)$`)
+
+func (w logWindow) active() bool { return !w.start.IsZero() }
+
+func (w logWindow) String() string {
+	if !w.active() {
+		return ""
+	}
+	window := w.start.Local().Format(logWindowLayout) + " to "
+	if w.end.IsZero() {
+		return window + "now"
+	}
+	return window + w.end.Local().Format(logWindowLayout)
+}
+
+// parseLogTime reads the start of a window
+// Times without a zone, including bare clock times, are in the local timezone like the terminal clock;
+// a bare clock time later than now means yesterday, since logs can't come from the future
+func parseLogTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04Z07:00"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	for _, layout := range []string{logWindowLayout, "2006-01-02 15:04", "2006-01-02T15:04:05", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	if clock, ok := parseClock(value); ok {
+		t := onDay(clock, now)
+		if t.After(now) {
+			t = t.AddDate(0, 0, -1)
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, want e.g. 14:00, 2006-01-02 14:00 or RFC3339", value)
+}
+
+// parseLogEnd reads the end of a window starting at start
+// A bare clock time is on the start's day, or the next one when the window spans midnight, e.g. 23:50-00:10;
+// an end still to come is cut to now, so a window in progress shows what there is so far
+func parseLogEnd(value string, start, now time.Time) (time.Time, error) {
+	var end time.Time
+	if clock, ok := parseClock(strings.TrimSpace(value)); ok {
+		end = onDay(clock, start.In(now.Location()))
+		if end.Before(start) {
+			end = end.AddDate(0, 0, 1)
+		}
+	} else {
+		var err error
+		if end, err = parseLogTime(value, now); err != nil {
+			return time.Time{}, err
+		}
+	}
+	if end.After(now) {
+		end = now
+	}
+	return end, nil
+}
+
+// parseClock reads a bare clock time, e.g. 14:00 or 14:00:05
+func parseClock(value string) (time.Time, bool) {
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if clock, err := time.Parse(layout, value); err == nil {
+			return clock, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// onDay places a clock time on the day of day, in its timezone
+func onDay(clock, day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, day.Location())
+}
+
+// parseLogWindow reads a window typed as "START to END", "START..END" or "HH:MM-HH:MM"; a missing end reads up to now
+// An empty value clears the window
+func parseLogWindow(value string, now time.Time) (logWindow, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return logWindow{}, nil
+	}
+	start, end, found := strings.Cut(value, " to ")
+	if !found {
+		start, end, found = strings.Cut(value, "..")
+	}
+	if !found {
+		if match := clockRange.FindStringSubmatch(value); match != nil {
+			start, end = match[1], match[2]
+		}
+	}
+
+	var w logWindow
+	var err error
+	if w.start, err = parseLogTime(start, now); err != nil {
+		return logWindow{}, err
+	}
+	if w.start.After(now) {
+		return logWindow{}, fmt.Errorf("log window starts at %s, in the future", w.start.Local().Format(logWindowLayout))
+	}
+	if strings.TrimSpace(end) != "" && strings.TrimSpace(end) != "now" {
+		if w.end, err = parseLogEnd(end, w.start, now); err != nil {
+			return logWindow{}, err
+		}
+		if !w.end.After(w.start) {
+			return logWindow{}, fmt.Errorf("log window ends at %s, before it starts", w.end.Local().Format(logWindowLayout))
+		}
+	}
+	return w, nil
+}
+
+// copyLogsUntil copies timestamped log lines until the first one after end, then stops reading
+// Lines without a timestamp, such as a note from the kubelet, are kept
+func copyLogsUntil(dst io.Writer, src io.Reader, end time.Time) error {
+	reader := bufio.NewReader(src)
+	for {
+		line, err := reader.ReadString('\n')
+		if t, _, ok := splitLogTimestamp(strings.TrimSuffix(line, "\n")); ok && t.After(end) {
+			return nil
+		}
+		if _, writeErr := io.WriteString(dst, line); writeErr != nil {
+			return writeErr
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// promptLogWindow asks for the time range to show logs of, then fetches it
+func (m *Model) promptLogWindow() tea.Cmd {
+	return m.openPrompt("log window, e.g. 14:00-14:10 (local time, empty shows the tail)", m.logWindow.String(), func(m *Model, value string) tea.Cmd {
+		w, err := parseLogWindow(value, time.Now())
+		if err != nil {
+			return m.setStatus(err.Error())
+		}
+		m.logWindow = w
+		if m.state == ClusterLogsState {
+			return m.loadClusterLogs()
+		}
+		return m.reloadLogs()
+	})
+}
+
+// logWindowTitle marks a title with the window being shown, along with its timezone
+func (m *Model) logWindowTitle() string {
+	if !m.logWindow.active() {
+		return ""
+	}
+	return fmt.Sprintf(" (%s %s)", m.logWindow, m.logWindow.start.Local().Format("MST"))
+}
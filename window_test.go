@@ -0,0 +1,90 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestParseLogWindow(t *testing.T) {
+	now := time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC)
+	at := func(day, hour, minute int) time.Time { return time.Date(2026, 10, day, hour, minute, 0, 0, time.UTC) }
+	tests := []struct {
+		value   string
+		now     time.Time // zero is now
+		want    logWindow
+		wantErr string
+	}{
+		{value: "", want: logWindow{}},
+		{value: "09:00-09:10", want: logWindow{start: at(14, 9, 0), end: at(14, 9, 10)}},
+		{value: "14:00 - 14:10", want: logWindow{start: at(13, 14, 0), end: at(13, 14, 10)}},
+		{value: "23:50-00:10", want: logWindow{start: at(13, 23, 50), end: at(14, 0, 10)}},
+		{value: "23:50-00:10", now: at(14, 23, 55), want: logWindow{start: at(14, 23, 50), end: at(14, 23, 55)}},
+		{value: "09:25-09:40", want: logWindow{start: at(14, 9, 25), end: now}},
+		{value: "2026-10-12 08:00 to 09:00", want: logWindow{start: at(12, 8, 0), end: at(12, 9, 0)}},
+		{value: "2026-10-12 08:00", want: logWindow{start: at(12, 8, 0)}},
+		{value: "2026-10-12T08:00:00+02:00..2026-10-12T08:30:00+02:00", want: logWindow{start: at(12, 6, 0), end: at(12, 6, 30)}},
+		{value: "09:00 to now", want: logWindow{start: at(14, 9, 0)}},
+		{value: "2026-10-15 08:00", wantErr: "in the future"},
+		{value: "2026-10-12 08:00 to 2026-10-12 07:00", wantErr: "before it starts"},
+		{value: "yesterday", wantErr: "invalid time"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			current := now
+			if !tt.now.IsZero() {
+				current = tt.now
+			}
+			got, err := parseLogWindow(tt.value, current)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("parseLogWindow() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseLogWindow() error = %v", err)
+			}
+			if !got.start.Equal(tt.want.start) || !got.end.Equal(tt.want.end) {
+				t.Errorf("parseLogWindow() = %v to %v, want %v to %v", got.start, got.end, tt.want.start, tt.want.end)
+			}
+		})
+	}
+}
+
+func TestCopyLogsUntil(t *testing.T) {
+	logs := "2026-10-14T09:00:00Z first\n" +
+		"unable to retrieve container logs\n" +
+		"2026-10-14T09:10:00Z second\n" +
+		"2026-10-14T09:10:01Z third\n"
+	var out strings.Builder
+	if err := copyLogsUntil(&out, strings.NewReader(logs), time.Date(2026, 10, 14, 9, 10, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("copyLogsUntil() error = %v", err)
+	}
+	if want := "2026-10-14T09:00:00Z first\nunable to retrieve container logs\n2026-10-14T09:10:00Z second\n"; out.String() != want {
+		t.Errorf("copyLogsUntil() = %q, want the lines up to the end", out.String())
+	}
+}
+
+func TestLogWindowOptions(t *testing.T) {
+	m, client := newTestModel(nil)
+	start := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	m.logWindow = logWindow{start: start, end: start.Add(10 * time.Minute)}
+	m.logSince = time.Hour
+
+	if _, _, err := m.getPodLogs(testNamespace, "etcd-main-0", "etcd"); err != nil {
+		t.Fatalf("getPodLogs() error = %v", err)
+	}
+	var opts *corev1.PodLogOptions
+	for _, action := range client.Actions() {
+		if generic, ok := action.(k8stesting.GenericAction); ok && action.GetSubresource() == "log" {
+			opts, _ = generic.GetValue().(*corev1.PodLogOptions)
+		}
+	}
+	if opts == nil || opts.SinceTime == nil || !opts.SinceTime.Time.Equal(start) || opts.SinceSeconds != nil || opts.TailLines != nil || !opts.Timestamps {
+		t.Errorf("log options = %+v, want timestamps since the window start without a tail", opts)
+	}
+}